@@ -24,9 +24,12 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
 	"k8s.io/component-base/logs"
+	"k8s.io/klog/v2"
 
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cmctl/v2/internal/util"
 	"github.com/cert-manager/cmctl/v2/pkg/build"
 	"github.com/cert-manager/cmctl/v2/pkg/build/commands"
 )
@@ -35,21 +38,62 @@ func NewCertManagerCtlCommand(ctx context.Context, in io.Reader, out, err io.Wri
 	ctx = logf.NewContext(ctx, logf.Log)
 
 	logOptions := logs.NewOptions()
+	warnings := util.NewWarningHandler(err)
 
 	cmds := &cobra.Command{
 		Use:   build.Name(),
 		Short: "cert-manager CLI tool to manage and configure cert-manager resources",
 		Long: build.WithTemplate(`
-{{.BuildName}} is a CLI tool manage and configure cert-manager resources for Kubernetes`),
+{{.BuildName}} is a CLI tool manage and configure cert-manager resources for Kubernetes
+
+Exit codes:
+  0    success
+  1    unclassified error
+  2    a resource or component is not ready
+  3    invalid flags, arguments or input
+  4    a resource was not found
+  5    a server could not be reached
+  124  the command timed out
+
+Request tracing:
+  -v=6  log the URL, response code and duration of every API request
+  -v=7  also log request headers
+  -v=8  also log response headers and the request and response bodies, Secret data is redacted
+  -v=9  log every API request as a curl command and log longer bodies
+
+Environment variables:
+  CMCTL_NAMESPACE  default for --namespace
+  CMCTL_CONTEXT    default for --context
+  CMCTL_OUTPUT     default for --output, 'json' or 'yaml', on commands that
+                   accept the value; see the help of their --output flag
+  CMCTL_OFFLINE    default for --offline
+Flags given on the command line take precedence.`),
 		CompletionOptions: cobra.CompletionOptions{
 			DisableDefaultCmd: true,
 		},
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// Print API server warnings, e.g. about deprecated APIs, to
+			// stderr instead of logging them.
+			rest.SetDefaultWarningHandler(warnings)
 			return logf.ValidateAndApply(logOptions)
 		},
+		PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+			return warnings.Check()
+		},
 		SilenceErrors: true, // Errors are already logged when calling cmd.Execute()
 	}
 	cmds.SetUsageTemplate(usageTemplate())
+	cmds.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return util.ValidationError(err)
+	})
+	util.AddPagerFlag(cmds.PersistentFlags())
+	util.AddInteractiveFlag(cmds.PersistentFlags())
+	util.AddWarningFlags(cmds.PersistentFlags())
+	util.AddOfflineFlag(cmds.PersistentFlags())
+
+	// API request and response bodies are logged from -v=8 on, make sure
+	// they never contain private keys.
+	klog.SetLogFilter(util.RedactingLogFilter{})
 
 	{
 		var logFlags pflag.FlagSet
@@ -62,6 +106,7 @@ func NewCertManagerCtlCommand(ctx context.Context, in io.Reader, out, err io.Wri
 				// backwards compatibility we allow the "v" logging flag to be set without a value
 				// and default to "2" (which will result in the same behaviour as before).
 				f.NoOptDefVal = "2"
+				f.Usage += ". Use 6 to 9 to trace API requests, Secret data is redacted"
 				cmds.PersistentFlags().AddFlag(f)
 			default:
 				cmds.PersistentFlags().AddFlag(f)
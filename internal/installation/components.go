@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package installation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// ComponentLabel is the label with the name of a cert-manager component.
+	ComponentLabel = "app.kubernetes.io/component"
+	// VersionLabel is the label with the version of the Helm chart or manifest
+	// that a cert-manager resource was installed with.
+	VersionLabel = "app.kubernetes.io/version"
+)
+
+// Components are the Deployments of a cert-manager installation, by the
+// value of their ComponentLabel.
+var Components = []string{"controller", "webhook", "cainjector"}
+
+// Deployment is the Deployment of a cert-manager component.
+type Deployment struct {
+	appsv1.Deployment
+
+	// Component is the value of the ComponentLabel, e.g. "webhook".
+	Component string
+	// Container is the container that runs the cert-manager image.
+	Container corev1.Container
+	// Version is the tag of the cert-manager image, or the value of the
+	// VersionLabel if the image has no tag.
+	Version string
+}
+
+// ListDeployments returns the Deployments of the cert-manager components in
+// namespace, or in all namespaces if namespace is metav1.NamespaceAll.
+func ListDeployments(ctx context.Context, kubeClient kubernetes.Interface, namespace string) ([]Deployment, error) {
+	list, err := kubeClient.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: ComponentLabel + " in (" + strings.Join(Components, ",") + ")",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error when listing Deployments: %w", err)
+	}
+
+	var deploys []Deployment
+	for _, d := range list.Items {
+		if deploy, ok := ComponentDeployment(d); ok {
+			deploys = append(deploys, deploy)
+		}
+	}
+	return deploys, nil
+}
+
+// ComponentDeployment describes deploy as a cert-manager component. Other
+// projects use the same well-known component labels, so it returns false if
+// none of the containers of deploy runs a cert-manager image.
+func ComponentDeployment(deploy appsv1.Deployment) (Deployment, bool) {
+	for _, c := range deploy.Spec.Template.Spec.Containers {
+		if !strings.Contains(c.Image, "cert-manager-") {
+			continue
+		}
+
+		version := ImageVersion(c.Image)
+		if version == "" {
+			version = deploy.Labels[VersionLabel]
+		}
+		return Deployment{
+			Deployment: deploy,
+			Component:  deploy.Labels[ComponentLabel],
+			Container:  c,
+			Version:    version,
+		}, true
+	}
+	return Deployment{}, false
+}
+
+// ImageVersion returns the tag of image, or "" if it has none.
+func ImageVersion(image string) string {
+	name := image[strings.LastIndex(image, "/")+1:]
+	if i := strings.Index(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		return name[i+1:]
+	}
+	return ""
+}
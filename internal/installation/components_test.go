@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package installation
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestComponentDeployment(t *testing.T) {
+	tests := map[string]struct {
+		images       []string
+		expOK        bool
+		expContainer string
+		expVersion   string
+	}{
+		"the version is the tag of the cert-manager image": {
+			images:       []string{"quay.io/jetstack/cert-manager-controller:v1.14.0"},
+			expOK:        true,
+			expContainer: "c0",
+			expVersion:   "v1.14.0",
+		},
+		"the version label is used for images without a tag": {
+			images:       []string{"example.com/sidecar:v1", "registry.local/cert-manager-webhook@sha256:aaa"},
+			expOK:        true,
+			expContainer: "c1",
+			expVersion:   "v1.13.3",
+		},
+		"other projects using the component labels are ignored": {
+			images: []string{"example.com/other-controller:v1"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			deploy := appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{ComponentLabel: "controller", VersionLabel: "v1.13.3"},
+				},
+			}
+			for i, image := range test.images {
+				deploy.Spec.Template.Spec.Containers = append(deploy.Spec.Template.Spec.Containers, corev1.Container{
+					Name:  "c" + string(rune('0'+i)),
+					Image: image,
+				})
+			}
+
+			got, ok := ComponentDeployment(deploy)
+			if ok != test.expOK {
+				t.Fatalf("unexpected ok, exp=%t got=%t", test.expOK, ok)
+			}
+			if !ok {
+				return
+			}
+			if got.Component != "controller" || got.Container.Name != test.expContainer || got.Version != test.expVersion {
+				t.Errorf("unexpected deployment, exp component=controller container=%s version=%s, got component=%s container=%s version=%s",
+					test.expContainer, test.expVersion, got.Component, got.Container.Name, got.Version)
+			}
+		})
+	}
+}
+
+func TestImageVersion(t *testing.T) {
+	tests := map[string]string{
+		"quay.io/jetstack/cert-manager-controller:v1.13.3":                "v1.13.3",
+		"localhost:5000/cert-manager-webhook":                             "",
+		"quay.io/jetstack/cert-manager-cainjector:v1.13.3@sha256:abcdef0": "v1.13.3",
+	}
+	for image, exp := range tests {
+		if got := ImageVersion(image); got != exp {
+			t.Errorf("ImageVersion(%q): expected %q, got %q", image, exp, got)
+		}
+	}
+}
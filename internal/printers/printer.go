@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+)
+
+// PrinterFormats describes the structured output formats supported by
+// NewPrinter, for use in the usage text of --output flags.
+const PrinterFormats = "'json', 'yaml', 'jsonpath=<template>', 'jsonpath-as-json=<template>' or 'jsonpath-file=<path>'"
+
+// Printer prints objects in one of the structured output formats. JSONPath
+// templates are evaluated against the document that '-o json' prints, with
+// the same semantics as kubectl: missing keys are ignored and no newline is
+// added after the output.
+type Printer struct {
+	format   string
+	jsonPath *jsonpath.JSONPath
+}
+
+// IsPrinterFormat returns true if output selects one of the formats supported
+// by NewPrinter.
+func IsPrinterFormat(output string) bool {
+	format, _, _ := strings.Cut(output, "=")
+	switch format {
+	case "json", "yaml", "jsonpath", "jsonpath-as-json", "jsonpath-file":
+		return true
+	}
+	return false
+}
+
+// NewPrinter returns a Printer for the output format given with --output.
+func NewPrinter(output string) (*Printer, error) {
+	format, template, hasTemplate := strings.Cut(output, "=")
+	switch format {
+	case "json", "yaml":
+		if hasTemplate {
+			return nil, fmt.Errorf("output format %q does not accept a template", format)
+		}
+		return &Printer{format: format}, nil
+	case "jsonpath", "jsonpath-as-json", "jsonpath-file":
+	default:
+		return nil, fmt.Errorf("unsupported output format %q, must be one of %s", output, PrinterFormats)
+	}
+
+	if template == "" {
+		return nil, fmt.Errorf("%s requires a template, e.g. %s='{.metadata.name}'", format, format)
+	}
+	if format == "jsonpath-file" {
+		data, err := os.ReadFile(template)
+		if err != nil {
+			return nil, fmt.Errorf("error reading JSONPath template file: %w", err)
+		}
+		template = string(data)
+	}
+
+	jp := jsonpath.New("output").AllowMissingKeys(true)
+	jp.EnableJSONOutput(format == "jsonpath-as-json")
+	if err := jp.Parse(template); err != nil {
+		return nil, fmt.Errorf("error parsing JSONPath template %q: %w", template, err)
+	}
+	return &Printer{format: format, jsonPath: jp}, nil
+}
+
+// Print writes obj to w.
+func (p *Printer) Print(w io.Writer, obj interface{}) error {
+	switch p.format {
+	case "json":
+		data, err := json.MarshalIndent(obj, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	case "yaml":
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+
+	generic, err := jsonObject(obj)
+	if err != nil {
+		return err
+	}
+	if err := p.jsonPath.Execute(w, generic); err != nil {
+		return fmt.Errorf("error executing JSONPath template: %w", err)
+	}
+	return nil
+}
+
+// jsonObject returns the document that '-o json' prints for obj as generic
+// maps and slices, which is what JSONPath expressions are evaluated against.
+func jsonObject(obj interface{}) (interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
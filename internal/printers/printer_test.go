@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printers
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPrinter(t *testing.T) {
+	type status struct {
+		NotAfter string `json:"notAfter,omitempty"`
+	}
+	type object struct {
+		Name   string `json:"name"`
+		Kind   string `json:"kind"`
+		Status status `json:"status"`
+	}
+	single := object{Name: "my-crt", Kind: "Certificate", Status: status{NotAfter: "2024-01-01T00:00:00Z"}}
+	list := []object{single, {Name: "letsencrypt", Kind: "ClusterIssuer"}}
+
+	templateFile := filepath.Join(t.TempDir(), "template")
+	if err := os.WriteFile(templateFile, []byte(`{.name}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		output string
+		obj    interface{}
+		expOut string
+		expErr bool
+	}{
+		"json is indented": {
+			output: "json",
+			obj:    single,
+			expOut: "{\n  \"name\": \"my-crt\",\n  \"kind\": \"Certificate\",\n  \"status\": {\n    \"notAfter\": \"2024-01-01T00:00:00Z\"\n  }\n}\n",
+		},
+		"yaml": {
+			output: "yaml",
+			obj:    single,
+			expOut: "kind: Certificate\nname: my-crt\nstatus:\n  notAfter: \"2024-01-01T00:00:00Z\"\n",
+		},
+		"jsonpath uses the JSON field names and adds no newline": {
+			output: "jsonpath={.status.notAfter}",
+			obj:    single,
+			expOut: "2024-01-01T00:00:00Z",
+		},
+		"jsonpath ignores missing keys": {
+			output: "jsonpath={.status.renewalTime}",
+			obj:    single,
+			expOut: "",
+		},
+		"jsonpath filters a list": {
+			output: `jsonpath={range [?(@.kind=="ClusterIssuer")]}{.name}{"\n"}{end}`,
+			obj:    list,
+			expOut: "letsencrypt\n",
+		},
+		"jsonpath-as-json prints the results as JSON": {
+			output: "jsonpath-as-json={[*].name}",
+			obj:    list,
+			expOut: "[\n    \"my-crt\",\n    \"letsencrypt\"\n]\n",
+		},
+		"jsonpath-file reads the template from a file": {
+			output: "jsonpath-file=" + templateFile,
+			obj:    single,
+			expOut: "my-crt",
+		},
+		"jsonpath without a template is an error": {
+			output: "jsonpath",
+			expErr: true,
+		},
+		"an invalid template is an error": {
+			output: "jsonpath={.name",
+			expErr: true,
+		},
+		"json does not accept a template": {
+			output: "json={.name}",
+			expErr: true,
+		},
+		"an unknown format is an error": {
+			output: "go-template={{.name}}",
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := NewPrinter(test.output)
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			var out bytes.Buffer
+			if err := p.Print(&out, test.obj); err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != test.expOut {
+				t.Errorf("unexpected output, exp=%q got=%q", test.expOut, out.String())
+			}
+		})
+	}
+}
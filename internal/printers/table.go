@@ -0,0 +1,353 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/pflag"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// TableOptions are the flags shared by all commands that print a table.
+type TableOptions struct {
+	// NoHeaders omits the header row.
+	NoHeaders bool
+	// Quiet only prints the name of every row.
+	Quiet bool
+	// Wide also prints the columns added with WideColumns. It is set by
+	// commands from '-o wide' rather than registered as a flag.
+	Wide bool
+	// SortBy is a column name or a JSONPath expression to sort the rows by.
+	SortBy string
+	// Filter is a comma-separated list of conditions that a row must match to
+	// be printed, e.g. 'ready=False,namespace!=kube-system'.
+	Filter string
+}
+
+// AddFlags registers the --no-headers, --quiet, --sort-by and --filter flags.
+func (o *TableOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(&o.NoHeaders, "no-headers", o.NoHeaders, "If true, don't print headers in table output.")
+	flags.BoolVarP(&o.Quiet, "quiet", "q", o.Quiet, "If true, only print the names of the listed resources, one per line.")
+	flags.StringVar(&o.SortBy, "sort-by", o.SortBy, "Sort rows by a column name, e.g. 'namespace' or 'expires', or by a JSONPath expression, e.g. '{.metadata.name}'. Empty values are sorted last.")
+	flags.StringVar(&o.Filter, "filter", o.Filter, "Only print rows that match all of the given comma-separated conditions, e.g. 'ready=False,namespace!=kube-system'. The left-hand side is a column name or a JSONPath expression, values are compared case-insensitively.")
+}
+
+// Validate checks the syntax of --sort-by and --filter. Column names are only
+// checked when the table is printed.
+func (o TableOptions) Validate() error {
+	if _, err := parseFilter(o.Filter); err != nil {
+		return err
+	}
+	if isJSONPath(o.SortBy) {
+		if _, err := parseJSONPath(o.SortBy); err != nil {
+			return fmt.Errorf("invalid --sort-by: %w", err)
+		}
+	}
+	return nil
+}
+
+// Table collects the rows of a table and prints them according to the
+// TableOptions.
+type Table struct {
+	options     TableOptions
+	headers     []string
+	rows        [][]string
+	objects     []interface{}
+	nameColumns []int
+	// wideFrom is the index of the first column that is only printed in
+	// wide mode, or -1 if there are none.
+	wideFrom int
+	// jsonObjects are the JSON representations of objects, against which
+	// JSONPath expressions are evaluated. They are converted on first use.
+	jsonObjects []interface{}
+}
+
+// NewTabWriter returns a *tabwriter.Writer with the parameters used for all
+// tabular output.
+func NewTabWriter(w io.Writer) *tabwriter.Writer {
+	return tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+}
+
+// NewTable returns an empty Table with the given headers. By default, the
+// first column is used as the name of a row.
+func (o TableOptions) NewTable(headers ...string) *Table {
+	return &Table{options: o, headers: headers, nameColumns: []int{0}, wideFrom: -1}
+}
+
+// WideColumns appends columns that are only printed with '-o wide', following
+// the kubectl convention of adding extra columns after the default ones.
+func (t *Table) WideColumns(headers ...string) *Table {
+	if t.wideFrom < 0 {
+		t.wideFrom = len(t.headers)
+	}
+	t.headers = append(t.headers, headers...)
+	return t
+}
+
+// SetNameColumns sets the columns that are joined with a '/' to form the name
+// of a row in quiet mode, e.g. the namespace and name columns. Empty and '-'
+// cells are skipped, so cluster-scoped resources are printed by name only.
+func (t *Table) SetNameColumns(columns ...int) *Table {
+	t.nameColumns = columns
+	return t
+}
+
+// AddRow adds a row, which must have a cell for every header, including the
+// wide columns.
+func (t *Table) AddRow(cells ...string) {
+	t.AddObjectRow(nil, cells...)
+}
+
+// AddObjectRow adds a row for obj, against which JSONPath expressions in
+// --sort-by and --filter are evaluated.
+func (t *Table) AddObjectRow(obj interface{}, cells ...string) {
+	t.rows = append(t.rows, cells)
+	t.objects = append(t.objects, obj)
+}
+
+// Print writes the table to w.
+func (t *Table) Print(w io.Writer) error {
+	rows, err := t.selectRows()
+	if err != nil {
+		return err
+	}
+
+	if t.options.Quiet {
+		for _, row := range rows {
+			var parts []string
+			for _, c := range t.nameColumns {
+				if c < len(row) && row[c] != "" && row[c] != "-" {
+					parts = append(parts, row[c])
+				}
+			}
+			if _, err := fmt.Fprintln(w, strings.Join(parts, "/")); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tw := NewTabWriter(w)
+	if !t.options.NoHeaders {
+		fmt.Fprintln(tw, strings.Join(t.columns(t.headers), "\t"))
+	}
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(t.columns(row), "\t"))
+	}
+	return tw.Flush()
+}
+
+// columns drops the wide columns of a row unless wide output is requested.
+func (t *Table) columns(row []string) []string {
+	if t.options.Wide || t.wideFrom < 0 || len(row) < t.wideFrom {
+		return row
+	}
+	return row[:t.wideFrom]
+}
+
+// selectRows returns the rows that match the filter, sorted as requested.
+func (t *Table) selectRows() ([][]string, error) {
+	conditions, err := parseFilter(t.options.Filter)
+	if err != nil {
+		return nil, err
+	}
+	var sortBy *jsonpath.JSONPath
+	if isJSONPath(t.options.SortBy) {
+		if sortBy, err = parseJSONPath(t.options.SortBy); err != nil {
+			return nil, fmt.Errorf("invalid --sort-by: %w", err)
+		}
+	}
+	t.jsonObjects = make([]interface{}, len(t.objects))
+
+	type entry struct {
+		row []string
+		key string
+	}
+	var entries []entry
+	for i, row := range t.rows {
+		matches := true
+		for _, c := range conditions {
+			value, err := t.value(i, c.key, c.jsonPath)
+			if err != nil {
+				return nil, err
+			}
+			if strings.EqualFold(value, c.value) == c.negate {
+				matches = false
+				break
+			}
+		}
+		if !matches {
+			continue
+		}
+
+		e := entry{row: row}
+		if t.options.SortBy != "" {
+			if e.key, err = t.value(i, t.options.SortBy, sortBy); err != nil {
+				return nil, err
+			}
+		}
+		entries = append(entries, e)
+	}
+
+	if t.options.SortBy != "" {
+		sort.SliceStable(entries, func(i, j int) bool {
+			return lessValue(entries[i].key, entries[j].key)
+		})
+	}
+
+	rows := make([][]string, len(entries))
+	for i, e := range entries {
+		rows[i] = e.row
+	}
+	return rows, nil
+}
+
+// value returns the value of key, a column name or a JSONPath expression, for
+// the row at index i. jsonPath is the parsed expression if key is one.
+func (t *Table) value(i int, key string, jsonPath *jsonpath.JSONPath) (string, error) {
+	if jsonPath != nil {
+		return t.jsonPathValue(i, jsonPath)
+	}
+
+	name := normalizeColumn(key)
+	for c, header := range t.headers {
+		if normalizeColumn(header) == name {
+			if c < len(t.rows[i]) {
+				return t.rows[i][c], nil
+			}
+			return "", nil
+		}
+	}
+
+	columns := make([]string, len(t.headers))
+	for c, header := range t.headers {
+		columns[c] = strings.ToLower(strings.ReplaceAll(header, " ", "-"))
+	}
+	return "", fmt.Errorf("unknown column %q, must be one of %s or a JSONPath expression", key, strings.Join(columns, ", "))
+}
+
+// normalizeColumn makes column names comparable regardless of case and of the
+// separator between words, so that 'KEY ALGORITHM' matches 'key-algorithm'.
+func normalizeColumn(name string) string {
+	return strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(name))
+}
+
+// lessValue orders numbers numerically and everything else lexically, which
+// also orders RFC3339 timestamps chronologically. Empty values such as '-' or
+// '<none>' are sorted last.
+func lessValue(a, b string) bool {
+	if emptyValue(a) || emptyValue(b) {
+		return !emptyValue(a) && emptyValue(b)
+	}
+	if x, err := strconv.ParseFloat(a, 64); err == nil {
+		if y, err := strconv.ParseFloat(b, 64); err == nil {
+			return x < y
+		}
+	}
+	return a < b
+}
+
+func emptyValue(v string) bool {
+	return v == "" || v == "-" || strings.HasPrefix(v, "<")
+}
+
+type condition struct {
+	key    string
+	value  string
+	negate bool
+	// jsonPath is the parsed key if it is a JSONPath expression.
+	jsonPath *jsonpath.JSONPath
+}
+
+// parseFilter parses a comma-separated list of 'key=value' and 'key!=value'
+// conditions.
+func parseFilter(filter string) ([]condition, error) {
+	if filter == "" {
+		return nil, nil
+	}
+
+	var conditions []condition
+	for _, expr := range strings.Split(filter, ",") {
+		var c condition
+		key, value, ok := strings.Cut(expr, "!=")
+		if ok {
+			c.negate = true
+		} else if key, value, ok = strings.Cut(expr, "=="); !ok {
+			key, value, ok = strings.Cut(expr, "=")
+		}
+		c.key, c.value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || c.key == "" {
+			return nil, fmt.Errorf("invalid --filter condition %q, must be of the form 'key=value' or 'key!=value'", expr)
+		}
+		if isJSONPath(c.key) {
+			var err error
+			if c.jsonPath, err = parseJSONPath(c.key); err != nil {
+				return nil, fmt.Errorf("invalid --filter condition %q: %w", expr, err)
+			}
+		}
+		conditions = append(conditions, c)
+	}
+	return conditions, nil
+}
+
+func isJSONPath(key string) bool {
+	return strings.HasPrefix(key, "{") || strings.HasPrefix(key, ".")
+}
+
+// parseJSONPath parses a JSONPath expression, which may omit the surrounding
+// braces like in kubectl's --sort-by.
+func parseJSONPath(expr string) (*jsonpath.JSONPath, error) {
+	if !strings.HasPrefix(expr, "{") {
+		expr = "{" + expr + "}"
+	}
+	jp := jsonpath.New("table").AllowMissingKeys(true)
+	if err := jp.Parse(expr); err != nil {
+		return nil, err
+	}
+	return jp, nil
+}
+
+// jsonPathValue evaluates jsonPath against the JSON representation of the
+// object of the row at index i.
+func (t *Table) jsonPathValue(i int, jsonPath *jsonpath.JSONPath) (string, error) {
+	if t.objects[i] == nil {
+		return "", errors.New("JSONPath expressions are not supported by this command")
+	}
+	if t.jsonObjects[i] == nil {
+		obj, err := jsonObject(t.objects[i])
+		if err != nil {
+			return "", err
+		}
+		t.jsonObjects[i] = obj
+	}
+
+	results, err := jsonPath.FindResults(t.jsonObjects[i])
+	if err != nil {
+		return "", err
+	}
+	if len(results) == 0 || len(results[0]) == 0 {
+		return "", nil
+	}
+	return fmt.Sprint(results[0][0].Interface()), nil
+}
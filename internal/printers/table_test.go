@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printers
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTable(t *testing.T) {
+	tests := map[string]struct {
+		options TableOptions
+		exp     string
+	}{
+		"table": {
+			exp: "NAMESPACE  NAME    READY\n" +
+				"-          issuer  True\n" +
+				"default    app     False\n",
+		},
+		"no headers": {
+			options: TableOptions{NoHeaders: true},
+			exp: "-        issuer  True\n" +
+				"default  app     False\n",
+		},
+		"wide": {
+			options: TableOptions{Wide: true},
+			exp: "NAMESPACE  NAME    READY  AGE\n" +
+				"-          issuer  True   5m\n" +
+				"default    app     False  1h\n",
+		},
+		"quiet": {
+			options: TableOptions{Quiet: true, NoHeaders: true},
+			exp:     "issuer\ndefault/app\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			table := test.options.NewTable("NAMESPACE", "NAME", "READY").SetNameColumns(0, 1).WideColumns("AGE")
+			table.AddRow("-", "issuer", "True", "5m")
+			table.AddRow("default", "app", "False", "1h")
+
+			var buf bytes.Buffer
+			if err := table.Print(&buf); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != test.exp {
+				t.Errorf("unexpected output:\n%q\nexpected:\n%q", buf.String(), test.exp)
+			}
+		})
+	}
+}
+
+func TestTableSortAndFilter(t *testing.T) {
+	type object struct {
+		Revision int `json:"revision"`
+	}
+
+	tests := map[string]struct {
+		options TableOptions
+		exp     string
+		expErr  bool
+	}{
+		"sort by column": {
+			options: TableOptions{SortBy: "expires"},
+			exp:     "b/two\na/one\nc/three\n",
+		},
+		"sort numerically by JSONPath": {
+			options: TableOptions{SortBy: "{.revision}"},
+			exp:     "c/three\nb/two\na/one\n",
+		},
+		"sort by JSONPath without braces": {
+			options: TableOptions{SortBy: ".revision"},
+			exp:     "c/three\nb/two\na/one\n",
+		},
+		"filter by column ignores case": {
+			options: TableOptions{Filter: "ready=false"},
+			exp:     "a/one\nc/three\n",
+		},
+		"filter by several conditions": {
+			options: TableOptions{Filter: "ready==False,namespace!=a"},
+			exp:     "c/three\n",
+		},
+		"filter by wide column": {
+			options: TableOptions{Filter: "key-algorithm=RSA"},
+			exp:     "b/two\n",
+		},
+		"filter by JSONPath": {
+			options: TableOptions{Filter: "{.revision}=2"},
+			exp:     "b/two\n",
+		},
+		"unknown column": {
+			options: TableOptions{SortBy: "age"},
+			expErr:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.options.Quiet = true
+			table := test.options.NewTable("NAMESPACE", "NAME", "READY", "EXPIRES").SetNameColumns(0, 1).WideColumns("KEY ALGORITHM")
+			table.AddObjectRow(object{Revision: 10}, "a", "one", "False", "2024-03-01T00:00:00Z", "ECDSA")
+			table.AddObjectRow(object{Revision: 2}, "b", "two", "True", "2024-02-01T00:00:00Z", "RSA")
+			table.AddObjectRow(object{Revision: 1}, "c", "three", "False", "<not issued>", "ECDSA")
+
+			var buf bytes.Buffer
+			err := table.Print(&buf)
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if buf.String() != test.exp {
+				t.Errorf("unexpected output:\n%q\nexpected:\n%q", buf.String(), test.exp)
+			}
+		})
+	}
+}
+
+func TestTableOptionsValidate(t *testing.T) {
+	tests := map[string]struct {
+		options TableOptions
+		expErr  bool
+	}{
+		"no options":              {},
+		"valid filter":            {options: TableOptions{Filter: "ready=False,{.kind}!=Issuer"}},
+		"filter without operator": {options: TableOptions{Filter: "ready"}, expErr: true},
+		"filter without key":      {options: TableOptions{Filter: "=True"}, expErr: true},
+		"invalid JSONPath":        {options: TableOptions{SortBy: "{.status"}, expErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := test.options.Validate(); (err != nil) != test.expErr {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/spf13/pflag"
+)
+
+// DefaultConcurrency is the number of items that bulk operations process at
+// the same time unless --concurrency is given.
+const DefaultConcurrency = 10
+
+// AddConcurrencyFlag registers the --concurrency flag, which limits the
+// number of items that a bulk operation processes at the same time.
+func AddConcurrencyFlag(fs *pflag.FlagSet, concurrency *int) {
+	fs.IntVar(concurrency, "concurrency", DefaultConcurrency, "The maximum number of resources that are processed at the same time.")
+}
+
+// ValidateConcurrency returns an error if concurrency is not a valid value
+// for --concurrency. Zero selects DefaultConcurrency.
+func ValidateConcurrency(concurrency int) error {
+	if concurrency < 0 {
+		return errors.New("--concurrency must not be negative")
+	}
+	return nil
+}
+
+// Bulk configures how ForEach processes the items of a bulk operation.
+type Bulk struct {
+	// Concurrency is the maximum number of items that are processed at the
+	// same time. Zero selects DefaultConcurrency.
+	Concurrency int
+	// Out receives the output of every item.
+	Out io.Writer
+	// Progress is incremented for every processed item, and is used to
+	// write to Out without mixing up the output with the progress line. It
+	// may be nil.
+	Progress *Progress
+}
+
+// ItemError is the error of a single item of a bulk operation.
+type ItemError struct {
+	Item string
+	Err  error
+}
+
+func (e *ItemError) Error() string {
+	return e.Item + ": " + e.Err.Error()
+}
+
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// BulkError is returned by ForEach when a bulk operation failed for some of
+// its items. The errors are ordered like the items, regardless of the order
+// in which the items were processed.
+type BulkError struct {
+	Total  int
+	Errors []*ItemError
+}
+
+func (e *BulkError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "failed for %d of %d item(s):", len(e.Errors), e.Total)
+	for _, err := range e.Errors {
+		fmt.Fprintf(&b, "\n  %s", err)
+	}
+	return b.String()
+}
+
+// Unwrap returns the errors of the failed items, so that the exit code is
+// derived from them.
+func (e *BulkError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// ForEach calls fn for every item, running at most b.Concurrency calls at the
+// same time. fn writes its output to the given writer, which is buffered and
+// copied to b.Out in the order of items as soon as all previous items are
+// done, so the output does not depend on which items finish first.
+//
+// A failing item does not stop the operation. The errors of all failed items
+// are returned as a *BulkError, with every item identified by name. When
+// there is only a single item, its error is returned as is. Items that were
+// not started before ctx was cancelled fail with the error of ctx.
+func ForEach[T any](ctx context.Context, b Bulk, items []T, name func(T) string, fn func(ctx context.Context, out io.Writer, item T) error) error {
+	concurrency := b.Concurrency
+	if concurrency == 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	var (
+		mu      sync.Mutex
+		outputs = make([]bytes.Buffer, len(items))
+		errs    = make([]error, len(items))
+		done    = make([]bool, len(items))
+		// next is the first item whose output has not been written yet.
+		next int
+	)
+	finish := func(i int, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs[i], done[i] = err, true
+		for ; next < len(items) && done[next]; next++ {
+			if outputs[next].Len() > 0 {
+				b.Progress.Printf(b.Out, "%s", outputs[next].Bytes())
+				outputs[next] = bytes.Buffer{}
+			}
+		}
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i := range items {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := ctx.Err()
+			if err == nil {
+				err = fn(ctx, &outputs[i], items[i])
+			}
+			finish(i, err)
+			b.Progress.Increment()
+		}(i)
+	}
+	wg.Wait()
+
+	if len(items) == 1 {
+		return errs[0]
+	}
+	bulkErr := &BulkError{Total: len(items)}
+	for i, err := range errs {
+		if err != nil {
+			bulkErr.Errors = append(bulkErr.Errors, &ItemError{Item: name(items[i]), Err: err})
+		}
+	}
+	if len(bulkErr.Errors) > 0 {
+		return bulkErr
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestForEach(t *testing.T) {
+	tests := map[string]struct {
+		items       []int
+		concurrency int
+		failing     map[int]bool
+		expOut      string
+		expErr      string
+	}{
+		"output is written in the order of the items": {
+			items:  []int{1, 2, 3, 4, 5},
+			expOut: "1\n2\n3\n4\n5\n",
+		},
+		"items are processed one at a time with a concurrency of 1": {
+			items:       []int{3, 2, 1},
+			concurrency: 1,
+			expOut:      "3\n2\n1\n",
+		},
+		"failing items do not stop the others and are reported in order": {
+			items:   []int{1, 2, 3, 4},
+			failing: map[int]bool{4: true, 2: true},
+			expOut:  "1\n3\n",
+			expErr:  "failed for 2 of 4 item(s):\n  item-2: boom\n  item-4: boom",
+		},
+		"the error of a single item is returned as is": {
+			items:   []int{1},
+			failing: map[int]bool{1: true},
+			expErr:  "boom",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := ForEach(context.Background(), Bulk{Concurrency: test.concurrency, Out: &out}, test.items,
+				func(i int) string { return "item-" + strconv.Itoa(i) },
+				func(_ context.Context, w io.Writer, i int) error {
+					// Later items finish first.
+					time.Sleep(time.Duration(10-i) * time.Millisecond)
+					if test.failing[i] {
+						return errors.New("boom")
+					}
+					fmt.Fprintln(w, i)
+					return nil
+				})
+
+			if got := out.String(); got != test.expOut {
+				t.Errorf("unexpected output, exp=%q got=%q", test.expOut, got)
+			}
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != test.expErr {
+				t.Errorf("unexpected error, exp=%q got=%q", test.expErr, gotErr)
+			}
+		})
+	}
+}
+
+func TestForEachConcurrency(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	items := make([]int, 20)
+
+	err := ForEach(context.Background(), Bulk{Concurrency: 3, Out: io.Discard}, items,
+		strconv.Itoa,
+		func(context.Context, io.Writer, int) error {
+			n := running.Add(1)
+			for {
+				m := maxRunning.Load()
+				if n <= m || maxRunning.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			running.Add(-1)
+			return nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := maxRunning.Load(); got > 3 {
+		t.Errorf("expected at most 3 items to be processed at the same time, got %d", got)
+	}
+}
+
+func TestBulkErrorExitCode(t *testing.T) {
+	err := &BulkError{Total: 2, Errors: []*ItemError{{Item: "a", Err: NotFoundError(errors.New("not found"))}}}
+	if got := ExitCodeFor(err); got != ExitCodeNotFound {
+		t.Errorf("expected the exit code of the failed item, got %d", got)
+	}
+}
+
+func TestForEachCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	called := false
+	err := ForEach(ctx, Bulk{Out: io.Discard}, []int{1, 2}, strconv.Itoa,
+		func(context.Context, io.Writer, int) error {
+			called = true
+			return nil
+		})
+	if called {
+		t.Error("expected no item to be processed after the context was cancelled")
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the error of the context, got %v", err)
+	}
+}
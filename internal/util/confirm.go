@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// ErrAborted is returned by Confirm when the user declines the operation.
+var ErrAborted = errors.New("aborted")
+
+// AddConfirmFlags registers the --yes flag, and --force as an alias of it,
+// which skip the confirmation prompt of destructive operations.
+func AddConfirmFlags(fs *pflag.FlagSet, yes *bool) {
+	fs.BoolVarP(yes, "yes", "y", *yes, "If true, do not ask for confirmation before making changes.")
+	fs.BoolVar(yes, "force", *yes, "Alias for --yes.")
+}
+
+// Confirm asks whether to proceed with the operation described by prompt,
+// e.g. "12 Certificates will be renewed.", and reads the answer from in. It
+// returns nil if yes is set or the user answers "y" or "yes", and an error
+// otherwise. If in is closed without an answer, as is the case when running
+// non-interactively, the returned error suggests passing --yes.
+func Confirm(in io.Reader, out io.Writer, yes bool, prompt string) error {
+	if yes {
+		return nil
+	}
+
+	fmt.Fprintf(out, "%s Continue? [y/N]: ", prompt)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to read confirmation: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	case "":
+		if errors.Is(err, io.EOF) {
+			fmt.Fprintln(out)
+			return ValidationError(errors.New("no confirmation received, use --yes to proceed without confirmation"))
+		}
+	}
+	return ErrAborted
+}
+
+// ConfirmStdinNames is used instead of Confirm when the names of the
+// resources were read from stdin, which then cannot also supply the answer to
+// the prompt. It returns nil if yes is set or at most one name was read, and
+// a validation error that asks for --yes otherwise. what describes the
+// operation, e.g. "approve 12 CertificateRequest(s)".
+func ConfirmStdinNames(yes bool, count int, what string) error {
+	if yes || count <= 1 {
+		return nil
+	}
+	return ValidationError(fmt.Errorf("refusing to %s read from stdin without confirmation, use --yes to proceed", what))
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := map[string]struct {
+		yes     bool
+		input   string
+		expErr  bool
+		expCode int
+		expOut  string
+	}{
+		"the prompt is skipped with --yes": {
+			yes: true,
+		},
+		"'y' confirms the operation": {
+			input:  "y\n",
+			expOut: "2 Certificates will be renewed. Continue? [y/N]: ",
+		},
+		"'YES' confirms the operation": {
+			input:  " YES \n",
+			expOut: "2 Certificates will be renewed. Continue? [y/N]: ",
+		},
+		"any other answer aborts the operation": {
+			input:   "n\n",
+			expErr:  true,
+			expCode: ExitCodeError,
+			expOut:  "2 Certificates will be renewed. Continue? [y/N]: ",
+		},
+		"an empty answer aborts the operation": {
+			input:   "\n",
+			expErr:  true,
+			expCode: ExitCodeError,
+			expOut:  "2 Certificates will be renewed. Continue? [y/N]: ",
+		},
+		"a closed input is a validation error": {
+			expErr:  true,
+			expCode: ExitCodeValidation,
+			expOut:  "2 Certificates will be renewed. Continue? [y/N]: \n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			err := Confirm(strings.NewReader(test.input), out, test.yes, "2 Certificates will be renewed.")
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err != nil {
+				if code := ExitCodeFor(err); code != test.expCode {
+					t.Errorf("unexpected exit code, exp=%d got=%d", test.expCode, code)
+				}
+				if test.expCode == ExitCodeError && !errors.Is(err, ErrAborted) {
+					t.Errorf("expected ErrAborted, got %v", err)
+				}
+			}
+			if got := out.String(); got != test.expOut {
+				t.Errorf("unexpected output, exp=%q got=%q", test.expOut, got)
+			}
+		})
+	}
+}
+
+func TestConfirmStdinNames(t *testing.T) {
+	tests := map[string]struct {
+		yes    bool
+		count  int
+		expErr bool
+	}{
+		"a single name needs no confirmation":  {count: 1},
+		"several names need --yes":             {count: 2, expErr: true},
+		"several names are confirmed by --yes": {yes: true, count: 2},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ConfirmStdinNames(test.yes, test.count, "approve 2 CertificateRequest(s)")
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+			if err != nil && ExitCodeFor(err) != ExitCodeValidation {
+				t.Errorf("expected a validation error, got exit code %d", ExitCodeFor(err))
+			}
+		})
+	}
+}
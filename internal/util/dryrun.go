@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DryRun is the strategy selected with the --dry-run flag. The zero value
+// means that changes are made.
+type DryRun string
+
+const (
+	// DryRunNone makes the changes.
+	DryRunNone DryRun = "none"
+	// DryRunClient only prints the changes, without sending them to the API
+	// server.
+	DryRunClient DryRun = "client"
+	// DryRunServer sends the changes to the API server as dry-run requests,
+	// so that they are validated and admitted but not persisted.
+	DryRunServer DryRun = "server"
+)
+
+// AddDryRunFlag registers the --dry-run flag. The flag may be given without a
+// value, or as --dry-run=true, which both select the client strategy.
+func AddDryRunFlag(fs *pflag.FlagSet, dryRun *DryRun) {
+	f := fs.VarPF(dryRun, "dry-run", "", `Must be "none", "server", or "client". If client strategy, only print the changes that would be made, without sending them. If server strategy, submit server-side requests without persisting the changes.`)
+	f.NoOptDefVal = string(DryRunClient)
+}
+
+func (d *DryRun) String() string {
+	if *d == "" {
+		return string(DryRunNone)
+	}
+	return string(*d)
+}
+
+func (d *DryRun) Set(s string) error {
+	switch s {
+	case "", "none", "false":
+		*d = DryRunNone
+	case "client", "true":
+		*d = DryRunClient
+	case "server":
+		*d = DryRunServer
+	default:
+		return fmt.Errorf(`invalid --dry-run value %q, must be "none", "server", or "client"`, s)
+	}
+	return nil
+}
+
+func (d *DryRun) Type() string {
+	return "string"
+}
+
+// Enabled returns true if any dry-run strategy is selected.
+func (d DryRun) Enabled() bool {
+	return d == DryRunClient || d == DryRunServer
+}
+
+// SkipRequest returns true if API writes must not be sent at all.
+func (d DryRun) SkipRequest() bool {
+	return d == DryRunClient
+}
+
+// Options returns the value of the DryRun field of the create, update, patch
+// and delete options of API writes.
+func (d DryRun) Options() []string {
+	if d == DryRunServer {
+		return []string{metav1.DryRunAll}
+	}
+	return nil
+}
+
+// Suffix returns the suffix that is appended to messages that report a
+// change, e.g. " (server dry run)".
+func (d DryRun) Suffix() string {
+	switch d {
+	case DryRunClient:
+		return " (dry run)"
+	case DryRunServer:
+		return " (server dry run)"
+	default:
+		return ""
+	}
+}
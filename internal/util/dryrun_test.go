@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestDryRunFlag(t *testing.T) {
+	tests := map[string]struct {
+		args       []string
+		expDryRun  DryRun
+		expErr     bool
+		expOptions []string
+		expSuffix  string
+	}{
+		"changes are made by default": {
+			expDryRun: "",
+		},
+		"the flag without a value selects the client strategy": {
+			args:      []string{"--dry-run"},
+			expDryRun: DryRunClient,
+			expSuffix: " (dry run)",
+		},
+		"the boolean form selects the client strategy": {
+			args:      []string{"--dry-run=true"},
+			expDryRun: DryRunClient,
+			expSuffix: " (dry run)",
+		},
+		"the server strategy sends dry-run requests": {
+			args:       []string{"--dry-run=server"},
+			expDryRun:  DryRunServer,
+			expOptions: []string{"All"},
+			expSuffix:  " (server dry run)",
+		},
+		"none disables dry-run": {
+			args:      []string{"--dry-run=none"},
+			expDryRun: DryRunNone,
+		},
+		"an unknown strategy is an error": {
+			args:   []string{"--dry-run=yes"},
+			expErr: true,
+		},
+		"the flag does not consume a following argument": {
+			args:      []string{"--dry-run", "server"},
+			expDryRun: DryRunClient,
+			expSuffix: " (dry run)",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var dryRun DryRun
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			AddDryRunFlag(fs, &dryRun)
+
+			err := fs.Parse(test.args)
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+			if test.expErr {
+				return
+			}
+			if dryRun != test.expDryRun {
+				t.Errorf("unexpected strategy, exp=%q got=%q", test.expDryRun, dryRun)
+			}
+			if got := dryRun.Options(); !reflect.DeepEqual(got, test.expOptions) {
+				t.Errorf("unexpected options, exp=%v got=%v", test.expOptions, got)
+			}
+			if got := dryRun.Suffix(); got != test.expSuffix {
+				t.Errorf("unexpected suffix, exp=%q got=%q", test.expSuffix, got)
+			}
+			if got := dryRun.Enabled(); got != (test.expSuffix != "") {
+				t.Errorf("unexpected Enabled, got=%t", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDays(t *testing.T) {
+	tests := map[string]struct {
+		in     string
+		exp    time.Duration
+		expErr bool
+	}{
+		"days":         {in: "90d", exp: 90 * 24 * time.Hour},
+		"hours":        {in: "36h", exp: 36 * time.Hour},
+		"invalid days": {in: "1.5d", expErr: true},
+		"missing unit": {in: "90", expErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseDays(test.in)
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error, expErr=%v got=%v", test.expErr, err)
+			}
+			if got != test.exp {
+				t.Errorf("unexpected duration, exp=%v got=%v", test.exp, got)
+			}
+		})
+	}
+}
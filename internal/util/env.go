@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// envFlags maps the environment variables that can be used instead of common
+// flags to the names of those flags. Variables that are restricted only set
+// flags that accept their value, see AllowOutputFromEnv.
+var envFlags = []struct {
+	env        string
+	flag       string
+	restricted bool
+}{
+	{"CMCTL_NAMESPACE", "namespace", false},
+	{"CMCTL_CONTEXT", "context", false},
+	{"CMCTL_OUTPUT", "output", true},
+	{"CMCTL_OFFLINE", "offline", false},
+}
+
+// envValuesAnnotation is the flag annotation that lists the values a
+// restricted environment variable may set the flag to.
+const envValuesAnnotation = "cmctl.cert-manager.io/env-values"
+
+// AllowOutputFromEnv lets CMCTL_OUTPUT set the --output flag in fs if it is
+// one of formats, which are 'json', 'yaml' or both. Commands whose --output
+// flag is not registered with it ignore CMCTL_OUTPUT.
+func AllowOutputFromEnv(fs *pflag.FlagSet, formats ...string) {
+	f := fs.Lookup("output")
+	f.Usage += fmt.Sprintf(" Defaults to $CMCTL_OUTPUT if it is %s.", quoteList(formats))
+	_ = fs.SetAnnotation("output", envValuesAnnotation, formats)
+}
+
+// quoteList returns values quoted and separated by commas and a final "or",
+// e.g. 'json' or 'yaml'.
+func quoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "'" + v + "'"
+	}
+	if len(quoted) < 2 {
+		return strings.Join(quoted, "")
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " or " + quoted[len(quoted)-1]
+}
+
+// offline is set by the global --offline flag.
+var offline bool
+
+// AddOfflineFlag registers the global --offline flag.
+func AddOfflineFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(&offline, "offline", offline, "If true, do not contact servers other than the Kubernetes API server. Only affects 'notify', which refuses to send its report, and 'inspect secret', which skips the CRL and OCSP revocation checks.")
+}
+
+// Offline returns true if the global --offline flag is set.
+func Offline() bool {
+	return offline
+}
+
+// ApplyEnv sets the flags in fs that were not given on the command line from
+// their CMCTL_* environment variable, e.g. --namespace from CMCTL_NAMESPACE.
+// Flags set from the environment are not marked as changed, so they act like
+// defaults. Restricted variables are ignored if the flag does not accept
+// their value.
+func ApplyEnv(fs *pflag.FlagSet, lookupEnv func(string) (string, bool)) error {
+	for _, ef := range envFlags {
+		f := fs.Lookup(ef.flag)
+		if f == nil || f.Changed {
+			continue
+		}
+		value, ok := lookupEnv(ef.env)
+		if !ok || value == "" {
+			continue
+		}
+		if ef.restricted && !slices.Contains(f.Annotations[envValuesAnnotation], value) {
+			continue
+		}
+		if err := f.Value.Set(value); err != nil {
+			return ValidationError(fmt.Errorf("invalid value %q in $%s: %w", value, ef.env, err))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestApplyEnv(t *testing.T) {
+	env := map[string]string{
+		"CMCTL_NAMESPACE": "from-env",
+		"CMCTL_CONTEXT":   "ci",
+		"CMCTL_OUTPUT":    "json",
+		"CMCTL_OFFLINE":   "true",
+	}
+	lookupEnv := func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+
+	var namespace, context, output string
+	var offline bool
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.StringVarP(&namespace, "namespace", "n", "", "")
+	fs.StringVar(&context, "context", "", "")
+	fs.StringVarP(&output, "output", "o", "", "")
+	AllowOutputFromEnv(fs, "json", "yaml")
+	fs.BoolVar(&offline, "offline", false, "")
+	if err := fs.Parse([]string{"-n", "from-flag"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ApplyEnv(fs, lookupEnv); err != nil {
+		t.Fatal(err)
+	}
+	if namespace != "from-flag" {
+		t.Errorf("expected the flag to take precedence, got namespace %q", namespace)
+	}
+	if context != "ci" || output != "json" || !offline {
+		t.Errorf("expected flags to be set from the environment, got context=%q output=%q offline=%t", context, output, offline)
+	}
+	if fs.Changed("output") {
+		t.Error("expected flags set from the environment not to be marked as changed")
+	}
+
+	for name, formats := range map[string][]string{
+		"without AllowOutputFromEnv":            nil,
+		"with other formats than $CMCTL_OUTPUT": {"yaml"},
+	} {
+		output = ""
+		fs = pflag.NewFlagSet("test", pflag.ContinueOnError)
+		fs.StringVarP(&output, "output", "o", "", "")
+		if formats != nil {
+			AllowOutputFromEnv(fs, formats...)
+		}
+		if err := ApplyEnv(fs, lookupEnv); err != nil {
+			t.Fatal(err)
+		}
+		if output != "" {
+			t.Errorf("%s: expected CMCTL_OUTPUT to be ignored, got output %q", name, output)
+		}
+	}
+
+	env["CMCTL_OFFLINE"] = "maybe"
+	fs = pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.BoolVar(&offline, "offline", false, "")
+	if err := ApplyEnv(fs, lookupEnv); ExitCodeFor(err) != ExitCodeValidation {
+		t.Errorf("expected a validation error for an invalid value, got %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+// Exit codes returned by all commands, so that scripts can branch on the
+// class of a failure.
+const (
+	// ExitCodeError is returned for errors that do not fall in any other class.
+	ExitCodeError = 1
+	// ExitCodeNotReady is returned when a resource or component is not ready.
+	ExitCodeNotReady = 2
+	// ExitCodeValidation is returned for invalid flags, arguments or input.
+	ExitCodeValidation = 3
+	// ExitCodeNotFound is returned when a resource does not exist.
+	ExitCodeNotFound = 4
+	// ExitCodeNetwork is returned when a server could not be reached.
+	ExitCodeNetwork = 5
+	// ExitCodeTimeout is returned when a command timed out.
+	ExitCodeTimeout = 124
+)
+
+// ExitError is an error with an explicit exit code.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+func withCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ExitError{Code: code, Err: err}
+}
+
+// NotReadyError marks err as a resource or component that is not ready. It
+// returns nil if err is nil.
+func NotReadyError(err error) error {
+	return withCode(ExitCodeNotReady, err)
+}
+
+// ValidationError marks err as invalid user input. It returns nil if err is
+// nil.
+func ValidationError(err error) error {
+	return withCode(ExitCodeValidation, err)
+}
+
+// NotFoundError marks err as a missing resource. It returns nil if err is nil.
+func NotFoundError(err error) error {
+	return withCode(ExitCodeNotFound, err)
+}
+
+// NetworkError marks err as a failure to reach a server. It returns nil if
+// err is nil.
+func NetworkError(err error) error {
+	return withCode(ExitCodeNetwork, err)
+}
+
+// ExitCodeFor returns the exit code for err. Errors that were not marked
+// explicitly are classified by their type.
+func ExitCodeFor(err error) int {
+	var exitErr *ExitError
+	var netErr net.Error
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &exitErr):
+		return exitErr.Code
+	case errors.Is(err, context.DeadlineExceeded):
+		return ExitCodeTimeout
+	case apierrors.IsNotFound(err):
+		return ExitCodeNotFound
+	case apierrors.IsInvalid(err), apierrors.IsBadRequest(err):
+		return ExitCodeValidation
+	case apierrors.IsServiceUnavailable(err), apierrors.IsTimeout(err), apierrors.IsServerTimeout(err),
+		errors.As(err, &netErr):
+		return ExitCodeNetwork
+	default:
+		return ExitCodeError
+	}
+}
+
+// hint is the remediation hint of the error reported by CheckErr.
+var hint string
+
+// CheckErr prints a user friendly error message and exits with the exit code
+// for err, if err is not nil. Commands must report errors through CheckErr.
+func CheckErr(err error) {
+	if err == nil {
+		return
+	}
+	// The first exit code that is set wins, so the generic exit code that
+	// cmdutil.CheckErr sets afterwards is ignored.
+	SetExitCode(err)
+	hint = HintFor(err)
+	cmdutil.CheckErr(unwrapMarkers(err))
+}
+
+// Hint returns the remediation hint of the error reported by CheckErr, to be
+// printed below the error message. It is empty if no error was reported or
+// the error has no hint.
+func Hint() string {
+	return hint
+}
+
+// unwrapMarkers removes the exit code and hint wrappers around err, so that
+// errors returned by the API server are formatted like kubectl does.
+func unwrapMarkers(err error) error {
+	for {
+		switch e := err.(type) {
+		case *ExitError:
+			err = e.Err
+		case *HintError:
+			err = e.Err
+		default:
+			return err
+		}
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestExitCodeFor(t *testing.T) {
+	notFound := apierrors.NewNotFound(schema.GroupResource{Group: "cert-manager.io", Resource: "certificates"}, "my-crt")
+
+	tests := map[string]struct {
+		err error
+		exp int
+	}{
+		"nil":                {err: nil, exp: 0},
+		"unclassified":       {err: errors.New("boom"), exp: ExitCodeError},
+		"not ready":          {err: NotReadyError(errors.New("not ready")), exp: ExitCodeNotReady},
+		"validation":         {err: ValidationError(errors.New("invalid")), exp: ExitCodeValidation},
+		"wrapped validation": {err: fmt.Errorf("context: %w", ValidationError(errors.New("invalid"))), exp: ExitCodeValidation},
+		"explicit not found": {err: NotFoundError(errors.New("missing")), exp: ExitCodeNotFound},
+		"API not found":      {err: fmt.Errorf("error when getting Certificate resource: %w", notFound), exp: ExitCodeNotFound},
+		"API bad request":    {err: apierrors.NewBadRequest("bad"), exp: ExitCodeValidation},
+		"API unavailable":    {err: apierrors.NewServiceUnavailable("down"), exp: ExitCodeNetwork},
+		"network":            {err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, exp: ExitCodeNetwork},
+		"explicit network":   {err: NetworkError(errors.New("OCSP responder unreachable")), exp: ExitCodeNetwork},
+		"timeout":            {err: fmt.Errorf("waiting: %w", context.DeadlineExceeded), exp: ExitCodeTimeout},
+		"explicit code wins": {err: NotReadyError(context.DeadlineExceeded), exp: ExitCodeNotReady},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := ExitCodeFor(test.err); got != test.exp {
+				t.Errorf("expected exit code %d, got %d", test.exp, got)
+			}
+		})
+	}
+
+	if NotReadyError(nil) != nil || ValidationError(nil) != nil {
+		t.Error("expected marking a nil error to return nil")
+	}
+}
@@ -21,16 +21,14 @@ import (
 	"errors"
 )
 
-// SetExitCode sets the exit code to 1 if the error is not a context.Canceled error.
+// SetExitCode sets the exit code for err, see ExitCodeFor, if the error is
+// not a context.Canceled error.
 func SetExitCode(err error) {
-	switch {
-	case err == nil || errors.Is(err, context.Canceled):
+	if err == nil || errors.Is(err, context.Canceled) {
 		// If the context was canceled, we don't need to set the exit code
-	case errors.Is(err, context.DeadlineExceeded):
-		SetExitCodeValue(124) // Indicate that there was a timeout error
-	default:
-		SetExitCodeValue(1) // Indicate that there was an error
+		return
 	}
+	SetExitCodeValue(ExitCodeFor(err))
 }
 
 // SetExitCode sets the exit code to 1 if the error is not a context.Canceled error.
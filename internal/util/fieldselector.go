@@ -0,0 +1,177 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/selection"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// FieldSelectorUsage is the help text of the --field-selector flag on
+// commands that select Certificates.
+const FieldSelectorUsage = "Selector (field query) to filter on, supports '=', '==', and '!='. " +
+	"Supported fields are metadata.name, metadata.namespace, spec.secretName, spec.commonName, " +
+	"spec.issuerRef.name, spec.issuerRef.kind, spec.issuerRef.group and status.conditions[<type>] " +
+	"(e.g. --field-selector spec.issuerRef.name=letsencrypt,status.conditions[Ready]=False)"
+
+const conditionFieldPrefix = "status.conditions["
+
+// certificateFields are the Certificate fields, other than conditions, that
+// can be used in a field selector.
+var certificateFields = map[string]func(*cmapi.Certificate) string{
+	"metadata.name":        func(crt *cmapi.Certificate) string { return crt.Name },
+	"metadata.namespace":   func(crt *cmapi.Certificate) string { return crt.Namespace },
+	"spec.secretName":      func(crt *cmapi.Certificate) string { return crt.Spec.SecretName },
+	"spec.commonName":      func(crt *cmapi.Certificate) string { return crt.Spec.CommonName },
+	"spec.issuerRef.name":  func(crt *cmapi.Certificate) string { return crt.Spec.IssuerRef.Name },
+	"spec.issuerRef.kind":  func(crt *cmapi.Certificate) string { return defaultString(crt.Spec.IssuerRef.Kind, cmapi.IssuerKind) },
+	"spec.issuerRef.group": func(crt *cmapi.Certificate) string { return defaultString(crt.Spec.IssuerRef.Group, "cert-manager.io") },
+}
+
+// serverSideFields are the fields the API server can filter custom resources
+// on.
+var serverSideFields = map[string]bool{
+	"metadata.name":      true,
+	"metadata.namespace": true,
+}
+
+// CertificateFieldSelector selects Certificates by field. The API server only
+// supports metadata.name and metadata.namespace for custom resources, so the
+// remaining terms are matched client-side after listing.
+type CertificateFieldSelector struct {
+	server fields.Selector
+	client fields.Selector
+}
+
+// ParseCertificateFieldSelector parses a field selector for Certificates. An
+// empty selector matches every Certificate.
+func ParseCertificateFieldSelector(s string) (*CertificateFieldSelector, error) {
+	sel, err := fields.ParseSelector(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid field selector %q: %w", s, err)
+	}
+
+	var server, client []fields.Selector
+	for _, req := range sel.Requirements() {
+		term, err := requirementSelector(req)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case serverSideFields[req.Field]:
+			server = append(server, term)
+		case certificateFields[req.Field] != nil, conditionType(req.Field) != "":
+			client = append(client, term)
+		default:
+			return nil, fmt.Errorf("field %q is not supported in a Certificate field selector", req.Field)
+		}
+	}
+
+	return &CertificateFieldSelector{
+		server: fields.AndSelectors(server...),
+		client: fields.AndSelectors(client...),
+	}, nil
+}
+
+// ServerSide returns the part of the selector that can be passed to the API
+// server in ListOptions.FieldSelector.
+func (s *CertificateFieldSelector) ServerSide() string {
+	if s == nil {
+		return ""
+	}
+	return s.server.String()
+}
+
+// ClientSide returns the part of the selector that is matched client-side
+// after listing.
+func (s *CertificateFieldSelector) ClientSide() string {
+	if s == nil {
+		return ""
+	}
+	return s.client.String()
+}
+
+// Matches returns true if crt matches every term of the selector.
+func (s *CertificateFieldSelector) Matches(crt *cmapi.Certificate) bool {
+	if s == nil {
+		return true
+	}
+	set := CertificateFields(crt)
+	return s.server.Matches(set) && s.client.Matches(set)
+}
+
+// Filter returns the Certificates in crts that match the selector.
+func (s *CertificateFieldSelector) Filter(crts []cmapi.Certificate) []cmapi.Certificate {
+	if s == nil || (s.server.Empty() && s.client.Empty()) {
+		return crts
+	}
+
+	var out []cmapi.Certificate
+	for i := range crts {
+		if s.Matches(&crts[i]) {
+			out = append(out, crts[i])
+		}
+	}
+	return out
+}
+
+// CertificateFields returns the fields of crt that can be used in a field
+// selector. Every condition is exposed as status.conditions[<type>] with its
+// status as value.
+func CertificateFields(crt *cmapi.Certificate) fields.Set {
+	set := fields.Set{}
+	for field, value := range certificateFields {
+		set[field] = value(crt)
+	}
+	for _, cond := range crt.Status.Conditions {
+		set[conditionFieldPrefix+string(cond.Type)+"]"] = string(cond.Status)
+	}
+	return set
+}
+
+// conditionType returns the condition type of a status.conditions[<type>]
+// field, or an empty string if field does not refer to a condition.
+func conditionType(field string) string {
+	if !strings.HasPrefix(field, conditionFieldPrefix) || !strings.HasSuffix(field, "]") {
+		return ""
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(field, conditionFieldPrefix), "]")
+}
+
+func requirementSelector(req fields.Requirement) (fields.Selector, error) {
+	switch req.Operator {
+	case selection.Equals, selection.DoubleEquals:
+		return fields.OneTermEqualSelector(req.Field, req.Value), nil
+	case selection.NotEquals:
+		return fields.OneTermNotEqualSelector(req.Field, req.Value), nil
+	default:
+		return nil, fmt.Errorf("operator %q is not supported in a field selector", req.Operator)
+	}
+}
+
+func defaultString(value, def string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+func TestCertificateFieldSelector(t *testing.T) {
+	crt := func(name, issuer string, ready cmmeta.ConditionStatus) cmapi.Certificate {
+		return cmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name},
+			Spec: cmapi.CertificateSpec{
+				SecretName: name + "-tls",
+				IssuerRef:  cmmeta.ObjectReference{Name: issuer},
+			},
+			Status: cmapi.CertificateStatus{
+				Conditions: []cmapi.CertificateCondition{{Type: cmapi.CertificateConditionReady, Status: ready}},
+			},
+		}
+	}
+	crts := []cmapi.Certificate{
+		crt("a", "letsencrypt", cmmeta.ConditionTrue),
+		crt("b", "letsencrypt", cmmeta.ConditionFalse),
+		crt("c", "vault", cmmeta.ConditionFalse),
+	}
+
+	tests := map[string]struct {
+		selector      string
+		expServer     string
+		expClientSide bool
+		expNames      []string
+		expErr        bool
+	}{
+		"an empty selector matches everything": {
+			expNames: []string{"a", "b", "c"},
+		},
+		"metadata fields are passed to the API server": {
+			selector:  "metadata.name=a",
+			expServer: "metadata.name=a",
+			expNames:  []string{"a"},
+		},
+		"conditions are matched client-side": {
+			selector:      "status.conditions[Ready]=False",
+			expClientSide: true,
+			expNames:      []string{"b", "c"},
+		},
+		"terms are combined": {
+			selector:      "metadata.namespace=ns,spec.issuerRef.name!=vault,status.conditions[Ready]==False",
+			expServer:     "metadata.namespace=ns",
+			expClientSide: true,
+			expNames:      []string{"b"},
+		},
+		"the issuer kind is defaulted": {
+			selector:      "spec.issuerRef.kind=Issuer,spec.secretName=c-tls",
+			expClientSide: true,
+			expNames:      []string{"c"},
+		},
+		"a missing condition does not match": {
+			selector:      "status.conditions[Issuing]=True",
+			expClientSide: true,
+		},
+		"unknown fields are rejected": {
+			selector: "spec.dnsNames=example.com",
+			expErr:   true,
+		},
+		"invalid selectors are rejected": {
+			selector: "metadata.name",
+			expErr:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			sel, err := ParseCertificateFieldSelector(test.selector)
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if got := sel.ServerSide(); got != test.expServer {
+				t.Errorf("unexpected server-side selector, exp=%q got=%q", test.expServer, got)
+			}
+			if got := sel.ClientSide() != ""; got != test.expClientSide {
+				t.Errorf("unexpected client-side selector %q", sel.ClientSide())
+			}
+
+			var got []string
+			for _, crt := range sel.Filter(crts) {
+				got = append(got, crt.Name)
+			}
+			if !reflect.DeepEqual(got, test.expNames) {
+				t.Errorf("unexpected selection, exp=%v got=%v", test.expNames, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"errors"
+	"net"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+)
+
+// HintError is an error with a hint that tells the user how to resolve it.
+// The hint is printed below the error message by CheckErr.
+type HintError struct {
+	Hint string
+	Err  error
+}
+
+func (e *HintError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *HintError) Unwrap() error {
+	return e.Err
+}
+
+// WithHint attaches a remediation hint to err. It returns nil if err is nil.
+func WithHint(err error, hint string) error {
+	if err == nil {
+		return nil
+	}
+	return &HintError{Hint: hint, Err: err}
+}
+
+// HintFor returns the remediation hint for err. Errors without an explicit
+// hint are classified by their type, an empty string is returned for errors
+// that have no known remedy.
+func HintFor(err error) string {
+	var hintErr *HintError
+	var netErr net.Error
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &hintErr):
+		return hintErr.Hint
+	case clientcmd.IsEmptyConfig(err):
+		return "No kubeconfig was found, set $KUBECONFIG or use --kubeconfig to select one."
+	case isWebhookError(err):
+		return build.WithTemplate("The cert-manager webhook could not be called, run '{{.BuildName}} check api' to verify that it is working.")
+	case meta.IsNoMatchError(err):
+		return build.WithTemplate("The cert-manager CRDs might not be installed, run '{{.BuildName}} check install' to verify the installation.")
+	case apierrors.IsNotFound(err):
+		return "Check the name of the resource, and select its namespace with -n/--namespace."
+	case apierrors.IsForbidden(err):
+		return "Check the permissions of the current user with 'kubectl auth can-i'."
+	case apierrors.IsUnauthorized(err):
+		return "Check the credentials of the current kubeconfig context, or select another one with --context."
+	case errors.As(err, &netErr):
+		return "Check that the API server is reachable, and that the current kubeconfig context is the intended one."
+	default:
+		return ""
+	}
+}
+
+// isWebhookError returns true if err was returned because the API server
+// could not call an admission or conversion webhook.
+func isWebhookError(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "failed calling webhook") || strings.Contains(msg, "conversion webhook for")
+}
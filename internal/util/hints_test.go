@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestHintFor(t *testing.T) {
+	crts := schema.GroupResource{Group: "cert-manager.io", Resource: "certificates"}
+
+	tests := map[string]struct {
+		err error
+		exp string
+	}{
+		"nil":          {err: nil, exp: ""},
+		"unclassified": {err: errors.New("boom"), exp: ""},
+		"explicit hint": {
+			err: fmt.Errorf("context: %w", WithHint(NotReadyError(errors.New("not ready")), "Wait a bit.")),
+			exp: "Wait a bit.",
+		},
+		"explicit hint wins": {
+			err: WithHint(apierrors.NewNotFound(crts, "my-crt"), "Create it first."),
+			exp: "Create it first.",
+		},
+		"not found": {
+			err: fmt.Errorf("error when getting Certificate resource: %w", apierrors.NewNotFound(crts, "my-crt")),
+			exp: "-n/--namespace",
+		},
+		"forbidden": {
+			err: apierrors.NewForbidden(crts, "my-crt", errors.New("denied")),
+			exp: "kubectl auth can-i",
+		},
+		"webhook": {
+			err: apierrors.NewInternalError(errors.New(`failed calling webhook "webhook.cert-manager.io": x509: certificate signed by unknown authority`)),
+			exp: "check api",
+		},
+		"CRDs not installed": {
+			err: &meta.NoKindMatchError{GroupKind: schema.GroupKind{Group: "cert-manager.io", Kind: "Certificate"}},
+			exp: "check install",
+		},
+		"network": {
+			err: &net.OpError{Op: "dial", Err: errors.New("connection refused")},
+			exp: "API server is reachable",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := HintFor(test.err)
+			if test.exp == "" && got != "" {
+				t.Errorf("expected no hint, got %q", got)
+			}
+			if !strings.Contains(got, test.exp) {
+				t.Errorf("expected hint to contain %q, got %q", test.exp, got)
+			}
+		})
+	}
+
+	if WithHint(nil, "hint") != nil {
+		t.Error("expected adding a hint to a nil error to return nil")
+	}
+}
+
+func TestUnwrapMarkers(t *testing.T) {
+	notFound := apierrors.NewNotFound(schema.GroupResource{Resource: "certificates"}, "my-crt")
+	if got := unwrapMarkers(WithHint(NotFoundError(notFound), "hint")); got != notFound {
+		t.Errorf("expected the API error to be unwrapped, got %#v", got)
+	}
+
+	wrapped := fmt.Errorf("context: %w", NotFoundError(notFound))
+	if got := unwrapMarkers(wrapped); got != wrapped {
+		t.Errorf("expected errors with context to be kept, got %#v", got)
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// Kind is a resource kind that can be named in arguments and flags.
+type Kind struct {
+	// Name is the name of the kind, e.g. Certificate.
+	Name string
+	// Resource is the resource of the kind.
+	Resource schema.GroupVersionResource
+	// Namespaced is true if resources of the kind are namespaced.
+	Namespaced bool
+	// Aliases are the plural and short names of the kind, matching the
+	// names that kubectl accepts.
+	Aliases []string
+}
+
+// CertManager returns true if the kind is a cert-manager resource.
+func (k Kind) CertManager() bool {
+	return k.Resource.Group == cmapi.SchemeGroupVersion.Group || k.Resource.Group == cmacme.SchemeGroupVersion.Group
+}
+
+var kinds = []Kind{
+	{cmapi.CertificateKind, cmapi.SchemeGroupVersion.WithResource("certificates"), true, []string{"certificates", "cert", "certs"}},
+	{cmapi.CertificateRequestKind, cmapi.SchemeGroupVersion.WithResource("certificaterequests"), true, []string{"certificaterequests", "cr", "crs"}},
+	{cmapi.IssuerKind, cmapi.SchemeGroupVersion.WithResource("issuers"), true, []string{"issuers", "iss"}},
+	{cmapi.ClusterIssuerKind, cmapi.SchemeGroupVersion.WithResource("clusterissuers"), false, []string{"clusterissuers", "ciss"}},
+	{cmacme.OrderKind, cmacme.SchemeGroupVersion.WithResource("orders"), true, []string{"orders"}},
+	{cmacme.ChallengeKind, cmacme.SchemeGroupVersion.WithResource("challenges"), true, []string{"challenges"}},
+	{"CertificateSigningRequest", certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests"), false, []string{"certificatesigningrequests", "csr", "csrs"}},
+}
+
+// ParseKind returns the kind named by s. The kind name, its plural and short
+// names are accepted case-insensitively, optionally qualified with the group,
+// e.g. Certificate, certs, cr or challenges.acme.cert-manager.io.
+func ParseKind(s string) (Kind, error) {
+	name := strings.ToLower(s)
+	for _, k := range kinds {
+		name := strings.TrimSuffix(name, "."+k.Resource.Group)
+		if name == strings.ToLower(k.Name) {
+			return k, nil
+		}
+		for _, alias := range k.Aliases {
+			if name == alias {
+				return k, nil
+			}
+		}
+	}
+	return Kind{}, fmt.Errorf("unknown resource kind %q", s)
+}
+
+// KindAliases returns the names of kind that a command named use can also be
+// called by, e.g. the aliases of "status certificate".
+func KindAliases(kind, use string) []string {
+	var aliases []string
+	for _, k := range kinds {
+		if k.Name != kind {
+			continue
+		}
+		for _, name := range append([]string{strings.ToLower(k.Name)}, k.Aliases...) {
+			if name != use {
+				aliases = append(aliases, name)
+			}
+		}
+	}
+	return aliases
+}
+
+// NormalizeIssuerKind returns the canonical name of an Issuer or
+// ClusterIssuer kind given as an alias, e.g. "ciss" for ClusterIssuer. Kinds
+// of external issuers, which are in another group than cert-manager.io, are
+// returned unchanged.
+func NormalizeIssuerKind(kind, group string) string {
+	if group != "" && group != cmapi.SchemeGroupVersion.Group {
+		return kind
+	}
+	k, err := ParseKind(kind)
+	if err != nil || (k.Name != cmapi.IssuerKind && k.Name != cmapi.ClusterIssuerKind) {
+		return kind
+	}
+	return k.Name
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseKind(t *testing.T) {
+	tests := map[string]struct {
+		in      string
+		expKind string
+		expErr  bool
+	}{
+		"kind":            {in: "Certificate", expKind: "Certificate"},
+		"lower case kind": {in: "clusterissuer", expKind: "ClusterIssuer"},
+		"plural":          {in: "Challenges", expKind: "Challenge"},
+		"short name":      {in: "cr", expKind: "CertificateRequest"},
+		"issuer":          {in: "iss", expKind: "Issuer"},
+		"cluster issuer":  {in: "ciss", expKind: "ClusterIssuer"},
+		"CSR":             {in: "csr", expKind: "CertificateSigningRequest"},
+		"qualified":       {in: "certs.cert-manager.io", expKind: "Certificate"},
+		"qualified ACME":  {in: "orders.acme.cert-manager.io", expKind: "Order"},
+		"wrong group":     {in: "orders.cert-manager.io", expErr: true},
+		"unknown":         {in: "secrets", expErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			k, err := ParseKind(test.in)
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error, expErr=%v got=%v", test.expErr, err)
+			}
+			if k.Name != test.expKind {
+				t.Errorf("unexpected kind, exp=%q got=%q", test.expKind, k.Name)
+			}
+		})
+	}
+}
+
+func TestKindAliases(t *testing.T) {
+	exp := []string{"certificates", "cert", "certs"}
+	if got := KindAliases("Certificate", "certificate"); !reflect.DeepEqual(got, exp) {
+		t.Errorf("unexpected aliases, exp=%v got=%v", exp, got)
+	}
+}
+
+func TestNormalizeIssuerKind(t *testing.T) {
+	tests := []struct {
+		kind, group, exp string
+	}{
+		{"ciss", "", "ClusterIssuer"},
+		{"issuer", "cert-manager.io", "Issuer"},
+		{"ClusterIssuer", "", "ClusterIssuer"},
+		{"cert", "", "cert"},
+		{"iss", "awspca.cert-manager.io", "iss"},
+		{"AWSPCAClusterIssuer", "awspca.cert-manager.io", "AWSPCAClusterIssuer"},
+	}
+	for _, test := range tests {
+		if got := NormalizeIssuerKind(test.kind, test.group); got != test.exp {
+			t.Errorf("NormalizeIssuerKind(%q, %q) = %q, exp %q", test.kind, test.group, got, test.exp)
+		}
+	}
+}
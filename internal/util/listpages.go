@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// ListPageSize is the maximum number of resources that are requested with a
+// single List call.
+const ListPageSize = 500
+
+// ListPages lists resources in pages of at most ListPageSize items, so that
+// the resources of a large cluster are neither sent by the API server nor
+// held in memory at once. listPage is called with the Limit and Continue
+// options of every page, processes the items of the page and returns the
+// continue token of the list it received, e.g.:
+//
+//	err := ListPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+//		list, err := client.CertmanagerV1().Certificates(ns).List(ctx, opts)
+//		if err != nil {
+//			return "", err
+//		}
+//		crts = append(crts, list.Items...)
+//		return list.Continue, nil
+//	})
+func ListPages(ctx context.Context, opts metav1.ListOptions, listPage func(opts metav1.ListOptions) (string, error)) error {
+	if opts.Limit == 0 {
+		opts.Limit = ListPageSize
+	}
+	for {
+		next, err := listPage(opts)
+		if apierrors.IsResourceExpired(err) {
+			return fmt.Errorf("the resources changed too much while they were listed page by page, please try again: %w", err)
+		}
+		if err != nil {
+			return err
+		}
+		if next == "" {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		opts.Continue = next
+	}
+}
+
+// LogListFilters logs with -v=2 or higher how the resources of a List call
+// are filtered: the label and field selectors in opts are applied by the API
+// server, while clientSide describes the filter that is applied to every
+// listed resource afterwards, if any. An empty namespace lists all namespaces.
+func LogListFilters(ctx context.Context, resource, namespace string, opts metav1.ListOptions, clientSide string) {
+	logf.FromContext(ctx, "list").V(2).Info("Listing "+resource,
+		"namespace", namespace,
+		"serverLabelSelector", opts.LabelSelector,
+		"serverFieldSelector", opts.FieldSelector,
+		"clientSideFilter", clientSide,
+	)
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestListPages(t *testing.T) {
+	t.Run("all pages are listed with the default page size", func(t *testing.T) {
+		var got []string
+		err := ListPages(context.Background(), metav1.ListOptions{LabelSelector: "app=test"}, func(opts metav1.ListOptions) (string, error) {
+			if opts.Limit != ListPageSize || opts.LabelSelector != "app=test" {
+				t.Errorf("unexpected options %+v", opts)
+			}
+			got = append(got, opts.Continue)
+			if len(got) == 3 {
+				return "", nil
+			}
+			return strconv.Itoa(len(got)), nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exp := []string{"", "1", "2"}; !reflect.DeepEqual(got, exp) {
+			t.Errorf("unexpected continue tokens, exp=%v got=%v", exp, got)
+		}
+	})
+
+	t.Run("a given limit is kept", func(t *testing.T) {
+		err := ListPages(context.Background(), metav1.ListOptions{Limit: 10}, func(opts metav1.ListOptions) (string, error) {
+			if opts.Limit != 10 {
+				t.Errorf("unexpected limit %d", opts.Limit)
+			}
+			return "", nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("errors stop listing", func(t *testing.T) {
+		calls := 0
+		expErr := errors.New("boom")
+		err := ListPages(context.Background(), metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+			calls++
+			return "next", expErr
+		})
+		if !errors.Is(err, expErr) || calls != 1 {
+			t.Errorf("unexpected result, err=%v calls=%d", err, calls)
+		}
+	})
+
+	t.Run("an expired continue token asks to try again", func(t *testing.T) {
+		err := ListPages(context.Background(), metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+			return "", apierrors.NewResourceExpired("too old")
+		})
+		if !apierrors.IsResourceExpired(err) {
+			t.Errorf("expected a wrapped ResourceExpired error, got %v", err)
+		}
+	})
+
+	t.Run("a cancelled context stops listing", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		err := ListPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+			cancel()
+			return "next", nil
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+}
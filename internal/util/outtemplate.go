@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/pflag"
+)
+
+// OutputFile is the data an --out-template is executed with for every file
+// that is written.
+type OutputFile struct {
+	// Namespace is the namespace of the exported resource.
+	Namespace string
+	// Name is the name of the exported resource.
+	Name string
+	// SecretName is the name of the Secret holding the key material.
+	SecretName string
+	// File is the name the file would get without a template, e.g. tls.crt.
+	File string
+}
+
+// OutTemplate renders the paths of the files written by export commands, so
+// that bulk exports land in a predictable directory structure.
+type OutTemplate struct {
+	tmpl *template.Template
+}
+
+// AddOutTemplateFlag adds the --out-template flag to fs.
+func AddOutTemplateFlag(fs *pflag.FlagSet, value *string) {
+	fs.StringVar(value, "out-template", *value, "Go template for the path of every written file, relative to --out. "+
+		"Available fields are .Namespace, .Name, .SecretName and .File (e.g. '{{.Namespace}}/{{.Name}}/{{.File}}')")
+}
+
+// ParseOutTemplate parses an --out-template. An empty template writes every
+// file with its default name directly into the output directory.
+func ParseOutTemplate(s string) (*OutTemplate, error) {
+	if s == "" {
+		return &OutTemplate{}, nil
+	}
+	tmpl, err := template.New("out-template").Option("missingkey=error").Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --out-template: %w", err)
+	}
+	return &OutTemplate{tmpl: tmpl}, nil
+}
+
+// Path returns the path of the file described by data. Relative paths are
+// joined with dir.
+func (t *OutTemplate) Path(dir string, data OutputFile) (string, error) {
+	if t == nil || t.tmpl == nil {
+		return filepath.Join(dir, data.File), nil
+	}
+
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("error when executing --out-template: %w", err)
+	}
+	path := buf.String()
+	if strings.TrimSpace(path) == "" || strings.HasSuffix(path, "/") {
+		return "", fmt.Errorf("--out-template must render a file name, got %q", path)
+	}
+
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path), nil
+	}
+	return filepath.Join(dir, path), nil
+}
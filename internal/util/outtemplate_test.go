@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestOutTemplatePath(t *testing.T) {
+	data := OutputFile{Namespace: "ns", Name: "my-crt", SecretName: "my-tls", File: "tls.crt"}
+
+	tests := map[string]struct {
+		template string
+		dir      string
+		expPath  string
+		expErr   bool
+	}{
+		"without a template the file is written to the directory": {
+			dir:     "out",
+			expPath: filepath.Join("out", "tls.crt"),
+		},
+		"relative paths are joined with the directory": {
+			template: "{{.Namespace}}/{{.Name}}/{{.File}}",
+			dir:      "out",
+			expPath:  filepath.Join("out", "ns", "my-crt", "tls.crt"),
+		},
+		"absolute paths are used as is": {
+			template: "/tmp/{{.SecretName}}.pem",
+			dir:      "out",
+			expPath:  "/tmp/my-tls.pem",
+		},
+		"a template rendering a directory is rejected": {
+			template: "{{.Namespace}}/",
+			expErr:   true,
+		},
+		"unknown fields are rejected": {
+			template: "{{.Kind}}",
+			expErr:   true,
+		},
+		"invalid templates are rejected": {
+			template: "{{.Name",
+			expErr:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tmpl, err := ParseOutTemplate(test.template)
+			var path string
+			if err == nil {
+				path, err = tmpl.Path(test.dir, data)
+			}
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+			if path != test.expPath {
+				t.Errorf("unexpected path, exp=%q got=%q", test.expPath, path)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// pagerDisabled is set by the global --no-pager flag.
+var pagerDisabled bool
+
+// AddPagerFlag registers the global --no-pager flag.
+func AddPagerFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(&pagerDisabled, "no-pager", pagerDisabled, "If true, do not pipe long output through a pager. The pager is taken from $CMCTL_PAGER or $PAGER and defaults to 'less'.")
+}
+
+// StartPager pipes everything written to the returned writer through a
+// pager, like git does, if out is a terminal and paging is not disabled.
+// Otherwise out itself is returned. The returned function must be called once
+// all output is written; it waits for the user to quit the pager.
+//
+// The pager is started with LESS=FRX unless LESS is set, so that output that
+// fits on one screen is printed directly.
+func StartPager(out io.Writer) (io.Writer, func()) {
+	noop := func() {}
+
+	if pagerDisabled || !isTerminal(out) {
+		return out, noop
+	}
+	args := pagerCommand(os.LookupEnv)
+	if len(args) == 0 {
+		return out, noop
+	}
+
+	// #nosec G204 -- The pager is chosen by the user running the command.
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdout = out
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		cmd.Env = append(cmd.Env, "LESS=FRX")
+	}
+	if _, ok := os.LookupEnv("LV"); !ok {
+		cmd.Env = append(cmd.Env, "LV=-c")
+	}
+
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return out, noop
+	}
+	if err := cmd.Start(); err != nil {
+		return out, noop
+	}
+
+	return in, func() {
+		in.Close()
+		_ = cmd.Wait()
+	}
+}
+
+// pagerCommand returns the pager to run, or nil if paging is disabled by
+// setting the pager to "" or "cat".
+func pagerCommand(lookupEnv func(string) (string, bool)) []string {
+	pager := "less"
+	if v, ok := lookupEnv("CMCTL_PAGER"); ok {
+		pager = v
+	} else if v, ok := lookupEnv("PAGER"); ok {
+		pager = v
+	}
+
+	args := strings.Fields(pager)
+	if len(args) == 0 || args[0] == "cat" {
+		return nil
+	}
+	return args
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestPagerCommand(t *testing.T) {
+	tests := map[string]struct {
+		env map[string]string
+		exp []string
+	}{
+		"less is the default":          {exp: []string{"less"}},
+		"PAGER is used":                {env: map[string]string{"PAGER": "more -s"}, exp: []string{"more", "-s"}},
+		"CMCTL_PAGER takes precedence": {env: map[string]string{"PAGER": "more", "CMCTL_PAGER": "less -S"}, exp: []string{"less", "-S"}},
+		"an empty pager disables it":   {env: map[string]string{"CMCTL_PAGER": "", "PAGER": "more"}},
+		"cat disables the pager":       {env: map[string]string{"PAGER": "cat"}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := pagerCommand(func(key string) (string, bool) {
+				v, ok := test.env[key]
+				return v, ok
+			})
+			if !reflect.DeepEqual(got, test.exp) {
+				t.Errorf("unexpected pager, exp=%q got=%q", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestStartPagerWithoutTerminal(t *testing.T) {
+	buf := &bytes.Buffer{}
+	out, done := StartPager(buf)
+	defer done()
+	if out != buf {
+		t.Error("expected output that is not a terminal to be written directly")
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+)
+
+// maxPickerItems is the number of items that Pick lists at once, the others
+// are found by filtering.
+const maxPickerItems = 20
+
+// interactiveDisabled is set by the global --no-interactive flag.
+var interactiveDisabled bool
+
+// AddInteractiveFlag registers the global --no-interactive flag.
+func AddInteractiveFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(&interactiveDisabled, "no-interactive", interactiveDisabled, "If true, never prompt for a resource when its name is omitted, but fail like in a script.")
+}
+
+// Interactive returns true if the user can be prompted, i.e. in and out are
+// terminals and --no-interactive is not set.
+func Interactive(in io.Reader, out io.Writer) bool {
+	if interactiveDisabled || !isTerminal(out) {
+		return false
+	}
+	f, ok := in.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// Pick asks the user to choose one of items, which are described by what,
+// e.g. "Certificate". The user either enters the number of an item or text
+// to narrow down the list to the items that fuzzily match it. An item is
+// returned as soon as it is the only match, unless explicit is true. Then the
+// user always has to select the item by its number or full name, which
+// commands that change the picked resource require.
+func Pick(in io.Reader, out io.Writer, what string, items []string, explicit bool) (string, error) {
+	if len(items) == 0 {
+		return "", fmt.Errorf("no %s found", what)
+	}
+	if len(items) == 1 && !explicit {
+		fmt.Fprintf(out, "Using %s %s\n", what, items[0])
+		return items[0], nil
+	}
+
+	reader := bufio.NewReader(in)
+	candidates := items
+	for {
+		for i, item := range candidates {
+			if i == maxPickerItems {
+				fmt.Fprintf(out, "  ... and %d more, type to filter\n", len(candidates)-maxPickerItems)
+				break
+			}
+			fmt.Fprintf(out, "%3d) %s\n", i+1, item)
+		}
+		fmt.Fprintf(out, "Select a %s by number, or type to filter: ", what)
+
+		answer, err := reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("failed to read selection: %w", err)
+		}
+		answer = strings.TrimSpace(answer)
+		if answer == "" && errors.Is(err, io.EOF) {
+			fmt.Fprintln(out)
+			return "", fmt.Errorf("no %s selected", what)
+		}
+
+		for _, item := range items {
+			if item == answer {
+				return item, nil
+			}
+		}
+		if n, convErr := strconv.Atoi(answer); convErr == nil && n >= 1 && n <= len(candidates) && n <= maxPickerItems {
+			return candidates[n-1], nil
+		}
+
+		matches := FuzzyFilter(answer, items)
+		switch {
+		case len(matches) == 0:
+			fmt.Fprintf(out, "No %s matches %q\n", what, answer)
+		case len(matches) == 1 && !explicit:
+			fmt.Fprintf(out, "Using %s %s\n", what, matches[0])
+			return matches[0], nil
+		default:
+			candidates = matches
+		}
+		if errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("no %s selected", what)
+		}
+	}
+}
+
+// FuzzyFilter returns the items that contain the characters of query in
+// order, ignoring case, e.g. "mcrt" matches "my-cert". Items that contain
+// query as a substring are listed first.
+func FuzzyFilter(query string, items []string) []string {
+	query = strings.ToLower(query)
+
+	var exact, fuzzy []string
+	for _, item := range items {
+		lower := strings.ToLower(item)
+		switch {
+		case strings.Contains(lower, query):
+			exact = append(exact, item)
+		case isSubsequence(query, lower):
+			fuzzy = append(fuzzy, item)
+		}
+	}
+	return append(exact, fuzzy...)
+}
+
+func isSubsequence(query, s string) bool {
+	for _, r := range query {
+		i := strings.IndexRune(s, r)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(string(r)):]
+	}
+	return true
+}
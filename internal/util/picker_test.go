@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFuzzyFilter(t *testing.T) {
+	items := []string{"my-cert", "web-tls", "metrics-cert", "mtls"}
+
+	tests := map[string]struct {
+		query string
+		exp   []string
+	}{
+		"an empty query matches everything": {
+			query: "",
+			exp:   items,
+		},
+		"substring matches are listed first": {
+			query: "tls",
+			exp:   []string{"web-tls", "mtls"},
+		},
+		"characters are matched in order": {
+			query: "mcrt",
+			exp:   []string{"my-cert", "metrics-cert"},
+		},
+		"matching ignores case": {
+			query: "WEB",
+			exp:   []string{"web-tls"},
+		},
+		"no match": {
+			query: "xyz",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := FuzzyFilter(test.query, items)
+			if !reflect.DeepEqual(got, test.exp) {
+				t.Errorf("unexpected matches, exp=%v got=%v", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestPick(t *testing.T) {
+	items := []string{"my-cert", "my-cert-2", "web-tls"}
+
+	tests := map[string]struct {
+		items    []string
+		explicit bool
+		input    string
+		exp      string
+		expErr   bool
+	}{
+		"an item is picked by number": {
+			items: items,
+			input: "3\n",
+			exp:   "web-tls",
+		},
+		"a unique match is picked": {
+			items: items,
+			input: "web\n",
+			exp:   "web-tls",
+		},
+		"the list is narrowed down until an item is picked": {
+			items: items,
+			input: "my\n2\n",
+			exp:   "my-cert-2",
+		},
+		"an exact name is picked even if it matches other items": {
+			items: items,
+			input: "my-cert\n",
+			exp:   "my-cert",
+		},
+		"a query without matches asks again": {
+			items: items,
+			input: "xyz\n1\n",
+			exp:   "my-cert",
+		},
+		"a single item is picked without asking": {
+			items: []string{"web-tls"},
+			exp:   "web-tls",
+		},
+		"a single item has to be selected explicitly": {
+			items:    []string{"web-tls"},
+			explicit: true,
+			input:    "1\n",
+			exp:      "web-tls",
+		},
+		"a unique match has to be selected explicitly": {
+			items:    items,
+			explicit: true,
+			input:    "web\n1\n",
+			exp:      "web-tls",
+		},
+		"a single item is not picked when the input is closed": {
+			items:    []string{"web-tls"},
+			explicit: true,
+			expErr:   true,
+		},
+		"no items is an error": {
+			expErr: true,
+		},
+		"closing the input is an error": {
+			items:  items,
+			input:  "my\n",
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := Pick(strings.NewReader(test.input), &out, "Certificate", test.items, test.explicit)
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+			if got != test.exp {
+				t.Errorf("unexpected pick, exp=%q got=%q", test.exp, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+const (
+	// progressRedrawInterval is how often the progress line is redrawn on a
+	// terminal.
+	progressRedrawInterval = 100 * time.Millisecond
+	// progressLogInterval is how often a progress line is logged when the
+	// output is not a terminal.
+	progressLogInterval = 10 * time.Second
+
+	progressBarWidth = 20
+)
+
+var spinnerFrames = []string{"|", "/", "-", "\\"}
+
+// Progress reports the progress of an operation that iterates over many
+// objects or waits on a condition. When w is a terminal, a single line with a
+// spinner (or a bar if the total is known) is redrawn in place. Otherwise a
+// plain line is logged periodically, so that operations that complete quickly
+// stay silent in scripts and CI logs.
+type Progress struct {
+	mu sync.Mutex
+
+	w        io.Writer
+	tty      bool
+	interval time.Duration
+	message  string
+	total    int
+	done     int
+	frame    int
+	start    time.Time
+	logged   bool
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	finished sync.WaitGroup
+}
+
+// NewProgress starts reporting the progress of an operation described by
+// message to w. A total of zero means the number of steps is unknown, e.g.
+// when waiting on a condition, and a spinner is shown instead of a bar. The
+// returned Progress must be stopped with Finish. All methods are no-ops on a
+// nil Progress, so callers can skip reporting for small operations.
+func NewProgress(w io.Writer, message string, total int) *Progress {
+	p := newProgress(w, message, total, isTerminal(w), time.Now())
+	p.finished.Add(1)
+	go p.run()
+	return p
+}
+
+func newProgress(w io.Writer, message string, total int, tty bool, start time.Time) *Progress {
+	interval := progressLogInterval
+	if tty {
+		interval = progressRedrawInterval
+	}
+	return &Progress{
+		w:        w,
+		tty:      tty,
+		interval: interval,
+		message:  message,
+		total:    total,
+		start:    start,
+		stop:     make(chan struct{}),
+	}
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+func (p *Progress) run() {
+	defer p.finished.Done()
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case now := <-ticker.C:
+			p.mu.Lock()
+			p.render(now)
+			p.mu.Unlock()
+		}
+	}
+}
+
+// Increment marks one more step of the operation as done.
+func (p *Progress) Increment() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done++
+}
+
+// Printf writes a formatted line to w, first erasing the progress line on a
+// terminal so that the output is not mixed up with it. The progress line is
+// redrawn on the next tick. On a nil Progress it writes to w directly.
+func (p *Progress) Printf(w io.Writer, format string, args ...interface{}) {
+	if p == nil {
+		fmt.Fprintf(w, format, args...)
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.tty {
+		fmt.Fprint(p.w, "\r\033[K")
+	}
+	fmt.Fprintf(w, format, args...)
+}
+
+// Finish stops reporting progress. On a terminal the progress line is erased;
+// otherwise a final line is logged if any progress was logged before. It is
+// safe to call Finish more than once.
+func (p *Progress) Finish() {
+	if p == nil {
+		return
+	}
+	p.stopOnce.Do(func() {
+		close(p.stop)
+		p.finished.Wait()
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.finish(time.Now())
+	})
+}
+
+func (p *Progress) render(now time.Time) {
+	if p.tty {
+		p.frame++
+		fmt.Fprintf(p.w, "\r\033[K%s", p.line(now))
+		return
+	}
+	fmt.Fprintln(p.w, p.line(now))
+	p.logged = true
+}
+
+func (p *Progress) finish(now time.Time) {
+	switch {
+	case p.tty:
+		fmt.Fprint(p.w, "\r\033[K")
+	case p.logged:
+		fmt.Fprintf(p.w, "%s: done after %s\n", p.message, elapsed(p.start, now))
+	}
+}
+
+// line returns the progress line for the current state.
+func (p *Progress) line(now time.Time) string {
+	var b strings.Builder
+	if p.tty {
+		b.WriteString(spinnerFrames[p.frame%len(spinnerFrames)])
+		b.WriteString(" ")
+	}
+	b.WriteString(p.message)
+
+	if p.total > 0 {
+		if p.tty {
+			filled := min(p.done, p.total) * progressBarWidth / p.total
+			fmt.Fprintf(&b, " [%s%s]", strings.Repeat("#", filled), strings.Repeat(".", progressBarWidth-filled))
+		} else {
+			b.WriteString(":")
+		}
+		fmt.Fprintf(&b, " %d/%d", p.done, p.total)
+	}
+
+	fmt.Fprintf(&b, " (%s elapsed)", elapsed(p.start, now))
+	return b.String()
+}
+
+func elapsed(start, now time.Time) time.Duration {
+	return now.Sub(start).Round(time.Second)
+}
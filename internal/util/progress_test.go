@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestProgressLine(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start.Add(12 * time.Second)
+
+	tests := map[string]struct {
+		tty   bool
+		total int
+		done  int
+		frame int
+		exp   string
+	}{
+		"a bar is shown on a terminal if the total is known": {
+			tty:   true,
+			total: 4,
+			done:  1,
+			exp:   "| Renewing [#####...............] 1/4 (12s elapsed)",
+		},
+		"a spinner is shown on a terminal if the total is unknown": {
+			tty:   true,
+			frame: 1,
+			exp:   "/ Renewing (12s elapsed)",
+		},
+		"a count is logged if the total is known": {
+			total: 4,
+			done:  4,
+			exp:   "Renewing: 4/4 (12s elapsed)",
+		},
+		"only the elapsed time is logged if the total is unknown": {
+			exp: "Renewing (12s elapsed)",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := newProgress(&bytes.Buffer{}, "Renewing", test.total, test.tty, start)
+			p.done = test.done
+			p.frame = test.frame
+			if got := p.line(now); got != test.exp {
+				t.Errorf("unexpected line, exp=%q got=%q", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestProgressFinish(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start.Add(30 * time.Second)
+
+	tests := map[string]struct {
+		tty    bool
+		render bool
+		exp    string
+	}{
+		"nothing is logged for operations that finish quickly": {},
+		"a final line is logged if progress was logged before": {
+			render: true,
+			exp:    "Waiting (30s elapsed)\nWaiting: done after 30s\n",
+		},
+		"the progress line is erased on a terminal": {
+			tty:    true,
+			render: true,
+			exp:    "\r\033[K| Waiting (30s elapsed)\r\033[K",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			p := newProgress(buf, "Waiting", 0, test.tty, start)
+			if test.render {
+				p.frame = -1
+				p.render(now)
+			}
+			p.finish(now)
+			if got := buf.String(); got != test.exp {
+				t.Errorf("unexpected output, exp=%q got=%q", test.exp, got)
+			}
+		})
+	}
+}
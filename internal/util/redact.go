@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"strings"
+)
+
+// redacted replaces Secret values in logged API request and response bodies.
+const redacted = `"<redacted>"`
+
+// RedactingLogFilter removes the values of Secret data from log messages. At
+// -v=8 and above, client-go logs the bodies of API requests and responses,
+// which would otherwise include private keys. It implements klog.LogFilter.
+type RedactingLogFilter struct{}
+
+// Filter redacts Secret data in the arguments of unformatted log calls.
+func (RedactingLogFilter) Filter(args []interface{}) []interface{} {
+	return redactArgs(args)
+}
+
+// FilterF redacts Secret data in the arguments of formatted log calls.
+func (RedactingLogFilter) FilterF(format string, args []interface{}) (string, []interface{}) {
+	return format, redactArgs(args)
+}
+
+// FilterS redacts Secret data in the values of structured log calls.
+func (RedactingLogFilter) FilterS(msg string, keysAndValues []interface{}) (string, []interface{}) {
+	return RedactSecretData(msg), redactArgs(keysAndValues)
+}
+
+func redactArgs(args []interface{}) []interface{} {
+	var out []interface{}
+	for i, arg := range args {
+		s, ok := arg.(string)
+		if !ok {
+			continue
+		}
+		if r := RedactSecretData(s); r != s {
+			if out == nil {
+				out = append([]interface{}{}, args...)
+			}
+			out[i] = r
+		}
+	}
+	if out == nil {
+		return args
+	}
+	return out
+}
+
+// RedactSecretData replaces the values in the "data" and "stringData" fields
+// of the Secrets in a JSON encoded body. Bodies that do not contain a Secret
+// or SecretList are returned unchanged. Bodies may be truncated, so the JSON
+// is scanned instead of decoded.
+func RedactSecretData(body string) string {
+	if !strings.Contains(body, `"kind":"Secret`) {
+		return body
+	}
+
+	var b strings.Builder
+	for {
+		i, field := nextDataField(body)
+		if i < 0 {
+			b.WriteString(body)
+			return b.String()
+		}
+		i += len(field)
+		b.WriteString(body[:i])
+		body = body[i:]
+
+		n := redactObject(&b, body)
+		body = body[n:]
+	}
+}
+
+// nextDataField returns the index and text of the first "data" or
+// "stringData" object field in s.
+func nextDataField(s string) (int, string) {
+	index, field := -1, ""
+	for _, f := range []string{`"data":{`, `"stringData":{`} {
+		if i := strings.Index(s, f); i >= 0 && (index < 0 || i < index) {
+			index, field = i, f
+		}
+	}
+	return index, field
+}
+
+// redactObject writes the members of the JSON object starting right after its
+// opening brace in s to b, with every string value redacted. It returns the
+// number of bytes of s that were consumed.
+func redactObject(b *strings.Builder, s string) int {
+	value := false
+	for i := 0; i < len(s); {
+		switch s[i] {
+		case '}':
+			return i
+		case ':':
+			value = true
+		case ',':
+			value = false
+		case '"':
+			n := stringLen(s[i:])
+			if value {
+				b.WriteString(redacted)
+			} else {
+				b.WriteString(s[i : i+n])
+			}
+			i += n
+			continue
+		}
+		b.WriteByte(s[i])
+		i++
+	}
+	return len(s)
+}
+
+// stringLen returns the length of the JSON string at the start of s,
+// including its quotes. A truncated string spans the rest of s.
+func stringLen(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return len(s)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+)
+
+func TestRedactSecretData(t *testing.T) {
+	tests := map[string]struct {
+		body string
+		exp  string
+	}{
+		"a Secret": {
+			body: `{"kind":"Secret","apiVersion":"v1","metadata":{"name":"tls"},"data":{"tls.crt":"Y3J0","tls.key":"a2V5"},"type":"kubernetes.io/tls"}`,
+			exp:  `{"kind":"Secret","apiVersion":"v1","metadata":{"name":"tls"},"data":{"tls.crt":"<redacted>","tls.key":"<redacted>"},"type":"kubernetes.io/tls"}`,
+		},
+		"a SecretList with stringData": {
+			body: `{"kind":"SecretList","items":[{"data":{"a":"YQ=="}},{"stringData":{"b":"say \"hi\""}}]}`,
+			exp:  `{"kind":"SecretList","items":[{"data":{"a":"<redacted>"}},{"stringData":{"b":"<redacted>"}}]}`,
+		},
+		"a truncated body": {
+			body: `{"kind":"Secret","data":{"tls.key":"a2V5a2V5 [truncated 2000 chars]`,
+			exp:  `{"kind":"Secret","data":{"tls.key":"<redacted>"`,
+		},
+		"a ConfigMap is not redacted": {
+			body: `{"kind":"ConfigMap","data":{"ca.crt":"cert"}}`,
+			exp:  `{"kind":"ConfigMap","data":{"ca.crt":"cert"}}`,
+		},
+		"a body without data": {
+			body: `{"kind":"Secret","metadata":{"name":"tls"}}`,
+			exp:  `{"kind":"Secret","metadata":{"name":"tls"}}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := RedactSecretData(test.body); got != test.exp {
+				t.Errorf("unexpected result,\nexp=%s\ngot=%s", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestRedactingLogFilter(t *testing.T) {
+	body := `{"kind":"Secret","data":{"tls.key":"a2V5"}}`
+	format, args := RedactingLogFilter{}.FilterF("%s: %s", []interface{}{"Response Body", body})
+	if format != "%s: %s" {
+		t.Errorf("unexpected format %q", format)
+	}
+	if exp := `{"kind":"Secret","data":{"tls.key":"<redacted>"}}`; args[1] != exp {
+		t.Errorf("unexpected argument, exp=%s got=%s", exp, args[1])
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// StdinArg is the argument that makes a command read resource names from
+// stdin.
+const StdinArg = "-"
+
+// IsStdinArg returns true if args consists of only StdinArg.
+func IsStdinArg(args []string) bool {
+	return len(args) == 1 && args[0] == StdinArg
+}
+
+// ReadNames reads newline-separated resource names from r, as printed by the
+// --quiet flag of list commands. Every line is either 'namespace/name' or
+// 'name', in which case defaultNamespace is used. A resource type prefix as
+// printed by 'kubectl get -o name', e.g. 'certificate.cert-manager.io/name',
+// is ignored. Empty lines and lines starting with '#' are skipped. Invalid
+// input is returned as a validation error.
+func ReadNames(r io.Reader, defaultNamespace string) ([]types.NamespacedName, error) {
+	var names []types.NamespacedName
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		item := strings.TrimSpace(scanner.Text())
+		if item == "" || strings.HasPrefix(item, "#") {
+			continue
+		}
+
+		name, err := parseName(item, defaultNamespace)
+		if err != nil {
+			return nil, ValidationError(fmt.Errorf("line %d: %w", line, err))
+		}
+		names = append(names, name)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read names from stdin: %w", err)
+	}
+	if len(names) == 0 {
+		return nil, ValidationError(errors.New("no names were read from stdin"))
+	}
+
+	return names, nil
+}
+
+func parseName(item, defaultNamespace string) (types.NamespacedName, error) {
+	parts := strings.Split(item, "/")
+
+	// Namespaces cannot contain dots, so a first part with a dot is the
+	// resource type printed by 'kubectl get -o name'.
+	if len(parts) > 1 && strings.Contains(parts[0], ".") {
+		parts = parts[1:]
+	}
+
+	switch {
+	case len(parts) == 1 && parts[0] != "":
+		return types.NamespacedName{Namespace: defaultNamespace, Name: parts[0]}, nil
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return types.NamespacedName{Namespace: parts[0], Name: parts[1]}, nil
+	default:
+		return types.NamespacedName{}, fmt.Errorf("invalid name %q, must be 'namespace/name' or 'name'", item)
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestReadNames(t *testing.T) {
+	tests := map[string]struct {
+		input  string
+		exp    []types.NamespacedName
+		expErr bool
+	}{
+		"namespaced and plain names": {
+			input: "ns/a\n\n  b  \n# comment\n",
+			exp:   []types.NamespacedName{{Namespace: "ns", Name: "a"}, {Namespace: "default", Name: "b"}},
+		},
+		"kubectl resource type prefixes are ignored": {
+			input: "certificate.cert-manager.io/a\n",
+			exp:   []types.NamespacedName{{Namespace: "default", Name: "a"}},
+		},
+		"too many parts": {
+			input:  "a/b/c\n",
+			expErr: true,
+		},
+		"missing name": {
+			input:  "ns/\n",
+			expErr: true,
+		},
+		"no names": {
+			input:  "\n# nothing\n",
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ReadNames(strings.NewReader(test.input), "default")
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, test.exp) {
+				t.Errorf("unexpected names, exp=%v got=%v", test.exp, got)
+			}
+		})
+	}
+}
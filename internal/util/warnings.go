@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/spf13/pflag"
+)
+
+// warningsAsErrors is set by the global --warnings-as-errors flag.
+var warningsAsErrors bool
+
+// AddWarningFlags registers the global --warnings-as-errors flag.
+func AddWarningFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(&warningsAsErrors, "warnings-as-errors", warningsAsErrors, "If true, treat warnings received from the API server, e.g. about deprecated cert-manager APIs, as errors and exit with a non-zero exit code.")
+}
+
+// WarningHandler prints the warnings sent by the API server, e.g. about the
+// use of a deprecated API version or field, as "Warning: <message>" lines
+// instead of log messages. Every distinct warning is only printed once.
+//
+// WarningHandler implements rest.WarningHandler.
+type WarningHandler struct {
+	out io.Writer
+
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+// NewWarningHandler returns a WarningHandler that prints to out.
+func NewWarningHandler(out io.Writer) *WarningHandler {
+	return &WarningHandler{
+		out:  out,
+		seen: map[string]bool{},
+	}
+}
+
+// HandleWarningHeader prints the message of a warning header. Only warnings
+// with the 299 warn-code are sent by the API server, others are ignored.
+func (h *WarningHandler) HandleWarningHeader(code int, agent string, message string) {
+	if code != 299 || len(message) == 0 {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.seen[message] {
+		return
+	}
+	h.seen[message] = true
+	fmt.Fprintf(h.out, "Warning: %s\n", message)
+}
+
+// Count returns the number of distinct warnings received.
+func (h *WarningHandler) Count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.seen)
+}
+
+// Check returns an error if warnings were received and --warnings-as-errors
+// is set.
+func (h *WarningHandler) Check() error {
+	if !warningsAsErrors {
+		return nil
+	}
+	if n := h.Count(); n > 0 {
+		return fmt.Errorf("%d warning(s) received from the API server, failing because --warnings-as-errors is set", n)
+	}
+	return nil
+}
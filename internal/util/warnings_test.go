@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWarningHandler(t *testing.T) {
+	var out bytes.Buffer
+	h := NewWarningHandler(&out)
+
+	h.HandleWarningHeader(299, "", "cert-manager.io/v1alpha2 Certificate is deprecated")
+	h.HandleWarningHeader(299, "", "cert-manager.io/v1alpha2 Certificate is deprecated")
+	h.HandleWarningHeader(199, "", "not sent by the API server")
+	h.HandleWarningHeader(299, "", "")
+	h.HandleWarningHeader(299, "", "spec.privateKey.rotationPolicy: unknown field")
+
+	exp := "Warning: cert-manager.io/v1alpha2 Certificate is deprecated\n" +
+		"Warning: spec.privateKey.rotationPolicy: unknown field\n"
+	if got := out.String(); got != exp {
+		t.Errorf("unexpected output, exp=%q got=%q", exp, got)
+	}
+	if got := h.Count(); got != 2 {
+		t.Errorf("unexpected count, exp=2 got=%d", got)
+	}
+
+	warningsAsErrors = false
+	if err := h.Check(); err != nil {
+		t.Errorf("expected no error without --warnings-as-errors, got %v", err)
+	}
+
+	warningsAsErrors = true
+	defer func() { warningsAsErrors = false }()
+	if err := h.Check(); err == nil {
+		t.Error("expected an error with --warnings-as-errors")
+	}
+	if err := NewWarningHandler(&out).Check(); err != nil {
+		t.Errorf("expected no error without warnings, got %v", err)
+	}
+}
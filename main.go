@@ -39,7 +39,8 @@ func main() {
 
 	// In cmctl, we are using cmdutil.CheckErr, a kubectl utility function that creates human readable
 	// error messages from errors. By default, this function will call os.Exit(1) if it receives an error.
-	// Instead, we want to do a soft exit, and use SetExitCode to set the correct exit code.
+	// Instead, we want to do a soft exit, and use SetExitCode to set the correct exit code, see
+	// util.CheckErr.
 	// Additionally, we make sure to output the final error message to stdout, as we do not want this
 	// message to be mixed with other log outputs from the execution of the command.
 	// To do this, we need to set a custom error handler.
@@ -51,6 +52,9 @@ func main() {
 			}
 			fmt.Fprint(os.Stdout, msg)
 		}
+		if hint := util.Hint(); len(hint) > 0 {
+			fmt.Fprintf(os.Stdout, "hint: %s\n", hint)
+		}
 
 		util.SetExitCodeValue(code)
 		runtime.Goexit() // Do soft exit (handle all defers, that should set correct exit code)
@@ -60,6 +64,6 @@ func main() {
 	cmd := ctlcmd.NewCertManagerCtlCommand(ctx, os.Stdin, os.Stdout, os.Stderr)
 
 	if err := cmd.Execute(); err != nil {
-		cmdutil.CheckErr(err)
+		util.CheckErr(err)
 	}
 }
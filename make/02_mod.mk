@@ -29,3 +29,13 @@ release: | $(NEEDS_CRANE) $(bin_dir)/scratch
 	$(MAKE) exe-publish
 
 	@echo "Release complete!"
+
+.PHONY: generate-explain-crds
+## Copy the CRDs of the cert-manager version cmctl is compiled against, used by
+## the explain command.
+## @category [shared] Generate/ Verify
+generate-explain-crds: | $(NEEDS_GO)
+	cp $$($(GO) list -m -f '{{.Dir}}' github.com/cert-manager/cert-manager)/deploy/crds/crd-*.yaml pkg/explain/crds/
+	chmod 644 pkg/explain/crds/*.yaml
+
+shared_generate_targets += generate-explain-crds
@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adopt
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/cert-manager/cmctl/v2/pkg/adopt/gateway"
+	"github.com/cert-manager/cmctl/v2/pkg/adopt/ingress"
+)
+
+func NewCmdAdopt(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	cmds := &cobra.Command{
+		Use:   "adopt",
+		Short: "Generate Certificates for TLS Secrets that are not managed by cert-manager",
+		Long:  `Generate cert-manager Certificates matching the TLS Secrets referenced by existing resources, e.g. Ingresses or Gateways, to onboard them onto cert-manager`,
+	}
+
+	cmds.AddCommand(ingress.NewCmdAdoptIngress(ctx, ioStreams))
+	cmds.AddCommand(gateway.NewCmdAdoptGateway(ctx, ioStreams))
+
+	return cmds
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1"
+	gwclient "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
+
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/adopt/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Generate cert-manager Certificates for the TLS Secrets referenced by a Gateway.
+
+A Certificate is generated for every Secret referenced by a listener that terminates TLS.
+Its DNS names, common name, private key and duration are taken from the listener
+hostnames and from the certificate currently stored in the Secret, so that cert-manager
+takes over the Secret without changing what is served. Secrets that are already managed
+by cert-manager are skipped.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Print Certificates for the TLS Secrets of the Gateway 'my-gateway', issued by the Issuer 'ca'
+{{.BuildName}} adopt gateway my-gateway --issuer ca
+
+# Create the Certificates using the issuer from the cert-manager annotations of the Gateway
+{{.BuildName}} adopt gateway my-gateway --namespace my-namespace --apply`)))
+)
+
+// NewCmdAdoptGateway returns a cobra command for adopting the TLS Secrets of a Gateway
+func NewCmdAdoptGateway(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := util.NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "gateway",
+		Short:   "Generate Certificates for the TLS Secrets of a Gateway",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate("Gateway", args)))
+			cmcmdutil.CheckErr(o.Complete())
+			cmcmdutil.CheckErr(run(ctx, o, args[0]))
+		},
+	}
+
+	o.AddFlags(cmd)
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+func run(ctx context.Context, o *util.Options, name string) error {
+	gwcl, err := gwclient.NewForConfig(o.RESTConfig)
+	if err != nil {
+		return err
+	}
+
+	gw, err := gwcl.GatewayV1().Gateways(o.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error when getting Gateway resource: %w", err)
+	}
+
+	issuerRef, err := o.IssuerRef(gw.Annotations)
+	if err != nil {
+		return err
+	}
+
+	return o.Adopt(ctx, issuerRef, entries(gw))
+}
+
+func entries(gw *gwapi.Gateway) []util.TLSEntry {
+	var out []util.TLSEntry
+	for _, l := range gw.Spec.Listeners {
+		if l.TLS == nil || (l.TLS.Mode != nil && *l.TLS.Mode != gwapi.TLSModeTerminate) {
+			continue
+		}
+
+		var hosts []string
+		if l.Hostname != nil {
+			hosts = []string{string(*l.Hostname)}
+		}
+
+		for _, ref := range l.TLS.CertificateRefs {
+			if ref.Group != nil && *ref.Group != "" {
+				continue
+			}
+			if ref.Kind != nil && *ref.Kind != "Secret" {
+				continue
+			}
+			ns := gw.Namespace
+			if ref.Namespace != nil {
+				ns = string(*ref.Namespace)
+			}
+			out = append(out, util.TLSEntry{
+				Namespace:  ns,
+				SecretName: string(ref.Name),
+				Hosts:      hosts,
+			})
+		}
+	}
+	return out
+}
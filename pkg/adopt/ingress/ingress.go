@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/adopt/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Generate cert-manager Certificates for the TLS Secrets referenced by an Ingress.
+
+A Certificate is generated for every Secret in the Ingress' spec.tls. Its DNS names,
+common name, private key and duration are taken from the hosts of the Ingress and from
+the certificate currently stored in the Secret, so that cert-manager takes over the
+Secret without changing what is served. Secrets that are already managed by
+cert-manager are skipped.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Print Certificates for the TLS Secrets of the Ingress 'my-app', issued by the ClusterIssuer 'letsencrypt'
+{{.BuildName}} adopt ingress my-app --issuer letsencrypt --issuer-kind ClusterIssuer
+
+# Create the Certificates using the issuer from the cert-manager annotations of the Ingress
+{{.BuildName}} adopt ingress my-app --namespace my-namespace --apply
+
+# Check that the API server accepts the Certificates, without creating them
+{{.BuildName}} adopt ingress my-app --namespace my-namespace --apply --dry-run=server`)))
+)
+
+// NewCmdAdoptIngress returns a cobra command for adopting the TLS Secrets of an Ingress
+func NewCmdAdoptIngress(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := util.NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "ingress",
+		Short:   "Generate Certificates for the TLS Secrets of an Ingress",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate("Ingress", args)))
+			cmcmdutil.CheckErr(o.Complete())
+			cmcmdutil.CheckErr(run(ctx, o, args[0]))
+		},
+	}
+
+	o.AddFlags(cmd)
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+func run(ctx context.Context, o *util.Options, name string) error {
+	ing, err := o.KubeClient.NetworkingV1().Ingresses(o.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error when getting Ingress resource: %w", err)
+	}
+
+	issuerRef, err := o.IssuerRef(ing.Annotations)
+	if err != nil {
+		return err
+	}
+
+	return o.Adopt(ctx, issuerRef, entries(ing))
+}
+
+func entries(ing *networkingv1.Ingress) []util.TLSEntry {
+	var out []util.TLSEntry
+	for _, tls := range ing.Spec.TLS {
+		if tls.SecretName == "" {
+			continue
+		}
+		out = append(out, util.TLSEntry{
+			Namespace:  ing.Namespace,
+			SecretName: tls.SecretName,
+			Hosts:      tls.Hosts,
+		})
+	}
+	return out
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// TLSEntry is a TLS Secret referenced by an Ingress or Gateway, together with
+// the hosts it is served for.
+type TLSEntry struct {
+	Namespace  string
+	SecretName string
+	Hosts      []string
+}
+
+// MergeEntries merges entries referencing the same Secret, so that a single
+// Certificate is generated for all of their hosts.
+func MergeEntries(entries []TLSEntry) []TLSEntry {
+	var out []TLSEntry
+	index := map[string]int{}
+	for _, entry := range entries {
+		key := entry.Namespace + "/" + entry.SecretName
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(out)
+			out = append(out, TLSEntry{Namespace: entry.Namespace, SecretName: entry.SecretName})
+			i = len(out) - 1
+		}
+		out[i].Hosts = append(out[i].Hosts, entry.Hosts...)
+	}
+	return out
+}
+
+// BuildCertificate returns a Certificate for entry that matches the existing
+// certificate, if any. The Certificate is named after its Secret.
+func BuildCertificate(entry TLSEntry, issuerRef cmmeta.ObjectReference, cert *x509.Certificate) *cmapi.Certificate {
+	crt := &cmapi.Certificate{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: cmapi.SchemeGroupVersion.String(),
+			Kind:       cmapi.CertificateKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      entry.SecretName,
+			Namespace: entry.Namespace,
+		},
+		Spec: cmapi.CertificateSpec{
+			SecretName: entry.SecretName,
+			IssuerRef:  issuerRef,
+		},
+	}
+
+	dnsNames := entry.Hosts
+	if cert != nil {
+		dnsNames = append(append([]string{}, dnsNames...), cert.DNSNames...)
+		crt.Spec.CommonName = cert.Subject.CommonName
+
+		switch pub := cert.PublicKey.(type) {
+		case *rsa.PublicKey:
+			crt.Spec.PrivateKey = &cmapi.CertificatePrivateKey{Algorithm: cmapi.RSAKeyAlgorithm, Size: pub.N.BitLen()}
+		case *ecdsa.PublicKey:
+			crt.Spec.PrivateKey = &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: pub.Curve.Params().BitSize}
+		case ed25519.PublicKey:
+			crt.Spec.PrivateKey = &cmapi.CertificatePrivateKey{Algorithm: cmapi.Ed25519KeyAlgorithm}
+		}
+
+		// Durations below an hour are most likely test certificates and would
+		// be rejected by cert-manager, fall back to the issuer's default.
+		if validity := cert.NotAfter.Sub(cert.NotBefore).Round(time.Hour); validity >= time.Hour {
+			crt.Spec.Duration = &metav1.Duration{Duration: validity}
+		}
+	}
+	crt.Spec.DNSNames = uniqueSorted(dnsNames)
+
+	return crt
+}
+
+func uniqueSorted(in []string) []string {
+	if len(in) == 0 {
+		return nil
+	}
+	seen := map[string]bool{}
+	var out []string
+	for _, s := range in {
+		if s == "" || seen[s] {
+			continue
+		}
+		seen[s] = true
+		out = append(out, s)
+	}
+	sort.Strings(out)
+	return out
+}
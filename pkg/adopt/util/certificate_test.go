@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"reflect"
+	"testing"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func TestMergeEntries(t *testing.T) {
+	got := MergeEntries([]TLSEntry{
+		{Namespace: "ns", SecretName: "a", Hosts: []string{"a.example.com"}},
+		{Namespace: "ns", SecretName: "b", Hosts: []string{"b.example.com"}},
+		{Namespace: "other", SecretName: "a", Hosts: []string{"other.example.com"}},
+		{Namespace: "ns", SecretName: "a", Hosts: []string{"www.a.example.com"}},
+	})
+
+	exp := []TLSEntry{
+		{Namespace: "ns", SecretName: "a", Hosts: []string{"a.example.com", "www.a.example.com"}},
+		{Namespace: "ns", SecretName: "b", Hosts: []string{"b.example.com"}},
+		{Namespace: "other", SecretName: "a", Hosts: []string{"other.example.com"}},
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("unexpected entries, exp=%v got=%v", exp, got)
+	}
+}
+
+func TestBuildCertificate(t *testing.T) {
+	issuerRef := cmmeta.ObjectReference{Name: "ca", Kind: cmapi.IssuerKind}
+	entry := TLSEntry{Namespace: "ns", SecretName: "tls", Hosts: []string{"b.example.com", "a.example.com"}}
+
+	pk, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	notBefore := time.Now()
+	cert := &x509.Certificate{
+		Subject:   pkix.Name{CommonName: "a.example.com"},
+		DNSNames:  []string{"a.example.com", "c.example.com"},
+		NotBefore: notBefore,
+		NotAfter:  notBefore.Add(90 * 24 * time.Hour),
+		PublicKey: pk.Public(),
+	}
+
+	t.Run("from hosts only", func(t *testing.T) {
+		crt := BuildCertificate(entry, issuerRef, nil)
+		if crt.Name != "tls" || crt.Namespace != "ns" || crt.Spec.SecretName != "tls" {
+			t.Errorf("unexpected object reference %s/%s with secret %q", crt.Namespace, crt.Name, crt.Spec.SecretName)
+		}
+		if exp := []string{"a.example.com", "b.example.com"}; !reflect.DeepEqual(crt.Spec.DNSNames, exp) {
+			t.Errorf("unexpected DNS names, exp=%v got=%v", exp, crt.Spec.DNSNames)
+		}
+		if crt.Spec.PrivateKey != nil || crt.Spec.Duration != nil {
+			t.Errorf("expected no private key or duration, got %v and %v", crt.Spec.PrivateKey, crt.Spec.Duration)
+		}
+		if crt.Spec.IssuerRef != issuerRef {
+			t.Errorf("unexpected issuer, exp=%v got=%v", issuerRef, crt.Spec.IssuerRef)
+		}
+	})
+
+	t.Run("matching the existing certificate", func(t *testing.T) {
+		crt := BuildCertificate(entry, issuerRef, cert)
+		if exp := []string{"a.example.com", "b.example.com", "c.example.com"}; !reflect.DeepEqual(crt.Spec.DNSNames, exp) {
+			t.Errorf("unexpected DNS names, exp=%v got=%v", exp, crt.Spec.DNSNames)
+		}
+		if crt.Spec.CommonName != "a.example.com" {
+			t.Errorf("unexpected common name %q", crt.Spec.CommonName)
+		}
+		exp := &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 256}
+		if !reflect.DeepEqual(crt.Spec.PrivateKey, exp) {
+			t.Errorf("unexpected private key, exp=%v got=%v", exp, crt.Spec.PrivateKey)
+		}
+		if crt.Spec.Duration == nil || crt.Spec.Duration.Duration != 90*24*time.Hour {
+			t.Errorf("unexpected duration %v", crt.Spec.Duration)
+		}
+	})
+}
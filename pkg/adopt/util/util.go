@@ -0,0 +1,192 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+)
+
+// Options is a struct to support the adopt subcommands
+type Options struct {
+	// IssuerName, IssuerKind and IssuerGroup reference the issuer of the
+	// generated Certificates. They default to the cert-manager annotations
+	// of the adopted resource.
+	IssuerName  string
+	IssuerKind  string
+	IssuerGroup string
+
+	// Apply creates the generated Certificates instead of printing them.
+	Apply bool
+
+	// DryRun is the --dry-run strategy used when the Certificates are
+	// created with --apply.
+	DryRun cmcmdutil.DryRun
+
+	PrintFlags *genericclioptions.PrintFlags
+	Printer    printers.ResourcePrinter
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams:  ioStreams,
+		PrintFlags: genericclioptions.NewPrintFlags("").WithDefaultOutput("yaml"),
+	}
+}
+
+// AddFlags registers the flags shared by all adopt subcommands
+func (o *Options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.IssuerName, "issuer", o.IssuerName, "Name of the issuer referenced by the generated Certificates. Defaults to the cert-manager.io/issuer or cert-manager.io/cluster-issuer annotation.")
+	cmd.Flags().StringVar(&o.IssuerKind, "issuer-kind", o.IssuerKind, "Kind of the issuer referenced by the generated Certificates, e.g. Issuer (iss) or ClusterIssuer (ciss).")
+	cmd.Flags().StringVar(&o.IssuerGroup, "issuer-group", o.IssuerGroup, "API group of the issuer referenced by the generated Certificates.")
+	cmd.Flags().BoolVar(&o.Apply, "apply", o.Apply, "If true, create the generated Certificates instead of printing them.")
+	cmcmdutil.AddDryRunFlag(cmd.Flags(), &o.DryRun)
+	o.PrintFlags.AddFlags(cmd)
+	cmcmdutil.AllowOutputFromEnv(cmd.Flags(), "json", "yaml")
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(kind string, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("the name of the %s has to be provided as argument", kind)
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("only one argument can be passed in: the name of the %s", kind)
+	}
+	if o.IssuerName == "" && (o.IssuerKind != "" || o.IssuerGroup != "") {
+		return errors.New("--issuer-kind and --issuer-group require --issuer to be set")
+	}
+	if o.DryRun.Enabled() && !o.Apply {
+		return errors.New("--dry-run can only be used with --apply, the Certificates are only printed otherwise")
+	}
+	o.IssuerKind = cmcmdutil.NormalizeIssuerKind(o.IssuerKind, o.IssuerGroup)
+	return nil
+}
+
+// Complete builds the printer
+func (o *Options) Complete() error {
+	var err error
+	o.Printer, err = o.PrintFlags.ToPrinter()
+	return err
+}
+
+// IssuerRef returns the issuer to reference in the generated Certificates.
+// Flags take precedence over the annotations of the adopted resource.
+func (o *Options) IssuerRef(annotations map[string]string) (cmmeta.ObjectReference, error) {
+	if o.IssuerName != "" {
+		return cmmeta.ObjectReference{Name: o.IssuerName, Kind: o.IssuerKind, Group: o.IssuerGroup}, nil
+	}
+
+	ref := cmmeta.ObjectReference{
+		Kind:  annotations[cmapi.IssuerKindAnnotationKey],
+		Group: annotations[cmapi.IssuerGroupAnnotationKey],
+	}
+	if name := annotations[cmapi.IngressIssuerNameAnnotationKey]; name != "" {
+		ref.Name = name
+	} else if name := annotations[cmapi.IngressClusterIssuerNameAnnotationKey]; name != "" {
+		ref.Name = name
+		ref.Kind = cmapi.ClusterIssuerKind
+	}
+
+	if ref.Name == "" {
+		return ref, errors.New("no issuer found in the annotations of the resource, please specify one using --issuer")
+	}
+	return ref, nil
+}
+
+// Adopt generates a Certificate for every TLS Secret in entries and either
+// prints or creates them.
+func (o *Options) Adopt(ctx context.Context, issuerRef cmmeta.ObjectReference, entries []TLSEntry) error {
+	var crts []*cmapi.Certificate
+	for _, entry := range MergeEntries(entries) {
+		secret, err := o.KubeClient.CoreV1().Secrets(entry.Namespace).Get(ctx, entry.SecretName, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			fmt.Fprintf(o.ErrOut, "Secret %s/%s does not exist, generating Certificate from hosts only\n", entry.Namespace, entry.SecretName)
+			secret = nil
+		case err != nil:
+			return fmt.Errorf("error when getting Secret %s/%s: %w", entry.Namespace, entry.SecretName, err)
+		}
+
+		if secret != nil {
+			if name, ok := secret.Annotations[cmapi.CertificateNameKey]; ok {
+				fmt.Fprintf(o.ErrOut, "Secret %s/%s is already managed by Certificate %q, skipping\n", entry.Namespace, entry.SecretName, name)
+				continue
+			}
+		}
+
+		var cert *x509.Certificate
+		if secret != nil {
+			cert, err = pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+			if err != nil {
+				fmt.Fprintf(o.ErrOut, "Unable to decode certificate in Secret %s/%s, generating Certificate from hosts only: %v\n", entry.Namespace, entry.SecretName, err)
+				cert = nil
+			}
+		}
+
+		crts = append(crts, BuildCertificate(entry, issuerRef, cert))
+	}
+
+	if len(crts) == 0 {
+		fmt.Fprintln(o.ErrOut, "No TLS Secrets to adopt")
+		return nil
+	}
+
+	if !o.Apply {
+		for _, crt := range crts {
+			if err := o.Printer.PrintObj(crt, o.Out); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, crt := range crts {
+		if !o.DryRun.SkipRequest() {
+			_, err := o.CMClient.CertmanagerV1().Certificates(crt.Namespace).Create(ctx, crt, metav1.CreateOptions{DryRun: o.DryRun.Options()})
+			if apierrors.IsAlreadyExists(err) {
+				fmt.Fprintf(o.ErrOut, "Certificate %s/%s already exists, skipping\n", crt.Namespace, crt.Name)
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to create Certificate %s/%s: %w", crt.Namespace, crt.Name, err)
+			}
+		}
+		fmt.Fprintf(o.Out, "Created Certificate %s/%s%s\n", crt.Namespace, crt.Name, o.DryRun.Suffix())
+	}
+
+	return nil
+}
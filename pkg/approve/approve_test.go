@@ -61,6 +61,12 @@ func TestValidate(t *testing.T) {
 			message: "bar",
 			expErr:  false,
 		},
+		"'-' to read CR names from stdin should not error": {
+			args:    []string{"-"},
+			reason:  "foo",
+			message: "bar",
+			expErr:  false,
+		},
 	}
 
 	for name, test := range tests {
@@ -23,18 +23,34 @@ import (
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
+	"github.com/cert-manager/cmctl/v2/pkg/adopt"
 	"github.com/cert-manager/cmctl/v2/pkg/approve"
 	"github.com/cert-manager/cmctl/v2/pkg/check"
 	"github.com/cert-manager/cmctl/v2/pkg/completion"
 	"github.com/cert-manager/cmctl/v2/pkg/convert"
 	"github.com/cert-manager/cmctl/v2/pkg/create"
+	"github.com/cert-manager/cmctl/v2/pkg/debug"
 	"github.com/cert-manager/cmctl/v2/pkg/deny"
+	"github.com/cert-manager/cmctl/v2/pkg/diff"
+	"github.com/cert-manager/cmctl/v2/pkg/events"
 	"github.com/cert-manager/cmctl/v2/pkg/experimental"
+	"github.com/cert-manager/cmctl/v2/pkg/explain"
+	"github.com/cert-manager/cmctl/v2/pkg/export"
+	"github.com/cert-manager/cmctl/v2/pkg/forecast"
+	"github.com/cert-manager/cmctl/v2/pkg/gc"
+	"github.com/cert-manager/cmctl/v2/pkg/get"
+	"github.com/cert-manager/cmctl/v2/pkg/graph"
+	"github.com/cert-manager/cmctl/v2/pkg/importer"
 	"github.com/cert-manager/cmctl/v2/pkg/inspect"
+	"github.com/cert-manager/cmctl/v2/pkg/label"
+	"github.com/cert-manager/cmctl/v2/pkg/lint"
+	"github.com/cert-manager/cmctl/v2/pkg/notify"
 	"github.com/cert-manager/cmctl/v2/pkg/renew"
+	"github.com/cert-manager/cmctl/v2/pkg/rotatekey"
 	"github.com/cert-manager/cmctl/v2/pkg/status"
 	"github.com/cert-manager/cmctl/v2/pkg/upgrade"
 	"github.com/cert-manager/cmctl/v2/pkg/version"
+	"github.com/cert-manager/cmctl/v2/pkg/whichcert"
 )
 
 // registerCompletion gates whether the completion command is registered.
@@ -52,11 +68,28 @@ func Commands() []RegisterCommandFunc {
 		convert.NewCmdConvert,
 		create.NewCmdCreate,
 		renew.NewCmdRenew,
+		rotatekey.NewCmdRotateKey,
 		status.NewCmdStatus,
+		get.NewCmdGet,
+		explain.NewCmdExplain,
 		inspect.NewCmdInspect,
 		approve.NewCmdApprove,
 		deny.NewCmdDeny,
 		check.NewCmdCheck,
+		debug.NewCmdDebug,
+		diff.NewCmdDiff,
+		gc.NewCmdGC,
+		label.NewCmdLabel,
+		label.NewCmdAnnotate,
+		adopt.NewCmdAdopt,
+		export.NewCmdExport,
+		whichcert.NewCmdWhichCert,
+		graph.NewCmdGraph,
+		forecast.NewCmdForecast,
+		notify.NewCmdNotify,
+		events.NewCmdEvents,
+		lint.NewCmdLint,
+		importer.NewCmdImport,
 		upgrade.NewCmdUpgrade,
 
 		// Experimental features
@@ -26,13 +26,13 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
-	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
 
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/util/cmapichecker"
 	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
 	"github.com/cert-manager/cmctl/v2/pkg/factory"
 )
 
@@ -91,8 +91,8 @@ func NewCmdCheckApi(ctx context.Context, ioStreams genericclioptions.IOStreams)
 		Short: "Check if the cert-manager API is ready",
 		Long:  checkApiDesc,
 		Run: func(cmd *cobra.Command, args []string) {
-			cmdutil.CheckErr(o.Complete())
-			cmdutil.CheckErr(o.Run(ctx))
+			cmcmdutil.CheckErr(o.Complete())
+			cmcmdutil.CheckErr(o.Run(ctx))
 		},
 	}
 	cmd.Flags().DurationVar(&o.Wait, "wait", 0, "Wait until the cert-manager API is ready (default 0s = poll once)")
@@ -133,11 +133,10 @@ func (o *Options) Run(ctx context.Context) error {
 		if errors.Is(pollErr, context.DeadlineExceeded) && o.Wait > 0 {
 			log.V(2).Info("Timed out", "after", o.Wait, "err", pollErr)
 			cmcmdutil.SetExitCode(pollErr)
-		} else {
-			cmcmdutil.SetExitCode(lastError)
 		}
 
-		return lastError
+		return cmcmdutil.WithHint(cmcmdutil.NotReadyError(lastError), build.WithTemplate(
+			"Run '{{.BuildName}} check install' to find inconsistencies in the installation, and check the logs of the cert-manager webhook."))
 	}
 
 	fmt.Fprintln(o.Out, "The cert-manager API is ready")
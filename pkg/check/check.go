@@ -23,12 +23,14 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"github.com/cert-manager/cmctl/v2/pkg/check/api"
+	"github.com/cert-manager/cmctl/v2/pkg/check/install"
 )
 
 // NewCmdCheck returns a cobra command for checking cert-manager components.
 func NewCmdCheck(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
 	cmds := NewCmdCreateBare()
 	cmds.AddCommand(api.NewCmdCheckApi(ctx, ioStreams))
+	cmds.AddCommand(install.NewCmdCheckInstall(ctx, ioStreams))
 
 	return cmds
 }
@@ -0,0 +1,236 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package install
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cert-manager/cmctl/v2/internal/installation"
+)
+
+const (
+	statusOK   = "OK"
+	statusWarn = "WARN"
+	statusFail = "FAIL"
+)
+
+// expectedCRDs are the CustomResourceDefinitions of a cert-manager installation.
+var expectedCRDs = []string{
+	"certificaterequests.cert-manager.io",
+	"certificates.cert-manager.io",
+	"challenges.acme.cert-manager.io",
+	"clusterissuers.cert-manager.io",
+	"issuers.cert-manager.io",
+	"orders.acme.cert-manager.io",
+}
+
+// crdInfo describes an installed cert-manager CRD.
+type crdInfo struct {
+	Name    string
+	Version string
+	// ConversionService is the namespace/name of the conversion webhook
+	// Service, if the CRD uses webhook conversion.
+	ConversionService string
+}
+
+// webhookInfo describes a webhook configuration for cert-manager resources.
+type webhookInfo struct {
+	Kind     string
+	Name     string
+	Version  string
+	Services []string
+}
+
+// deploymentInfo describes a deployed cert-manager component.
+type deploymentInfo struct {
+	Namespace string
+	Name      string
+	Component string
+	Version   string
+}
+
+// result is the outcome of a single check.
+type result struct {
+	Status  string
+	Message string
+}
+
+// checkInstall verifies that the installed CRDs, webhook configurations and
+// components belong to a single, consistent cert-manager installation.
+func checkInstall(crds []crdInfo, webhooks []webhookInfo, deploys []deploymentInfo) []result {
+	var results []result
+	add := func(status, format string, args ...interface{}) {
+		results = append(results, result{Status: status, Message: fmt.Sprintf(format, args...)})
+	}
+
+	// CRDs
+	installed := map[string]bool{}
+	crdVersions := map[string][]string{}
+	for _, crd := range crds {
+		installed[crd.Name] = true
+		crdVersions[crd.Version] = append(crdVersions[crd.Version], crd.Name)
+	}
+	var missing []string
+	for _, name := range expectedCRDs {
+		if !installed[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		add(statusFail, "CRDs missing: %s", strings.Join(missing, ", "))
+	} else {
+		add(statusOK, "All %d cert-manager CRDs are installed", len(expectedCRDs))
+	}
+
+	crdVersion := ""
+	switch {
+	case len(crds) == 0:
+	case len(crdVersions) > 1:
+		add(statusFail, "CRDs have mixed versions: %s", describeVersions(crdVersions))
+	case crds[0].Version == "":
+		add(statusWarn, "CRDs have no app.kubernetes.io/version label, their version cannot be verified")
+	default:
+		crdVersion = crds[0].Version
+		add(statusOK, "CRDs are at version %s", crdVersion)
+	}
+
+	// Components
+	byNamespace := map[string][]deploymentInfo{}
+	for _, d := range deploys {
+		byNamespace[d.Namespace] = append(byNamespace[d.Namespace], d)
+	}
+	namespaces := sortedKeys(byNamespace)
+
+	var controllerNamespaces []string
+	for _, ns := range namespaces {
+		for _, d := range byNamespace[ns] {
+			if d.Component == "controller" {
+				controllerNamespaces = append(controllerNamespaces, ns)
+				break
+			}
+		}
+	}
+	switch {
+	case len(deploys) == 0:
+		add(statusFail, "No cert-manager components found")
+	case len(controllerNamespaces) > 1:
+		add(statusFail, "Multiple cert-manager installations found in namespaces %s", strings.Join(controllerNamespaces, ", "))
+	case len(controllerNamespaces) == 1:
+		add(statusOK, "A single cert-manager installation found in namespace %s", controllerNamespaces[0])
+	}
+
+	webhookNamespaces := map[string]bool{}
+	for _, ns := range namespaces {
+		present := map[string]bool{}
+		versions := map[string][]string{}
+		for _, d := range byNamespace[ns] {
+			present[d.Component] = true
+			versions[d.Version] = append(versions[d.Version], d.Name)
+		}
+		if present["webhook"] {
+			webhookNamespaces[ns] = true
+		}
+
+		for _, c := range installation.Components {
+			if present[c] {
+				continue
+			}
+			status := statusFail
+			if c == "cainjector" {
+				// The cainjector can be disabled when CA bundles are
+				// managed in another way.
+				status = statusWarn
+			}
+			add(status, "Component %s is missing in namespace %s", c, ns)
+		}
+
+		switch {
+		case len(versions) > 1:
+			add(statusFail, "Components in namespace %s run mixed versions: %s", ns, describeVersions(versions))
+		case crdVersion != "" && byNamespace[ns][0].Version != "" && byNamespace[ns][0].Version != crdVersion:
+			add(statusFail, "Components in namespace %s run version %s, but the CRDs are at version %s", ns, byNamespace[ns][0].Version, crdVersion)
+		default:
+			add(statusOK, "Components in namespace %s run version %s", ns, orUnknown(byNamespace[ns][0].Version))
+		}
+	}
+
+	// Webhook configurations
+	count := map[string][]string{}
+	for _, wh := range webhooks {
+		count[wh.Kind] = append(count[wh.Kind], wh.Name)
+	}
+	for _, kind := range []string{"ValidatingWebhookConfiguration", "MutatingWebhookConfiguration"} {
+		switch {
+		case len(count[kind]) == 0:
+			add(statusFail, "No %s for cert-manager resources found", kind)
+		case len(count[kind]) > 1:
+			add(statusFail, "Conflicting %ss for cert-manager resources: %s", kind, strings.Join(count[kind], ", "))
+		}
+	}
+
+	for _, wh := range webhooks {
+		if crdVersion != "" && wh.Version != "" && wh.Version != crdVersion {
+			add(statusFail, "%s %s is at version %s, but the CRDs are at version %s", wh.Kind, wh.Name, wh.Version, crdVersion)
+		}
+		for _, svc := range wh.Services {
+			if !webhookNamespaces[serviceNamespace(svc)] {
+				add(statusFail, "%s %s uses Service %s, which is not in a namespace with a cert-manager webhook", wh.Kind, wh.Name, svc)
+			}
+		}
+	}
+
+	for _, crd := range crds {
+		if crd.ConversionService != "" && !webhookNamespaces[serviceNamespace(crd.ConversionService)] {
+			add(statusFail, "CRD %s uses conversion Service %s, which is not in a namespace with a cert-manager webhook", crd.Name, crd.ConversionService)
+		}
+	}
+
+	return results
+}
+
+func serviceNamespace(svc string) string {
+	ns, _, _ := strings.Cut(svc, "/")
+	return ns
+}
+
+func describeVersions(versions map[string][]string) string {
+	var parts []string
+	for _, v := range sortedKeys(versions) {
+		names := versions[v]
+		sort.Strings(names)
+		parts = append(parts, fmt.Sprintf("%s (%s)", orUnknown(v), strings.Join(names, ", ")))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func orUnknown(version string) string {
+	if version == "" {
+		return "<unknown>"
+	}
+	return version
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package install
+
+import (
+	"reflect"
+	"testing"
+)
+
+func healthyCRDs(version string) []crdInfo {
+	var crds []crdInfo
+	for _, name := range expectedCRDs {
+		crds = append(crds, crdInfo{Name: name, Version: version, ConversionService: "cert-manager/cert-manager-webhook"})
+	}
+	return crds
+}
+
+func healthyWebhooks(version string) []webhookInfo {
+	return []webhookInfo{
+		{Kind: "ValidatingWebhookConfiguration", Name: "cert-manager-webhook", Version: version, Services: []string{"cert-manager/cert-manager-webhook"}},
+		{Kind: "MutatingWebhookConfiguration", Name: "cert-manager-webhook", Version: version, Services: []string{"cert-manager/cert-manager-webhook"}},
+	}
+}
+
+func healthyDeployments(ns, version string) []deploymentInfo {
+	return []deploymentInfo{
+		{Namespace: ns, Name: "cert-manager", Component: "controller", Version: version},
+		{Namespace: ns, Name: "cert-manager-webhook", Component: "webhook", Version: version},
+		{Namespace: ns, Name: "cert-manager-cainjector", Component: "cainjector", Version: version},
+	}
+}
+
+func failures(results []result) []string {
+	var out []string
+	for _, r := range results {
+		if r.Status == statusFail {
+			out = append(out, r.Message)
+		}
+	}
+	return out
+}
+
+func TestCheckInstall(t *testing.T) {
+	tests := map[string]struct {
+		crds     []crdInfo
+		webhooks []webhookInfo
+		deploys  []deploymentInfo
+		exp      []string
+	}{
+		"consistent installation": {
+			crds:     healthyCRDs("v1.13.3"),
+			webhooks: healthyWebhooks("v1.13.3"),
+			deploys:  healthyDeployments("cert-manager", "v1.13.3"),
+		},
+		"CRDs not upgraded": {
+			crds:     healthyCRDs("v1.12.0"),
+			webhooks: healthyWebhooks("v1.13.3"),
+			deploys:  healthyDeployments("cert-manager", "v1.13.3"),
+			exp: []string{
+				"Components in namespace cert-manager run version v1.13.3, but the CRDs are at version v1.12.0",
+				"ValidatingWebhookConfiguration cert-manager-webhook is at version v1.13.3, but the CRDs are at version v1.12.0",
+				"MutatingWebhookConfiguration cert-manager-webhook is at version v1.13.3, but the CRDs are at version v1.12.0",
+			},
+		},
+		"half-upgraded components": {
+			crds:     healthyCRDs(""),
+			webhooks: healthyWebhooks(""),
+			deploys: append(healthyDeployments("cert-manager", "v1.13.3")[:2],
+				deploymentInfo{Namespace: "cert-manager", Name: "cert-manager-cainjector", Component: "cainjector", Version: "v1.12.0"}),
+			exp: []string{
+				"Components in namespace cert-manager run mixed versions: v1.12.0 (cert-manager-cainjector); v1.13.3 (cert-manager, cert-manager-webhook)",
+			},
+		},
+		"duplicate installation": {
+			crds:     healthyCRDs("v1.13.3"),
+			webhooks: append(healthyWebhooks("v1.13.3"), webhookInfo{Kind: "ValidatingWebhookConfiguration", Name: "other-webhook", Services: []string{"other/cert-manager-webhook"}}),
+			deploys:  append(healthyDeployments("cert-manager", "v1.13.3"), healthyDeployments("other", "v1.13.3")...),
+			exp: []string{
+				"Multiple cert-manager installations found in namespaces cert-manager, other",
+				"Conflicting ValidatingWebhookConfigurations for cert-manager resources: cert-manager-webhook, other-webhook",
+			},
+		},
+		"missing CRDs and components": {
+			crds:     healthyCRDs("v1.13.3")[:5],
+			webhooks: healthyWebhooks("v1.13.3")[:1],
+			deploys:  healthyDeployments("cert-manager", "v1.13.3")[:1],
+			exp: []string{
+				"CRDs missing: orders.acme.cert-manager.io",
+				"Component webhook is missing in namespace cert-manager",
+				"No MutatingWebhookConfiguration for cert-manager resources found",
+				"ValidatingWebhookConfiguration cert-manager-webhook uses Service cert-manager/cert-manager-webhook, which is not in a namespace with a cert-manager webhook",
+				"CRD certificaterequests.cert-manager.io uses conversion Service cert-manager/cert-manager-webhook, which is not in a namespace with a cert-manager webhook",
+				"CRD certificates.cert-manager.io uses conversion Service cert-manager/cert-manager-webhook, which is not in a namespace with a cert-manager webhook",
+				"CRD challenges.acme.cert-manager.io uses conversion Service cert-manager/cert-manager-webhook, which is not in a namespace with a cert-manager webhook",
+				"CRD clusterissuers.cert-manager.io uses conversion Service cert-manager/cert-manager-webhook, which is not in a namespace with a cert-manager webhook",
+				"CRD issuers.cert-manager.io uses conversion Service cert-manager/cert-manager-webhook, which is not in a namespace with a cert-manager webhook",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := failures(checkInstall(test.crds, test.webhooks, test.deploys))
+			if !reflect.DeepEqual(got, test.exp) {
+				t.Errorf("unexpected failures:\nexp=%q\ngot=%q", test.exp, got)
+			}
+		})
+	}
+}
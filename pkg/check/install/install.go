@@ -0,0 +1,237 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package install
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cmctl/v2/internal/installation"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Verify that the cert-manager installation in the cluster is consistent.
+
+The check verifies that all cert-manager CRDs are installed at the same version, that the
+controller, webhook and cainjector run that same version, that there is exactly one set of
+webhook configurations for cert-manager resources pointing at a cert-manager webhook, and
+that there are no duplicate cert-manager installations in other namespaces.
+
+This catches half-upgraded installations, e.g. when the CRDs were not upgraded together
+with the Helm chart. The command exits with a non-zero exit code if any check fails.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Verify the cert-manager installation
+{{.BuildName}} check install
+`)))
+)
+
+// Options is a struct to support check install command
+type Options struct {
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdCheckInstall returns a cobra command for verifying the integrity of a cert-manager installation
+func NewCmdCheckInstall(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "install",
+		Short:   "Check if the cert-manager installation is consistent",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate(args)))
+			cmcmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("check install does not accept arguments")
+	}
+	return nil
+}
+
+// Run executes check install command
+func (o *Options) Run(ctx context.Context) error {
+	crds, err := o.listCRDs(ctx)
+	if err != nil {
+		return err
+	}
+	webhooks, err := o.listWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+	deploys, err := o.listDeployments(ctx)
+	if err != nil {
+		return err
+	}
+
+	out, closePager := cmcmdutil.StartPager(o.Out)
+	defer closePager()
+	o.Out = out
+
+	failed := 0
+	for _, r := range checkInstall(crds, webhooks, deploys) {
+		if r.Status == statusFail {
+			failed++
+		}
+		fmt.Fprintf(o.Out, "[%s] %s\n", r.Status, r.Message)
+	}
+
+	if failed > 0 {
+		return cmcmdutil.NotReadyError(fmt.Errorf("%d check(s) failed, the cert-manager installation is inconsistent", failed))
+	}
+
+	fmt.Fprintln(o.Out, "\nThe cert-manager installation is consistent")
+	return nil
+}
+
+func (o *Options) listCRDs(ctx context.Context) ([]crdInfo, error) {
+	client, err := apiextensionsclient.NewForConfig(o.RESTConfig)
+	if err != nil {
+		return nil, err
+	}
+	list, err := client.ApiextensionsV1().CustomResourceDefinitions().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error when listing CustomResourceDefinitions: %w", err)
+	}
+
+	var crds []crdInfo
+	for _, crd := range list.Items {
+		if !isCertManagerGroup(crd.Spec.Group) {
+			continue
+		}
+		info := crdInfo{Name: crd.Name, Version: crd.Labels[installation.VersionLabel]}
+		if c := crd.Spec.Conversion; c != nil && c.Strategy == apiextensionsv1.WebhookConverter &&
+			c.Webhook != nil && c.Webhook.ClientConfig != nil && c.Webhook.ClientConfig.Service != nil {
+			info.ConversionService = c.Webhook.ClientConfig.Service.Namespace + "/" + c.Webhook.ClientConfig.Service.Name
+		}
+		crds = append(crds, info)
+	}
+	return crds, nil
+}
+
+func (o *Options) listWebhooks(ctx context.Context) ([]webhookInfo, error) {
+	var webhooks []webhookInfo
+
+	validating, err := o.KubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error when listing ValidatingWebhookConfigurations: %w", err)
+	}
+	for _, cfg := range validating.Items {
+		var services []string
+		relevant := false
+		for _, wh := range cfg.Webhooks {
+			relevant = relevant || matchesCertManager(wh.Rules)
+			services = appendService(services, wh.ClientConfig)
+		}
+		if relevant {
+			webhooks = append(webhooks, webhookInfo{Kind: "ValidatingWebhookConfiguration", Name: cfg.Name, Version: cfg.Labels[installation.VersionLabel], Services: services})
+		}
+	}
+
+	mutating, err := o.KubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error when listing MutatingWebhookConfigurations: %w", err)
+	}
+	for _, cfg := range mutating.Items {
+		var services []string
+		relevant := false
+		for _, wh := range cfg.Webhooks {
+			relevant = relevant || matchesCertManager(wh.Rules)
+			services = appendService(services, wh.ClientConfig)
+		}
+		if relevant {
+			webhooks = append(webhooks, webhookInfo{Kind: "MutatingWebhookConfiguration", Name: cfg.Name, Version: cfg.Labels[installation.VersionLabel], Services: services})
+		}
+	}
+
+	return webhooks, nil
+}
+
+func (o *Options) listDeployments(ctx context.Context) ([]deploymentInfo, error) {
+	list, err := installation.ListDeployments(ctx, o.KubeClient, metav1.NamespaceAll)
+	if err != nil {
+		return nil, err
+	}
+
+	var deploys []deploymentInfo
+	for _, d := range list {
+		deploys = append(deploys, deploymentInfo{Namespace: d.Namespace, Name: d.Name, Component: d.Component, Version: d.Version})
+	}
+	return deploys, nil
+}
+
+func isCertManagerGroup(group string) bool {
+	return group == cmapi.SchemeGroupVersion.Group || group == cmacme.SchemeGroupVersion.Group
+}
+
+func matchesCertManager(rules []admissionregistrationv1.RuleWithOperations) bool {
+	for _, rule := range rules {
+		for _, group := range rule.APIGroups {
+			if isCertManagerGroup(group) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func appendService(services []string, cfg admissionregistrationv1.WebhookClientConfig) []string {
+	if cfg.Service == nil {
+		return services
+	}
+	svc := cfg.Service.Namespace + "/" + cfg.Service.Name
+	for _, s := range services {
+		if s == svc {
+			return services
+		}
+	}
+	return append(services, svc)
+}
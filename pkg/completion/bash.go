@@ -19,8 +19,8 @@ package completion
 import (
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
-	"k8s.io/kubectl/pkg/cmd/util"
 
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
 	"github.com/cert-manager/cmctl/v2/pkg/build"
 )
 
@@ -40,7 +40,7 @@ Bash:
 `),
 		DisableFlagsInUseLine: true,
 		Run: func(cmd *cobra.Command, args []string) {
-			util.CheckErr(cmd.Root().GenBashCompletion(ioStreams.Out))
+			cmcmdutil.CheckErr(cmd.Root().GenBashCompletion(ioStreams.Out))
 		},
 	}
 }
@@ -19,8 +19,8 @@ package completion
 import (
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
-	"k8s.io/kubectl/pkg/cmd/util"
 
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
 	"github.com/cert-manager/cmctl/v2/pkg/build"
 )
 
@@ -39,7 +39,7 @@ func newCmdCompletionZSH(ioStreams genericclioptions.IOStreams) *cobra.Command {
 `),
 		DisableFlagsInUseLine: true,
 		Run: func(cmd *cobra.Command, args []string) {
-			util.CheckErr(cmd.Root().GenZshCompletion(ioStreams.Out))
+			cmcmdutil.CheckErr(cmd.Root().GenZshCompletion(ioStreams.Out))
 		},
 	}
 }
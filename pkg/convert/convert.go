@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
 	"github.com/cert-manager/cmctl/v2/pkg/build"
 
 	"github.com/spf13/cobra"
@@ -95,8 +96,8 @@ func NewCmdConvert(ctx context.Context, ioStreams genericclioptions.IOStreams) *
 		Example:               example,
 		DisableFlagsInUseLine: true,
 		Run: func(cmd *cobra.Command, args []string) {
-			cmdutil.CheckErr(o.Complete())
-			cmdutil.CheckErr(o.Run())
+			cmcmdutil.CheckErr(o.Complete())
+			cmcmdutil.CheckErr(o.Run())
 		},
 	}
 
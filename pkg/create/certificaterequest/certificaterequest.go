@@ -28,10 +28,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/resource"
-	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
 
@@ -40,6 +38,7 @@ import (
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/ctl"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
 	"github.com/cert-manager/cmctl/v2/pkg/build"
 	"github.com/cert-manager/cmctl/v2/pkg/factory"
 )
@@ -63,6 +62,9 @@ Create a new CertificateRequest resource based on a Certificate resource, by gen
 
 # Create a CertificateRequest, wait for it to be signed for up to 20 minutes and store the x509 certificate in file 'my-cr.crt'.
 {{.BuildName}} create certificaterequest my-cr --from-certificate-file my-certificate.yaml --fetch-certificate --timeout 20m
+
+# Check that the API server would admit the CertificateRequest, without creating it or writing the private key.
+{{.BuildName}} create certificaterequest my-cr --from-certificate-file my-certificate.yaml --dry-run=server
 `)))
 )
 
@@ -90,6 +92,9 @@ type Options struct {
 	// Length of time the command blocks to wait on CertificateRequest to be ready if --fetch-certificate flag is set
 	// If not specified, default value is 5 minutes
 	Timeout time.Duration
+	// DryRun prints the CertificateRequest that would be created, without
+	// creating it or only submitting a dry-run request. No files are written.
+	DryRun cmcmdutil.DryRun
 
 	genericclioptions.IOStreams
 	*factory.Factory
@@ -108,14 +113,14 @@ func NewCmdCreateCR(ctx context.Context, ioStreams genericclioptions.IOStreams)
 
 	cmd := &cobra.Command{
 		Use:               "certificaterequest",
-		Aliases:           []string{"cr"},
+		Aliases:           cmcmdutil.KindAliases(cmapi.CertificateRequestKind, "certificaterequest"),
 		Short:             "Create a cert-manager CertificateRequest resource, using a Certificate resource as a template",
 		Long:              long,
 		Example:           example,
 		ValidArgsFunction: factory.ValidArgsListCertificateRequests(ctx, &o.Factory),
 		Run: func(cmd *cobra.Command, args []string) {
-			cmdutil.CheckErr(o.Validate(args))
-			cmdutil.CheckErr(o.Run(ctx, args))
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate(args)))
+			cmcmdutil.CheckErr(o.Run(ctx, args))
 		},
 	}
 	cmd.Flags().StringVar(&o.InputFilename, "from-certificate-file", o.InputFilename,
@@ -128,6 +133,7 @@ func NewCmdCreateCR(ctx context.Context, ioStreams genericclioptions.IOStreams)
 		"If set to true, command will wait for CertificateRequest to be signed to store x509 certificate in a file")
 	cmd.Flags().DurationVar(&o.Timeout, "timeout", 5*time.Minute,
 		"Time before timeout when waiting for CertificateRequest to be signed, must include unit, e.g. 10m or 1h")
+	cmcmdutil.AddDryRunFlag(cmd.Flags(), &o.DryRun)
 
 	o.Factory = factory.New(ctx, cmd)
 
@@ -155,6 +161,10 @@ func (o *Options) Validate(args []string) error {
 		return errors.New("cannot specify file to store certificate if not waiting for and fetching certificate, please set --fetch-certificate flag")
 	}
 
+	if o.FetchCert && o.DryRun.Enabled() {
+		return errors.New("cannot wait for and fetch certificate in conjunction with --dry-run")
+	}
+
 	return nil
 }
 
@@ -221,10 +231,12 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 	if o.KeyFilename != "" {
 		keyFileName = o.KeyFilename
 	}
-	if err := os.WriteFile(keyFileName, keyData, 0600); err != nil {
-		return fmt.Errorf("error when writing private key to file: %w", err)
+	if !o.DryRun.Enabled() {
+		if err := os.WriteFile(keyFileName, keyData, 0600); err != nil {
+			return fmt.Errorf("error when writing private key to file: %w", err)
+		}
+		fmt.Fprintf(o.ErrOut, "Private key written to file %s\n", keyFileName)
 	}
-	fmt.Fprintf(o.ErrOut, "Private key written to file %s\n", keyFileName)
 
 	// Build CertificateRequest with name as specified by argument
 	req, err := buildCertificateRequest(crt, keyData, crName)
@@ -232,29 +244,29 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 		return fmt.Errorf("error when building CertificateRequest: %w", err)
 	}
 
-	ns := crt.Namespace
-	if ns == "" {
-		ns = o.Namespace
+	req.Namespace = crt.Namespace
+	if req.Namespace == "" {
+		req.Namespace = o.Namespace
 	}
-	req, err = o.CMClient.CertmanagerV1().CertificateRequests(ns).Create(ctx, req, metav1.CreateOptions{})
-	if err != nil {
-		return fmt.Errorf("error creating CertificateRequest: %w", err)
+	if !o.DryRun.SkipRequest() {
+		req, err = o.CMClient.CertmanagerV1().CertificateRequests(req.Namespace).Create(ctx, req, metav1.CreateOptions{DryRun: o.DryRun.Options()})
+		if err != nil {
+			return fmt.Errorf("error creating CertificateRequest: %w", err)
+		}
 	}
-	fmt.Fprintf(o.ErrOut, "CertificateRequest %s has been created in namespace %s\n", req.Name, req.Namespace)
+	fmt.Fprintf(o.ErrOut, "CertificateRequest %s has been created in namespace %s%s\n", req.Name, req.Namespace, o.DryRun.Suffix())
 
 	if o.FetchCert {
 		fmt.Fprintf(o.ErrOut, "CertificateRequest %v in namespace %v has not been signed yet. Wait until it is signed...\n",
 			req.Name, req.Namespace)
-		err = wait.PollUntilContextTimeout(ctx, time.Second, o.Timeout, false, func(ctx context.Context) (done bool, err error) {
-			req, err = o.CMClient.CertmanagerV1().CertificateRequests(req.Namespace).Get(ctx, req.Name, metav1.GetOptions{})
-			if err != nil {
-				return false, nil
-			}
+		progress := cmcmdutil.NewProgress(o.ErrOut, "Waiting for CertificateRequest to be signed", 0)
+		req, err = o.WaitForCertificateRequest(ctx, req.Namespace, req.Name, o.Timeout, func(req *cmapi.CertificateRequest) bool {
 			return apiutil.CertificateRequestHasCondition(req, cmapi.CertificateRequestCondition{
 				Type:   cmapi.CertificateRequestConditionReady,
 				Status: cmmeta.ConditionTrue,
-			}) && len(req.Status.Certificate) > 0, nil
+			}) && len(req.Status.Certificate) > 0
 		})
+		progress.Finish()
 		if err != nil {
 			return fmt.Errorf("error when waiting for CertificateRequest to be signed: %w", err)
 		}
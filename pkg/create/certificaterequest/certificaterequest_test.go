@@ -21,6 +21,7 @@ import (
 	"os"
 	"testing"
 
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
 	"github.com/cert-manager/cmctl/v2/pkg/factory"
 )
 
@@ -31,6 +32,7 @@ func TestValidate(t *testing.T) {
 		keyFilename  string
 		certFilename string
 		fetchCert    bool
+		dryRun       cmcmdutil.DryRun
 
 		expErr    bool
 		expErrMsg string
@@ -76,6 +78,20 @@ func TestValidate(t *testing.T) {
 			expErr:       true,
 			expErrMsg:    "cannot specify file to store certificate if not waiting for and fetching certificate, please set --fetch-certificate flag",
 		},
+		"cannot fetch certificate with dry-run": {
+			inputFile: "example.yaml",
+			inputArgs: []string{"hello"},
+			fetchCert: true,
+			dryRun:    cmcmdutil.DryRunServer,
+			expErr:    true,
+			expErrMsg: "cannot wait for and fetch certificate in conjunction with --dry-run",
+		},
+		"dry-run without fetching certificate should not error": {
+			inputFile: "example.yaml",
+			inputArgs: []string{"hello"},
+			dryRun:    cmcmdutil.DryRunClient,
+			expErr:    false,
+		},
 	}
 
 	for name, test := range tests {
@@ -85,6 +101,7 @@ func TestValidate(t *testing.T) {
 				KeyFilename:   test.keyFilename,
 				CertFileName:  test.certFilename,
 				FetchCert:     test.fetchCert,
+				DryRun:        test.dryRun,
 			}
 
 			// Validating args and flags
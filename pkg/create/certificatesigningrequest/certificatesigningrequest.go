@@ -31,11 +31,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/resource"
 	"k8s.io/client-go/discovery"
-	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
 
@@ -44,6 +42,7 @@ import (
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/ctl"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
 	"github.com/cert-manager/cmctl/v2/pkg/build"
 	"github.com/cert-manager/cmctl/v2/pkg/factory"
 )
@@ -67,6 +66,9 @@ Create a new CertificateSigningRequest resource based on a Certificate resource,
 
 # Create a CertificateSigningRequest, wait for it to be signed for up to 20 minutes and store the x509 certificate in file 'my-cr.crt'.
 {{.BuildName}} x create csr my-cr --from-certificate-file my-certificate.yaml --fetch-certificate --timeout 20m
+
+# Check that the API server would admit the CertificateSigningRequest, without creating it or writing the private key.
+{{.BuildName}} x create csr my-csr -f my-certificate.yaml --dry-run=server
 `)))
 )
 
@@ -103,6 +105,11 @@ type Options struct {
 	// value is 5 minutes.
 	Timeout time.Duration
 
+	// DryRun prints the CertificateSigningRequest that would be created,
+	// without creating it or only submitting a dry-run request. No files are
+	// written.
+	DryRun cmcmdutil.DryRun
+
 	genericclioptions.IOStreams
 	*factory.Factory
 }
@@ -120,14 +127,14 @@ func NewCmdCreateCSR(ctx context.Context, ioStreams genericclioptions.IOStreams)
 
 	cmd := &cobra.Command{
 		Use:               "certificatesigningrequest",
-		Aliases:           []string{"csr"},
+		Aliases:           cmcmdutil.KindAliases("CertificateSigningRequest", "certificatesigningrequest"),
 		Short:             "Create a Kubernetes CertificateSigningRequest resource, using a Certificate resource as a template",
 		Long:              long,
 		Example:           example,
 		ValidArgsFunction: factory.ValidArgsListCertificateSigningRequests(ctx, &o.Factory),
 		Run: func(cmd *cobra.Command, args []string) {
-			cmdutil.CheckErr(o.Validate(args))
-			cmdutil.CheckErr(o.Run(ctx, args))
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate(args)))
+			cmcmdutil.CheckErr(o.Run(ctx, args))
 		},
 	}
 	cmd.Flags().StringVarP(&o.InputFilename, "from-certificate-file", "f", o.InputFilename,
@@ -140,6 +147,7 @@ func NewCmdCreateCSR(ctx context.Context, ioStreams genericclioptions.IOStreams)
 		"If set to true, command will wait for CertificateSigningRequest to be signed to store x509 certificate in a file")
 	cmd.Flags().DurationVar(&o.Timeout, "timeout", 5*time.Minute,
 		"Time before timeout when waiting for CertificateSigningRequest to be signed, must include unit, e.g. 10m or 1h")
+	cmcmdutil.AddDryRunFlag(cmd.Flags(), &o.DryRun)
 
 	o.Factory = factory.New(ctx, cmd)
 
@@ -167,6 +175,10 @@ func (o *Options) Validate(args []string) error {
 		return errors.New("cannot specify file to store certificate if not waiting for and fetching certificate, please set --fetch-certificate or -w flag")
 	}
 
+	if o.FetchCert && o.DryRun.Enabled() {
+		return errors.New("cannot wait for and fetch certificate in conjunction with --dry-run")
+	}
+
 	return nil
 }
 
@@ -238,10 +250,12 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 	if o.KeyFilename != "" {
 		keyFileName = o.KeyFilename
 	}
-	if err := os.WriteFile(keyFileName, keyPEM, 0600); err != nil {
-		return fmt.Errorf("error when writing private key to file: %s", err)
+	if !o.DryRun.Enabled() {
+		if err := os.WriteFile(keyFileName, keyPEM, 0600); err != nil {
+			return fmt.Errorf("error when writing private key to file: %s", err)
+		}
+		fmt.Fprintf(o.Out, "Private key written to file %s\n", keyFileName)
 	}
-	fmt.Fprintf(o.Out, "Private key written to file %s\n", keyFileName)
 
 	signerName, err := buildSignerName(o.KubeClient.Discovery(), crt)
 	if err != nil {
@@ -254,22 +268,22 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 		return fmt.Errorf("error when building CertificateSigningRequest: %s", err)
 	}
 
-	req, err = o.KubeClient.CertificatesV1().CertificateSigningRequests().Create(ctx, req, metav1.CreateOptions{})
-	if err != nil {
-		return fmt.Errorf("error creating CertificateSigningRequest: %s", err)
+	if !o.DryRun.SkipRequest() {
+		req, err = o.KubeClient.CertificatesV1().CertificateSigningRequests().Create(ctx, req, metav1.CreateOptions{DryRun: o.DryRun.Options()})
+		if err != nil {
+			return fmt.Errorf("error creating CertificateSigningRequest: %s", err)
+		}
 	}
-	fmt.Fprintf(o.Out, "CertificateSigningRequest %s has been created\n", req.Name)
+	fmt.Fprintf(o.Out, "CertificateSigningRequest %s has been created%s\n", req.Name, o.DryRun.Suffix())
 
 	if o.FetchCert {
 		fmt.Fprintf(o.Out, "CertificateSigningRequest %s has not been signed yet. Wait until it is signed...\n", req.Name)
 
-		err = wait.PollUntilContextTimeout(ctx, time.Second, o.Timeout, false, func(ctx context.Context) (done bool, err error) {
-			req, err = o.KubeClient.CertificatesV1().CertificateSigningRequests().Get(ctx, req.Name, metav1.GetOptions{})
-			if err != nil {
-				return false, err
-			}
-			return len(req.Status.Certificate) > 0, nil
+		progress := cmcmdutil.NewProgress(o.ErrOut, "Waiting for CertificateSigningRequest to be signed", 0)
+		req, err = o.WaitForCertificateSigningRequest(ctx, req.Name, o.Timeout, func(req *certificatesv1.CertificateSigningRequest) bool {
+			return len(req.Status.Certificate) > 0
 		})
+		progress.Finish()
 		if err != nil {
 			return fmt.Errorf("error when waiting for CertificateSigningRequest to be signed: %s", err)
 		}
@@ -18,6 +18,8 @@ package certificatesigningrequest
 
 import (
 	"testing"
+
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
 )
 
 func Test_Validate(t *testing.T) {
@@ -27,6 +29,7 @@ func Test_Validate(t *testing.T) {
 		keyFilename  string
 		certFilename string
 		fetchCert    bool
+		dryRun       cmcmdutil.DryRun
 
 		expErr    bool
 		expErrMsg string
@@ -72,6 +75,20 @@ func Test_Validate(t *testing.T) {
 			expErr:       true,
 			expErrMsg:    "cannot specify file to store certificate if not waiting for and fetching certificate, please set --fetch-certificate or -w flag",
 		},
+		"cannot fetch certificate with dry-run": {
+			inputFile: "example.yaml",
+			inputArgs: []string{"hello"},
+			fetchCert: true,
+			dryRun:    cmcmdutil.DryRunServer,
+			expErr:    true,
+			expErrMsg: "cannot wait for and fetch certificate in conjunction with --dry-run",
+		},
+		"dry-run without fetching certificate should not error": {
+			inputFile: "example.yaml",
+			inputArgs: []string{"hello"},
+			dryRun:    cmcmdutil.DryRunClient,
+			expErr:    false,
+		},
 	}
 
 	for name, test := range tests {
@@ -81,6 +98,7 @@ func Test_Validate(t *testing.T) {
 				KeyFilename:   test.keyFilename,
 				CertFileName:  test.certFilename,
 				FetchCert:     test.fetchCert,
+				DryRun:        test.dryRun,
 			}
 
 			// Validating args and flags
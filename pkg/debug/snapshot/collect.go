@@ -0,0 +1,199 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cmctl/v2/internal/installation"
+)
+
+const featureGatesFlag = "--feature-gates="
+
+// Snapshot is a point-in-time summary of a cert-manager installation. It only
+// contains versions, counts and aggregated metrics, never resource names,
+// namespaces, hostnames or registries.
+type Snapshot struct {
+	CapturedAt        string                    `json:"capturedAt"`
+	ClientVersion     string                    `json:"clientVersion"`
+	ServerVersion     string                    `json:"serverVersion,omitempty"`
+	KubernetesVersion string                    `json:"kubernetesVersion,omitempty"`
+	Components        []Component               `json:"components,omitempty"`
+	CRDVersions       map[string][]string       `json:"crdVersions,omitempty"`
+	Resources         map[string]map[string]int `json:"resources,omitempty"`
+	Metrics           map[string]float64        `json:"metrics,omitempty"`
+	// Unavailable lists the sections that could not be collected.
+	Unavailable []string `json:"unavailable,omitempty"`
+}
+
+// Component is a deployed cert-manager component.
+type Component struct {
+	Component     string   `json:"component"`
+	Images        []string `json:"images"`
+	FeatureGates  []string `json:"featureGates,omitempty"`
+	Replicas      int32    `json:"replicas"`
+	ReadyReplicas int32    `json:"readyReplicas"`
+}
+
+// component summarizes a Deployment of a cert-manager component.
+func component(deploy installation.Deployment) Component {
+	c := Component{
+		Component:     deploy.Component,
+		ReadyReplicas: deploy.Status.ReadyReplicas,
+	}
+	if deploy.Spec.Replicas != nil {
+		c.Replicas = *deploy.Spec.Replicas
+	}
+	for _, container := range deploy.Spec.Template.Spec.Containers {
+		c.Images = append(c.Images, imageName(container.Image))
+		for _, arg := range container.Args {
+			if gates, ok := strings.CutPrefix(arg, featureGatesFlag); ok {
+				c.FeatureGates = append(c.FeatureGates, strings.Split(gates, ",")...)
+			}
+		}
+	}
+	return c
+}
+
+// imageName strips the registry and repository path from image, which may
+// reveal private infrastructure, and keeps the name and tag or digest.
+func imageName(image string) string {
+	return image[strings.LastIndex(image, "/")+1:]
+}
+
+// addCounts adds the counts of src to dst.
+func addCounts(dst, src map[string]int) {
+	for state, count := range src {
+		dst[state] += count
+	}
+}
+
+func certificateStates(crts []cmapi.Certificate) map[string]int {
+	states := map[string]int{}
+	for _, crt := range crts {
+		// #nosec G601 -- False positive. See https://github.com/golang/go/discussions/56010
+		states[conditionState(apiutil.GetCertificateCondition(&crt, cmapi.CertificateConditionReady))]++
+	}
+	return states
+}
+
+func certificateRequestStates(reqs []cmapi.CertificateRequest) map[string]int {
+	states := map[string]int{}
+	for _, req := range reqs {
+		// #nosec G601 -- False positive. See https://github.com/golang/go/discussions/56010
+		cond := apiutil.GetCertificateRequestCondition(&req, cmapi.CertificateRequestConditionReady)
+		switch {
+		case apiutil.CertificateRequestIsDenied(&req):
+			states[cmapi.CertificateRequestReasonDenied]++
+		case cond == nil || cond.Reason == "":
+			states["Unknown"]++
+		default:
+			states[cond.Reason]++
+		}
+	}
+	return states
+}
+
+// issuerState returns the status of the Ready condition of an Issuer or
+// ClusterIssuer.
+func issuerState(conds []cmapi.IssuerCondition) string {
+	for _, cond := range conds {
+		if cond.Type == cmapi.IssuerConditionReady {
+			return string(cond.Status)
+		}
+	}
+	return "Unknown"
+}
+
+func orderStates(orders []cmacme.Order) map[string]int {
+	states := map[string]int{}
+	for _, order := range orders {
+		states[acmeState(order.Status.State)]++
+	}
+	return states
+}
+
+func challengeStates(challenges []cmacme.Challenge) map[string]int {
+	states := map[string]int{}
+	for _, ch := range challenges {
+		states[acmeState(ch.Status.State)]++
+	}
+	return states
+}
+
+func acmeState(state cmacme.State) string {
+	if state == cmacme.Unknown {
+		return "unknown"
+	}
+	return string(state)
+}
+
+func conditionState(cond *cmapi.CertificateCondition) string {
+	if cond == nil {
+		return "Unknown"
+	}
+	return string(cond.Status)
+}
+
+// parseMetrics sums the cert-manager metrics in the Prometheus text format
+// over all label values, which contain resource names. Histogram buckets and
+// timestamps are dropped because their sums are meaningless.
+func parseMetrics(r io.Reader) (map[string]float64, error) {
+	metrics := map[string]float64{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, rest := line, ""
+		if i := strings.IndexAny(line, "{ "); i >= 0 {
+			name, rest = line[:i], line[i:]
+		}
+		if !strings.HasPrefix(name, "certmanager_") ||
+			strings.HasSuffix(name, "_bucket") ||
+			strings.HasSuffix(name, "_timestamp_seconds") ||
+			strings.HasSuffix(name, "_time_seconds") {
+			continue
+		}
+
+		if strings.HasPrefix(rest, "{") {
+			end := strings.LastIndex(rest, "}")
+			if end < 0 {
+				continue
+			}
+			rest = rest[end+1:]
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			continue
+		}
+		value, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			continue
+		}
+		metrics[name] += value
+	}
+	return metrics, scanner.Err()
+}
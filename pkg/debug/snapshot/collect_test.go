@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cmctl/v2/internal/installation"
+)
+
+func TestParseMetrics(t *testing.T) {
+	input := `# HELP certmanager_controller_sync_call_count The number of sync() calls made by a controller.
+# TYPE certmanager_controller_sync_call_count counter
+certmanager_controller_sync_call_count{controller="certificates-issuing"} 10
+certmanager_controller_sync_call_count{controller="orders"} 5
+certmanager_certificate_ready_status{condition="True",name="my-crt",namespace="secret"} 1
+certmanager_certificate_expiration_timestamp_seconds{name="my-crt",namespace="secret"} 1.7e+09
+certmanager_http_acme_client_request_duration_seconds_bucket{le="0.1"} 3
+certmanager_http_acme_client_request_duration_seconds_count 4
+certmanager_clock_time_seconds 1.7e+09
+go_goroutines 120
+`
+	got, err := parseMetrics(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := map[string]float64{
+		"certmanager_controller_sync_call_count":                      15,
+		"certmanager_certificate_ready_status":                        1,
+		"certmanager_http_acme_client_request_duration_seconds_count": 4,
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("unexpected metrics, exp=%v got=%v", exp, got)
+	}
+}
+
+func TestComponent(t *testing.T) {
+	replicas := int32(2)
+	deploy := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "cert-manager", Labels: map[string]string{installation.ComponentLabel: "controller"}},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Image: "registry.internal.example.com/mirror/cert-manager-controller:v1.13.3",
+				Args:  []string{"--v=2", "--feature-gates=AdditionalCertificateOutputFormats=true,ServerSideApply=false"},
+			}}}},
+		},
+		Status: appsv1.DeploymentStatus{ReadyReplicas: 1},
+	}
+
+	exp := Component{
+		Component:     "controller",
+		Images:        []string{"cert-manager-controller:v1.13.3"},
+		FeatureGates:  []string{"AdditionalCertificateOutputFormats=true", "ServerSideApply=false"},
+		Replicas:      2,
+		ReadyReplicas: 1,
+	}
+	cmDeploy, ok := installation.ComponentDeployment(deploy)
+	if !ok {
+		t.Fatal("expected the Deployment to be a cert-manager component")
+	}
+	if got := component(cmDeploy); !reflect.DeepEqual(got, exp) {
+		t.Errorf("unexpected component, exp=%+v got=%+v", exp, got)
+	}
+}
+
+func TestCertificateStates(t *testing.T) {
+	ready := func(status cmmeta.ConditionStatus) cmapi.Certificate {
+		return cmapi.Certificate{Status: cmapi.CertificateStatus{Conditions: []cmapi.CertificateCondition{
+			{Type: cmapi.CertificateConditionReady, Status: status},
+		}}}
+	}
+
+	got := certificateStates([]cmapi.Certificate{
+		ready(cmmeta.ConditionTrue),
+		ready(cmmeta.ConditionTrue),
+		ready(cmmeta.ConditionFalse),
+		{},
+	})
+	exp := map[string]int{"True": 2, "False": 1, "Unknown": 1}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("unexpected states, exp=%v got=%v", exp, got)
+	}
+}
@@ -0,0 +1,355 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/pkg/util"
+	"github.com/cert-manager/cert-manager/pkg/util/versionchecker"
+	"github.com/cert-manager/cmctl/v2/internal/installation"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Capture a point-in-time JSON snapshot of a cert-manager installation for support cases.
+
+The snapshot combines the versions of the CLI, cert-manager, Kubernetes and the served
+cert-manager APIs, the images, replicas and feature gates of the cert-manager components,
+the number of resources per state and the cert-manager controller metrics.
+
+The snapshot is redacted by construction: it never contains resource names, namespaces,
+DNS names, image registries or metric labels, so it can be pasted into a public issue.
+Sections that cannot be collected are listed as unavailable and the reason is printed
+to stderr.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Capture a snapshot of the cert-manager installation in the 'cert-manager' namespace
+{{.BuildName}} debug snapshot
+
+# Capture a snapshot of an installation in the 'security' namespace and save it to a file
+{{.BuildName}} debug snapshot --cert-manager-namespace security > snapshot.json`)))
+)
+
+// Options is a struct to support debug snapshot command
+type Options struct {
+	// CertManagerNamespace is the namespace cert-manager is installed in.
+	CertManagerNamespace string
+	// MetricsPort is the port of the controller metrics endpoint.
+	MetricsPort int
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		CertManagerNamespace: "cert-manager",
+		MetricsPort:          9402,
+		IOStreams:            ioStreams,
+	}
+}
+
+// NewCmdSnapshot returns a cobra command for capturing a debug snapshot
+func NewCmdSnapshot(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "snapshot",
+		Short:   "Capture a redacted JSON snapshot of a cert-manager installation",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate(args)))
+			cmcmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.CertManagerNamespace, "cert-manager-namespace", o.CertManagerNamespace, "Namespace cert-manager is installed in.")
+	cmd.Flags().IntVar(&o.MetricsPort, "metrics-port", o.MetricsPort, "Port of the cert-manager controller metrics endpoint.")
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("debug snapshot does not accept arguments")
+	}
+	if o.CertManagerNamespace == "" {
+		return errors.New("--cert-manager-namespace must not be empty")
+	}
+	if o.MetricsPort <= 0 || o.MetricsPort > 65535 {
+		return fmt.Errorf("--metrics-port must be a valid port, got %d", o.MetricsPort)
+	}
+	return nil
+}
+
+// Run executes debug snapshot command
+func (o *Options) Run(ctx context.Context) error {
+	s := &Snapshot{
+		CapturedAt:    time.Now().UTC().Format(time.RFC3339),
+		ClientVersion: util.VersionInfo().GitVersion,
+	}
+
+	sections := []struct {
+		name    string
+		collect func(context.Context, *Snapshot) error
+	}{
+		{"serverVersion", o.collectServerVersion},
+		{"kubernetesVersion", o.collectKubernetesVersion},
+		{"components", o.collectComponents},
+		{"crdVersions", o.collectCRDVersions},
+		{"resources", o.collectResources},
+		{"metrics", o.collectMetrics},
+	}
+	for _, section := range sections {
+		if err := section.collect(ctx, s); err != nil {
+			// The error may contain names, so it is only printed locally.
+			fmt.Fprintf(o.ErrOut, "Unable to collect %s: %v\n", section.name, err)
+			s.Unavailable = append(s.Unavailable, section.name)
+		}
+	}
+
+	marshalled, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(o.Out, string(marshalled))
+	return nil
+}
+
+func (o *Options) collectServerVersion(ctx context.Context, s *Snapshot) error {
+	checker, err := versionchecker.New(o.RESTConfig, runtime.NewScheme())
+	if err != nil {
+		return err
+	}
+	version, err := checker.Version(ctx)
+	if err != nil {
+		return err
+	}
+	s.ServerVersion = version.Detected
+	return nil
+}
+
+func (o *Options) collectKubernetesVersion(_ context.Context, s *Snapshot) error {
+	version, err := o.KubeClient.Discovery().ServerVersion()
+	if err != nil {
+		return err
+	}
+	s.KubernetesVersion = version.GitVersion
+	return nil
+}
+
+func (o *Options) collectComponents(ctx context.Context, s *Snapshot) error {
+	deploys, err := installation.ListDeployments(ctx, o.KubeClient, o.CertManagerNamespace)
+	if err != nil {
+		return err
+	}
+	if len(deploys) == 0 {
+		return fmt.Errorf("no cert-manager Deployments found in namespace %q", o.CertManagerNamespace)
+	}
+
+	for _, deploy := range deploys {
+		s.Components = append(s.Components, component(deploy))
+	}
+	sort.Slice(s.Components, func(i, j int) bool {
+		return s.Components[i].Component < s.Components[j].Component
+	})
+	return nil
+}
+
+func (o *Options) collectCRDVersions(_ context.Context, s *Snapshot) error {
+	groups, err := o.KubeClient.Discovery().ServerGroups()
+	if err != nil {
+		return err
+	}
+
+	s.CRDVersions = map[string][]string{}
+	for _, group := range groups.Groups {
+		if group.Name != "cert-manager.io" && !strings.HasSuffix(group.Name, ".cert-manager.io") {
+			continue
+		}
+		for _, version := range group.Versions {
+			s.CRDVersions[group.Name] = append(s.CRDVersions[group.Name], version.Version)
+		}
+	}
+	return nil
+}
+
+func (o *Options) collectResources(ctx context.Context, s *Snapshot) error {
+	ns := metav1.NamespaceAll
+	if o.EnforceNamespace {
+		ns = o.Namespace
+	}
+	s.Resources = map[string]map[string]int{}
+
+	// Only the number of resources in every state is kept, so every page is
+	// dropped as soon as it has been counted.
+	s.Resources["Certificate"] = map[string]int{}
+	err := cmcmdutil.ListPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+		list, err := o.CMClient.CertmanagerV1().Certificates(ns).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		addCounts(s.Resources["Certificate"], certificateStates(list.Items))
+		return list.Continue, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.Resources["CertificateRequest"] = map[string]int{}
+	err = cmcmdutil.ListPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+		list, err := o.CMClient.CertmanagerV1().CertificateRequests(ns).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		addCounts(s.Resources["CertificateRequest"], certificateRequestStates(list.Items))
+		return list.Continue, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.Resources["Issuer"] = map[string]int{}
+	err = cmcmdutil.ListPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+		list, err := o.CMClient.CertmanagerV1().Issuers(ns).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		for _, issuer := range list.Items {
+			s.Resources["Issuer"][issuerState(issuer.Status.Conditions)]++
+		}
+		return list.Continue, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.Resources["ClusterIssuer"] = map[string]int{}
+	err = cmcmdutil.ListPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+		list, err := o.CMClient.CertmanagerV1().ClusterIssuers().List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		for _, issuer := range list.Items {
+			s.Resources["ClusterIssuer"][issuerState(issuer.Status.Conditions)]++
+		}
+		return list.Continue, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.Resources["Order"] = map[string]int{}
+	err = cmcmdutil.ListPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+		list, err := o.CMClient.AcmeV1().Orders(ns).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		addCounts(s.Resources["Order"], orderStates(list.Items))
+		return list.Continue, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.Resources["Challenge"] = map[string]int{}
+	err = cmcmdutil.ListPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+		list, err := o.CMClient.AcmeV1().Challenges(ns).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		addCounts(s.Resources["Challenge"], challengeStates(list.Items))
+		return list.Continue, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *Options) collectMetrics(ctx context.Context, s *Snapshot) error {
+	// The Pods are found through the controller Deployment, so that Pods of
+	// other projects that use the same component label are not scraped.
+	deploys, err := installation.ListDeployments(ctx, o.KubeClient, o.CertManagerNamespace)
+	if err != nil {
+		return err
+	}
+	var controller *installation.Deployment
+	for i := range deploys {
+		if deploys[i].Component == "controller" {
+			controller = &deploys[i]
+			break
+		}
+	}
+	if controller == nil {
+		return fmt.Errorf("no cert-manager controller Deployment found in namespace %q", o.CertManagerNamespace)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(controller.Spec.Selector)
+	if err != nil {
+		return fmt.Errorf("invalid selector of the controller Deployment: %w", err)
+	}
+	pods, err := o.KubeClient.CoreV1().Pods(o.CertManagerNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	var pod *corev1.Pod
+	for i := range pods.Items {
+		if pods.Items[i].Status.Phase == corev1.PodRunning {
+			pod = &pods.Items[i]
+			break
+		}
+	}
+	if pod == nil {
+		return fmt.Errorf("no running cert-manager controller Pod found in namespace %q", o.CertManagerNamespace)
+	}
+
+	raw, err := o.KubeClient.CoreV1().Pods(pod.Namespace).ProxyGet("http", pod.Name, fmt.Sprint(o.MetricsPort), "/metrics", nil).DoRaw(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.Metrics, err = parseMetrics(bytes.NewReader(raw))
+	return err
+}
@@ -20,17 +20,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
-	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
 	"github.com/cert-manager/cmctl/v2/pkg/build"
 	"github.com/cert-manager/cmctl/v2/pkg/factory"
 )
@@ -45,6 +47,12 @@ var (
 
 # Deny a CertificateRequest giving a custom reason and message
 {{.BuildName}} deny my-cr --reason "ManualDenial" --reason "Denied by PKI department"
+
+# Deny the CertificateRequests listed on stdin, one 'namespace/name' per line
+cat requests.txt | {{.BuildName}} deny - --yes
+
+# Check that the API server accepts the change, without denying the CertificateRequest
+{{.BuildName}} deny my-cr --dry-run=server
 `)))
 )
 
@@ -57,6 +65,15 @@ type Options struct {
 	// Denied condition.
 	Message string
 
+	// DryRun prints the CertificateRequests that would be denied, without
+	// updating them or only submitting dry-run requests.
+	DryRun cmcmdutil.DryRun
+	// Concurrency is the maximum number of CertificateRequests that are
+	// denied at the same time when their names are read from stdin.
+	Concurrency int
+	// Yes confirms that all CertificateRequests read from stdin are denied.
+	Yes bool
+
 	genericclioptions.IOStreams
 	*factory.Factory
 }
@@ -78,8 +95,10 @@ func NewCmdDeny(ctx context.Context, ioStreams genericclioptions.IOStreams) *cob
 		Example:           example,
 		ValidArgsFunction: factory.ValidArgsListCertificateRequests(ctx, &o.Factory),
 		Run: func(cmd *cobra.Command, args []string) {
-			cmdutil.CheckErr(o.Validate(args))
-			cmdutil.CheckErr(o.Run(ctx, args))
+			args, err := o.PickCertificateRequest(ctx, o.IOStreams, args)
+			cmcmdutil.CheckErr(err)
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate(args)))
+			cmcmdutil.CheckErr(o.Run(ctx, args))
 		},
 	}
 
@@ -87,6 +106,9 @@ func NewCmdDeny(ctx context.Context, ioStreams genericclioptions.IOStreams) *cob
 		"The reason to give as to what denied this CertificateRequest.")
 	cmd.Flags().StringVar(&o.Message, "message", fmt.Sprintf("manually denied by %q", build.Name()),
 		"The message to give as to why this CertificateRequest was denied.")
+	cmcmdutil.AddDryRunFlag(cmd.Flags(), &o.DryRun)
+	cmcmdutil.AddConcurrencyFlag(cmd.Flags(), &o.Concurrency)
+	cmcmdutil.AddConfirmFlags(cmd.Flags(), &o.Yes)
 
 	o.Factory = factory.New(ctx, cmd)
 
@@ -110,12 +132,32 @@ func (o *Options) Validate(args []string) error {
 		return errors.New("a message must be given as to why this CertificateRequest is denied")
 	}
 
-	return nil
+	return cmcmdutil.ValidateConcurrency(o.Concurrency)
 }
 
 // Run executes deny command
 func (o *Options) Run(ctx context.Context, args []string) error {
-	cr, err := o.CMClient.CertmanagerV1().CertificateRequests(o.Namespace).Get(ctx, args[0], metav1.GetOptions{})
+	names := []types.NamespacedName{{Namespace: o.Namespace, Name: args[0]}}
+	if cmcmdutil.IsStdinArg(args) {
+		var err error
+		names, err = cmcmdutil.ReadNames(o.In, o.Namespace)
+		if err != nil {
+			return err
+		}
+		if !o.DryRun.Enabled() {
+			what := fmt.Sprintf("deny %d CertificateRequest(s)", len(names))
+			if err := cmcmdutil.ConfirmStdinNames(o.Yes, len(names), what); err != nil {
+				return err
+			}
+		}
+	}
+
+	bulk := cmcmdutil.Bulk{Concurrency: o.Concurrency, Out: o.Out}
+	return cmcmdutil.ForEach(ctx, bulk, names, types.NamespacedName.String, o.deny)
+}
+
+func (o *Options) deny(ctx context.Context, out io.Writer, name types.NamespacedName) error {
+	cr, err := o.CMClient.CertmanagerV1().CertificateRequests(name.Namespace).Get(ctx, name.Name, metav1.GetOptions{})
 	if err != nil {
 		return err
 	}
@@ -131,12 +173,14 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 	apiutil.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionDenied,
 		cmmeta.ConditionTrue, o.Reason, o.Message)
 
-	_, err = o.CMClient.CertmanagerV1().CertificateRequests(o.Namespace).UpdateStatus(ctx, cr, metav1.UpdateOptions{})
-	if err != nil {
-		return err
+	if !o.DryRun.SkipRequest() {
+		_, err = o.CMClient.CertmanagerV1().CertificateRequests(cr.Namespace).UpdateStatus(ctx, cr, metav1.UpdateOptions{DryRun: o.DryRun.Options()})
+		if err != nil {
+			return err
+		}
 	}
 
-	fmt.Fprintf(o.Out, "Denied CertificateRequest '%s/%s'\n", cr.Namespace, cr.Name)
+	fmt.Fprintf(out, "Denied CertificateRequest '%s/%s'%s\n", cr.Namespace, cr.Name, o.DryRun.Suffix())
 
 	return nil
 }
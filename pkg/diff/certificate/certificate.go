@@ -0,0 +1,217 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/pkg/util/predicate"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Compare the spec of a cert-manager Certificate with the X.509 certificate stored in its Secret
+and with the CSR of its most recent CertificateRequest.
+
+Every field is shown side by side, and the DRIFT column lists the sources whose value
+differs from the Certificate spec. This helps to explain unexpected re-issuance or a
+Secret that does not reflect the latest spec.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Compare the Certificate 'my-crt' in namespace 'my-namespace' with what was issued
+{{.BuildName}} diff certificate my-crt --namespace my-namespace
+`)))
+)
+
+// Options is a struct to support diff certificate command
+type Options struct {
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdDiffCert returns a cobra command for diff certificate
+func NewCmdDiffCert(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:               "certificate",
+		Aliases:           cmcmdutil.KindAliases(cmapi.CertificateKind, "certificate"),
+		Short:             "Compare a Certificate spec with its issued Secret and latest CertificateRequest",
+		Long:              long,
+		Example:           example,
+		ValidArgsFunction: factory.ValidArgsListCertificates(ctx, &o.Factory),
+		Run: func(cmd *cobra.Command, args []string) {
+			args, err := o.PickCertificate(ctx, o.IOStreams, factory.PickToRead, args)
+			cmcmdutil.CheckErr(err)
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate(args)))
+			cmcmdutil.CheckErr(o.Run(ctx, args))
+		},
+	}
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) < 1 {
+		return errors.New("the name of the Certificate has to be provided as argument")
+	}
+	if len(args) > 1 {
+		return errors.New("only one argument can be passed in: the name of the Certificate")
+	}
+	return nil
+}
+
+// Run executes diff certificate command
+func (o *Options) Run(ctx context.Context, args []string) error {
+	crt, err := o.CMClient.CertmanagerV1().Certificates(o.Namespace).Get(ctx, args[0], metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error when getting Certificate resource: %w", err)
+	}
+
+	spec, err := fieldsFromSpec(crt)
+	if err != nil {
+		return err
+	}
+
+	var secret, request fields
+
+	sec, err := o.KubeClient.CoreV1().Secrets(crt.Namespace).Get(ctx, crt.Spec.SecretName, metav1.GetOptions{})
+	if err != nil {
+		fmt.Fprintf(o.ErrOut, "Unable to read Secret %q: %v\n", crt.Spec.SecretName, err)
+	} else if secret, err = fieldsFromSecret(sec); err != nil {
+		fmt.Fprintf(o.ErrOut, "Unable to decode certificate in Secret %q: %v\n", sec.Name, err)
+	}
+
+	req, err := findLatestCR(ctx, o, crt)
+	switch {
+	case err != nil:
+		fmt.Fprintf(o.ErrOut, "Unable to find CertificateRequest: %v\n", err)
+	case req == nil:
+		fmt.Fprintln(o.ErrOut, "No CertificateRequest found for this Certificate")
+	default:
+		if request, err = fieldsFromRequest(req); err != nil {
+			fmt.Fprintf(o.ErrOut, "Unable to decode CSR in CertificateRequest %q: %v\n", req.Name, err)
+		}
+	}
+
+	fmt.Fprintf(o.Out, "Certificate: %s/%s\n", crt.Namespace, crt.Name)
+	if sec != nil && secret != nil {
+		fmt.Fprintf(o.Out, "Secret: %s\n", sec.Name)
+	}
+	if req != nil && request != nil {
+		fmt.Fprintf(o.Out, "CertificateRequest: %s (revision %s)\n", req.Name, req.Annotations[cmapi.CertificateRequestRevisionAnnotationKey])
+	}
+	fmt.Fprintln(o.Out)
+
+	rows := compare(spec, secret, request)
+
+	w := util.NewTabWriter(o.Out)
+	fmt.Fprint(w, "FIELD\tSPEC\tSECRET\tREQUEST\tDRIFT\n")
+	drifted := 0
+	for _, r := range rows {
+		if r.Drift != "" {
+			drifted++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.Field, r.Spec, r.Secret, r.Request, r.Drift)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(o.Out)
+	if drifted == 0 {
+		fmt.Fprintln(o.Out, "No drift detected")
+	} else {
+		fmt.Fprintf(o.Out, "Drift detected in %d field(s)\n", drifted)
+	}
+
+	return nil
+}
+
+// findLatestCR returns the CertificateRequest owned by crt with the highest
+// revision, or nil if none exists.
+func findLatestCR(ctx context.Context, o *Options, crt *cmapi.Certificate) (*cmapi.CertificateRequest, error) {
+	reqs, err := o.CMClient.CertmanagerV1().CertificateRequests(crt.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error when listing CertificateRequest resources: %w", err)
+	}
+
+	var (
+		latest         *cmapi.CertificateRequest
+		latestRevision int
+	)
+	for _, req := range reqs.Items {
+		// #nosec G601 -- False positive. See https://github.com/golang/go/discussions/56010
+		if !predicate.ResourceOwnedBy(crt)(&req) {
+			continue
+		}
+		revision, err := strconv.Atoi(req.Annotations[cmapi.CertificateRequestRevisionAnnotationKey])
+		if err != nil {
+			continue
+		}
+		if latest == nil || revision > latestRevision {
+			latest = req.DeepCopy()
+			latestRevision = revision
+		}
+	}
+
+	return latest, nil
+}
+
+func fieldsFromSecret(secret *corev1.Secret) (fields, error) {
+	cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, err
+	}
+
+	return fieldsFromX509(cert, secret.Annotations), nil
+}
+
+func fieldsFromRequest(req *cmapi.CertificateRequest) (fields, error) {
+	csr, err := pki.DecodeX509CertificateRequestBytes(req.Spec.Request)
+	if err != nil {
+		return nil, err
+	}
+
+	return fieldsFromCSR(csr, req), nil
+}
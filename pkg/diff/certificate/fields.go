@@ -0,0 +1,283 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"sort"
+	"strconv"
+	"strings"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	fieldCommonName     = "Common Name"
+	fieldDNSNames       = "DNS Names"
+	fieldIPAddresses    = "IP Addresses"
+	fieldURIs           = "URIs"
+	fieldEmailAddresses = "Email Addresses"
+	fieldOrganizations  = "Organizations"
+	fieldIsCA           = "Is CA"
+	fieldUsages         = "Usages"
+	fieldKeyAlgorithm   = "Key Algorithm"
+	fieldKeySize        = "Key Size"
+	fieldDuration       = "Duration"
+	fieldIssuer         = "Issuer"
+)
+
+// fieldOrder is the order in which fields are printed.
+var fieldOrder = []string{
+	fieldCommonName,
+	fieldDNSNames,
+	fieldIPAddresses,
+	fieldURIs,
+	fieldEmailAddresses,
+	fieldOrganizations,
+	fieldIsCA,
+	fieldUsages,
+	fieldKeyAlgorithm,
+	fieldKeySize,
+	fieldDuration,
+	fieldIssuer,
+}
+
+// fields holds the normalised, printable value of every compared field for a
+// single source. A nil fields means the source is not available.
+type fields map[string]string
+
+// row is a single line of the three-way comparison.
+type row struct {
+	Field   string
+	Spec    string
+	Secret  string
+	Request string
+	// Drift is a comma separated list of the sources that differ from the
+	// Certificate spec.
+	Drift string
+}
+
+// compare builds the three-way comparison of spec against secret and request.
+// Sources that are nil are shown as "<n/a>" and never reported as drifted.
+func compare(spec, secret, request fields) []row {
+	rows := make([]row, 0, len(fieldOrder))
+	for _, name := range fieldOrder {
+		r := row{
+			Field:   name,
+			Spec:    valueOrNA(spec, name),
+			Secret:  valueOrNA(secret, name),
+			Request: valueOrNA(request, name),
+		}
+
+		var drift []string
+		if secret != nil && secret[name] != spec[name] {
+			drift = append(drift, "secret")
+		}
+		if request != nil && request[name] != spec[name] {
+			drift = append(drift, "request")
+		}
+		r.Drift = strings.Join(drift, ",")
+
+		rows = append(rows, r)
+	}
+	return rows
+}
+
+func valueOrNA(f fields, name string) string {
+	if f == nil {
+		return "<n/a>"
+	}
+	return f[name]
+}
+
+func fieldsFromSpec(crt *cmapi.Certificate) (fields, error) {
+	spec := crt.Spec
+
+	var organizations []string
+	if spec.Subject != nil {
+		organizations = spec.Subject.Organizations
+	}
+
+	usages := spec.Usages
+	if len(usages) == 0 {
+		usages = cmapi.DefaultKeyUsages()
+	}
+	ku, eku, err := pki.KeyUsagesForCertificateOrCertificateRequest(usages, spec.IsCA)
+	if err != nil {
+		return nil, err
+	}
+
+	algorithm := cmapi.RSAKeyAlgorithm
+	size := 0
+	if spec.PrivateKey != nil {
+		if spec.PrivateKey.Algorithm != "" {
+			algorithm = spec.PrivateKey.Algorithm
+		}
+		size = spec.PrivateKey.Size
+	}
+	if size == 0 {
+		switch algorithm {
+		case cmapi.RSAKeyAlgorithm:
+			size = pki.MinRSAKeySize
+		case cmapi.ECDSAKeyAlgorithm:
+			size = pki.ECCurve256
+		}
+	}
+
+	duration := cmapi.DefaultCertificateDuration
+	if spec.Duration != nil {
+		duration = spec.Duration.Duration
+	}
+
+	return fields{
+		fieldCommonName:     orNone(spec.CommonName),
+		fieldDNSNames:       joinSorted(spec.DNSNames),
+		fieldIPAddresses:    joinSorted(spec.IPAddresses),
+		fieldURIs:           joinSorted(spec.URIs),
+		fieldEmailAddresses: joinSorted(spec.EmailAddresses),
+		fieldOrganizations:  joinSorted(organizations),
+		fieldIsCA:           strconv.FormatBool(spec.IsCA),
+		fieldUsages:         joinUsages(pki.BuildCertManagerKeyUsages(ku, eku)),
+		fieldKeyAlgorithm:   string(algorithm),
+		fieldKeySize:        keySizeString(size),
+		fieldDuration:       duration.String(),
+		fieldIssuer:         issuerString(spec.IssuerRef.Name, spec.IssuerRef.Kind, spec.IssuerRef.Group),
+	}, nil
+}
+
+func fieldsFromX509(cert *x509.Certificate, annotations map[string]string) fields {
+	algorithm, size := publicKeyInfo(cert.PublicKey)
+
+	return fields{
+		fieldCommonName:     orNone(cert.Subject.CommonName),
+		fieldDNSNames:       joinSorted(cert.DNSNames),
+		fieldIPAddresses:    joinSorted(pki.IPAddressesToString(cert.IPAddresses)),
+		fieldURIs:           joinSorted(pki.URLsToString(cert.URIs)),
+		fieldEmailAddresses: joinSorted(cert.EmailAddresses),
+		fieldOrganizations:  joinSorted(cert.Subject.Organization),
+		fieldIsCA:           strconv.FormatBool(cert.IsCA),
+		fieldUsages:         joinUsages(pki.BuildCertManagerKeyUsages(cert.KeyUsage, cert.ExtKeyUsage)),
+		fieldKeyAlgorithm:   algorithm,
+		fieldKeySize:        keySizeString(size),
+		fieldDuration:       cert.NotAfter.Sub(cert.NotBefore).String(),
+		fieldIssuer: issuerString(
+			annotations[cmapi.IssuerNameAnnotationKey],
+			annotations[cmapi.IssuerKindAnnotationKey],
+			annotations[cmapi.IssuerGroupAnnotationKey],
+		),
+	}
+}
+
+func fieldsFromCSR(csr *x509.CertificateRequest, req *cmapi.CertificateRequest) fields {
+	algorithm, size := publicKeyInfo(csr.PublicKey)
+
+	usages := req.Spec.Usages
+	if len(usages) == 0 {
+		usages = cmapi.DefaultKeyUsages()
+	}
+	// Normalise the usages of the request the same way as the spec usages,
+	// so that ordering and CA defaults do not show up as drift.
+	usagesString := joinUsages(usages)
+	if ku, eku, err := pki.KeyUsagesForCertificateOrCertificateRequest(usages, req.Spec.IsCA); err == nil {
+		usagesString = joinUsages(pki.BuildCertManagerKeyUsages(ku, eku))
+	}
+
+	// The duration is defaulted the same way as the spec duration, so that
+	// a Certificate that leaves it unset does not show drift.
+	duration := cmapi.DefaultCertificateDuration
+	if req.Spec.Duration != nil {
+		duration = req.Spec.Duration.Duration
+	}
+
+	return fields{
+		fieldCommonName:     orNone(csr.Subject.CommonName),
+		fieldDNSNames:       joinSorted(csr.DNSNames),
+		fieldIPAddresses:    joinSorted(pki.IPAddressesToString(csr.IPAddresses)),
+		fieldURIs:           joinSorted(pki.URLsToString(csr.URIs)),
+		fieldEmailAddresses: joinSorted(csr.EmailAddresses),
+		fieldOrganizations:  joinSorted(csr.Subject.Organization),
+		fieldIsCA:           strconv.FormatBool(req.Spec.IsCA),
+		fieldUsages:         usagesString,
+		fieldKeyAlgorithm:   algorithm,
+		fieldKeySize:        keySizeString(size),
+		fieldDuration:       duration.String(),
+		fieldIssuer:         issuerString(req.Spec.IssuerRef.Name, req.Spec.IssuerRef.Kind, req.Spec.IssuerRef.Group),
+	}
+}
+
+// publicKeyInfo returns the cert-manager name of the key algorithm and the
+// key size of pub. The size is 0 for algorithms that have a fixed size.
+func publicKeyInfo(pub crypto.PublicKey) (string, int) {
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		return string(cmapi.RSAKeyAlgorithm), k.N.BitLen()
+	case *ecdsa.PublicKey:
+		return string(cmapi.ECDSAKeyAlgorithm), k.Curve.Params().BitSize
+	case nil:
+		return "<none>", 0
+	default:
+		return string(cmapi.Ed25519KeyAlgorithm), 0
+	}
+}
+
+func issuerString(name, kind, group string) string {
+	if name == "" {
+		return "<none>"
+	}
+	if kind == "" {
+		kind = cmapi.IssuerKind
+	}
+	if group != "" && group != "cert-manager.io" {
+		kind = kind + "." + group
+	}
+	return kind + "/" + name
+}
+
+func keySizeString(size int) string {
+	if size == 0 {
+		return "-"
+	}
+	return strconv.Itoa(size)
+}
+
+func joinUsages(usages []cmapi.KeyUsage) string {
+	s := make([]string, 0, len(usages))
+	for _, u := range usages {
+		s = append(s, string(u))
+	}
+	return joinSorted(s)
+}
+
+func joinSorted(in []string) string {
+	if len(in) == 0 {
+		return "<none>"
+	}
+	s := append([]string(nil), in...)
+	sort.Strings(s)
+	return strings.Join(s, ",")
+}
+
+func orNone(in string) string {
+	if in == "" {
+		return "<none>"
+	}
+	return in
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestCompare(t *testing.T) {
+	key, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	crt := gen.Certificate("testing-cert",
+		gen.SetCertificateCommonName("cert-manager.test"),
+		gen.SetCertificateDNSNames("cert-manager.test", "www.cert-manager.test"),
+		gen.SetCertificateKeyAlgorithm(cmapi.ECDSAKeyAlgorithm),
+		gen.SetCertificateKeySize(256),
+		gen.SetCertificateDuration(time.Hour),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca", Kind: "ClusterIssuer"}),
+	)
+
+	template, err := pki.GenerateTemplate(crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, x509Cert, err := pki.SignCertificate(template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	annotations := map[string]string{
+		cmapi.IssuerNameAnnotationKey: "ca",
+		cmapi.IssuerKindAnnotationKey: "ClusterIssuer",
+	}
+
+	spec, err := fieldsFromSpec(crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := fieldsFromX509(x509Cert, annotations)
+
+	tests := map[string]struct {
+		spec, secret, request fields
+		expDrift              map[string]string
+	}{
+		"a freshly issued certificate has no drift": {
+			spec:     spec,
+			secret:   secret,
+			expDrift: map[string]string{},
+		},
+		"a missing secret is never reported as drift": {
+			spec:     spec,
+			expDrift: map[string]string{},
+		},
+		"changed DNS names in the spec are reported for secret and request": {
+			spec:    withField(spec, fieldDNSNames, "cert-manager.test"),
+			secret:  secret,
+			request: secret,
+			expDrift: map[string]string{
+				fieldDNSNames: "secret,request",
+			},
+		},
+		"a different issuer on the secret is only reported for the secret": {
+			spec:    spec,
+			secret:  withField(secret, fieldIssuer, "Issuer/other"),
+			request: spec,
+			expDrift: map[string]string{
+				fieldIssuer: "secret",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rows := compare(test.spec, test.secret, test.request)
+			if len(rows) != len(fieldOrder) {
+				t.Fatalf("expected %d rows, got %d", len(fieldOrder), len(rows))
+			}
+			for _, r := range rows {
+				if r.Drift != test.expDrift[r.Field] {
+					t.Errorf("unexpected drift for %q: exp=%q got=%q (spec=%q secret=%q request=%q)",
+						r.Field, test.expDrift[r.Field], r.Drift, r.Spec, r.Secret, r.Request)
+				}
+			}
+		})
+	}
+}
+
+func withField(f fields, name, value string) fields {
+	out := fields{}
+	for k, v := range f {
+		out[k] = v
+	}
+	out[name] = value
+	return out
+}
+
+func TestCompareDefaultDuration(t *testing.T) {
+	key, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Neither the Certificate nor its CertificateRequest set a duration, so
+	// both use the default.
+	crt := gen.Certificate("testing-cert",
+		gen.SetCertificateCommonName("cert-manager.test"),
+		gen.SetCertificateKeyAlgorithm(cmapi.ECDSAKeyAlgorithm),
+		gen.SetCertificateKeySize(256),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca", Kind: "ClusterIssuer"}),
+	)
+	template, err := pki.GenerateCSR(crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrDER, err := pki.EncodeCSR(template, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := gen.CertificateRequest("testing-cert-1",
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "ca", Kind: "ClusterIssuer"}),
+	)
+
+	spec, err := fieldsFromSpec(crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range compare(spec, nil, fieldsFromCSR(csr, req)) {
+		if r.Drift != "" {
+			t.Errorf("unexpected drift for %q: spec=%q request=%q", r.Field, r.Spec, r.Request)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diff
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/cert-manager/cmctl/v2/pkg/diff/certificate"
+)
+
+func NewCmdDiff(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	cmds := &cobra.Command{
+		Use:   "diff",
+		Short: "Compare the desired state of cert-manager resources with what was issued",
+		Long:  `Compare the desired state of cert-manager resources with what was actually issued, e.g. the X.509 certificate stored in a Certificate's Secret`,
+	}
+
+	cmds.AddCommand(certificate.NewCmdDiffCert(ctx, ioStreams))
+
+	return cmds
+}
@@ -0,0 +1,299 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cmctl/v2/internal/printers"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Show the Events of cert-manager resources, i.e. Certificates, CertificateRequests, Orders,
+Challenges, Issuers and ClusterIssuers.
+
+Events are printed oldest first. With --follow, new Events are streamed as they occur until
+the command is interrupted.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Show the Events of cert-manager resources in the current namespace
+{{.BuildName}} events
+
+# Stream the Events of the Certificate 'my-crt'
+{{.BuildName}} events --follow --for certificate/my-crt
+
+# Stream all Warning Events in the cluster as JSON
+{{.BuildName}} events --follow --all-namespaces --types Warning -o json
+
+# Print the reason of every Event on its own line
+{{.BuildName}} events -o jsonpath='{.reason}{"\n"}'`)))
+)
+
+// Options is a struct to support events command
+type Options struct {
+	// Follow streams new Events after the existing ones have been printed.
+	Follow bool
+	// For restricts the Events to a single resource, in the form kind/name.
+	For string
+	// Types restricts the Events to the given types, e.g. Warning.
+	Types         []string
+	AllNamespaces bool
+	// Output is the target output format. This may be of value "", "json" or
+	// one of the JSONPath formats supported by printers.NewPrinter.
+	Output string
+	// Color is one of auto, always or never.
+	Color string
+
+	forKind, forName string
+	color            bool
+	printer          *printers.Printer
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		Color:     "auto",
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdEvents returns a cobra command for events
+func NewCmdEvents(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "events",
+		Short:   "Show Events of cert-manager resources",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate(args)))
+			cmcmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+
+	cmd.Flags().BoolVarP(&o.Follow, "follow", "f", o.Follow, "If true, stream new Events as they occur.")
+	cmd.Flags().StringVar(&o.For, "for", o.For, "Only show Events of the given resource, in the form kind/name, e.g. certificate/my-crt")
+	cmd.Flags().StringSliceVar(&o.Types, "types", o.Types, "Only show Events of the given types, e.g. Normal or Warning")
+	factory.AddAllNamespacesFlag(cmd, &o.AllNamespaces, "show Events")
+	cmd.Flags().StringVarP(&o.Output, "output", "o", o.Output, "Output format. One of 'json', which prints one JSON object per line, or 'jsonpath=<template>', 'jsonpath-as-json=<template>' or 'jsonpath-file=<path>', which are evaluated for every Event.")
+	cmcmdutil.AllowOutputFromEnv(cmd.Flags(), "json")
+	cmd.Flags().StringVar(&o.Color, "color", o.Color, "When to colorize the Event type, one of 'auto', 'always' or 'never'")
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("events does not accept arguments, use --for to select a resource")
+	}
+
+	if len(o.For) > 0 {
+		var err error
+		if o.forKind, o.forName, err = parseFor(o.For); err != nil {
+			return err
+		}
+	}
+
+	for _, t := range o.Types {
+		if t != corev1.EventTypeNormal && t != corev1.EventTypeWarning {
+			return fmt.Errorf("unsupported Event type %q, must be %s or %s", t, corev1.EventTypeNormal, corev1.EventTypeWarning)
+		}
+	}
+
+	switch {
+	case o.Output == "", o.Output == "json":
+	case strings.HasPrefix(o.Output, "jsonpath"):
+		var err error
+		if o.printer, err = printers.NewPrinter(o.Output); err != nil {
+			return err
+		}
+	default:
+		return errors.New(`--output must be '', 'json' or a JSONPath format`)
+	}
+
+	switch o.Color {
+	case "always":
+		o.color = true
+	case "never":
+	case "auto":
+		f, ok := o.Out.(*os.File)
+		o.color = ok && term.IsTerminal(int(f.Fd()))
+	default:
+		return errors.New(`--color must be 'auto', 'always' or 'never'`)
+	}
+
+	return nil
+}
+
+// Run executes events command
+func (o *Options) Run(ctx context.Context) error {
+	ns := o.NamespaceOrAll(o.AllNamespaces)
+
+	var selectors []fields.Selector
+	if len(o.forKind) > 0 {
+		selectors = append(selectors,
+			fields.OneTermEqualSelector("involvedObject.kind", o.forKind),
+			fields.OneTermEqualSelector("involvedObject.name", o.forName),
+		)
+	}
+	if len(o.Types) == 1 {
+		selectors = append(selectors, fields.OneTermEqualSelector("type", o.Types[0]))
+	}
+	fieldSelector := fields.AndSelectors(selectors...).String()
+
+	events := o.KubeClient.CoreV1().Events(ns)
+	// All pages of a list are served from the same snapshot, so the resource
+	// version of any page is where a watch continues.
+	var (
+		items           []corev1.Event
+		resourceVersion string
+	)
+	err := cmcmdutil.ListPages(ctx, metav1.ListOptions{FieldSelector: fieldSelector}, func(opts metav1.ListOptions) (string, error) {
+		list, err := events.List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		items = append(items, list.Items...)
+		resourceVersion = list.ResourceVersion
+		return list.Continue, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error when listing Event resources: %w", err)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return eventTime(&items[i]).Before(eventTime(&items[j]))
+	})
+
+	found := false
+	for i := range items {
+		printed, err := o.print(&items[i])
+		if err != nil {
+			return err
+		}
+		found = found || printed
+	}
+
+	if !o.Follow {
+		if !found {
+			fmt.Fprintln(o.ErrOut, "No cert-manager Events found")
+		}
+		return nil
+	}
+
+	// The RetryWatcher resumes the watch from the last seen resource version
+	// when the connection to the API server is interrupted.
+	w, err := watchtools.NewRetryWatcher(resourceVersion, &cache.ListWatch{
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector
+			return events.Watch(ctx, options)
+		},
+	})
+	if err != nil {
+		return err
+	}
+	defer w.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-w.Done():
+			return nil
+		case e, ok := <-w.ResultChan():
+			if !ok {
+				return nil
+			}
+			switch e.Type {
+			case watch.Added, watch.Modified:
+				ev, ok := e.Object.(*corev1.Event)
+				if !ok {
+					continue
+				}
+				if _, err := o.print(ev); err != nil {
+					return err
+				}
+			case watch.Error:
+				return fmt.Errorf("error while watching Events: %v", e.Object)
+			}
+		}
+	}
+}
+
+// print prints ev if it matches the filters, and returns whether it did.
+func (o *Options) print(ev *corev1.Event) (bool, error) {
+	if !isCertManagerEvent(ev) || !o.typeSelected(ev.Type) {
+		return false, nil
+	}
+
+	if o.printer != nil {
+		return true, o.printer.Print(o.Out, newRecord(ev))
+	}
+
+	if o.Output == "json" {
+		data, err := json.Marshal(newRecord(ev))
+		if err != nil {
+			return false, err
+		}
+		fmt.Fprintln(o.Out, string(data))
+		return true, nil
+	}
+
+	fmt.Fprintln(o.Out, formatEvent(ev, o.AllNamespaces, o.color))
+	return true, nil
+}
+
+func (o *Options) typeSelected(t string) bool {
+	if len(o.Types) == 0 {
+		return true
+	}
+	for _, selected := range o.Types {
+		if selected == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+)
+
+const (
+	colorReset = "\033[0m"
+	colorGreen = "\033[32m"
+	colorRed   = "\033[31m"
+)
+
+// parseFor parses a --for value of the form kind/name.
+func parseFor(s string) (kind, name string, err error) {
+	k, name, ok := strings.Cut(s, "/")
+	if !ok || name == "" {
+		return "", "", fmt.Errorf("--for must be of the form kind/name, got %q", s)
+	}
+	parsed, err := cmcmdutil.ParseKind(k)
+	if err != nil || !parsed.CertManager() {
+		return "", "", fmt.Errorf("unsupported kind %q in --for, must be a cert-manager resource kind", k)
+	}
+	return parsed.Name, name, nil
+}
+
+// isCertManagerEvent returns true if ev is about a cert-manager resource.
+func isCertManagerEvent(ev *corev1.Event) bool {
+	gv, err := schema.ParseGroupVersion(ev.InvolvedObject.APIVersion)
+	if err != nil {
+		return false
+	}
+	return gv.Group == cmapi.SchemeGroupVersion.Group || gv.Group == cmacme.SchemeGroupVersion.Group
+}
+
+// eventTime returns the time ev was last observed.
+func eventTime(ev *corev1.Event) time.Time {
+	switch {
+	case !ev.LastTimestamp.IsZero():
+		return ev.LastTimestamp.Time
+	case !ev.EventTime.IsZero():
+		return ev.EventTime.Time
+	default:
+		return ev.CreationTimestamp.Time
+	}
+}
+
+// record is the JSON representation of an Event.
+type record struct {
+	Time      time.Time `json:"time"`
+	Type      string    `json:"type"`
+	Reason    string    `json:"reason"`
+	Namespace string    `json:"namespace,omitempty"`
+	Kind      string    `json:"kind"`
+	Name      string    `json:"name"`
+	Message   string    `json:"message"`
+	Count     int32     `json:"count,omitempty"`
+}
+
+func newRecord(ev *corev1.Event) record {
+	return record{
+		Time:      eventTime(ev).UTC(),
+		Type:      ev.Type,
+		Reason:    ev.Reason,
+		Namespace: ev.InvolvedObject.Namespace,
+		Kind:      ev.InvolvedObject.Kind,
+		Name:      ev.InvolvedObject.Name,
+		Message:   ev.Message,
+		Count:     ev.Count,
+	}
+}
+
+// formatEvent returns a single line describing ev. The namespace of the
+// involved object is included if withNamespace is true, and the severity is
+// colorized if color is true.
+func formatEvent(ev *corev1.Event, withNamespace, color bool) string {
+	r := newRecord(ev)
+
+	severity := fmt.Sprintf("%-7s", r.Type)
+	if color {
+		switch r.Type {
+		case corev1.EventTypeWarning:
+			severity = colorRed + severity + colorReset
+		case corev1.EventTypeNormal:
+			severity = colorGreen + severity + colorReset
+		}
+	}
+
+	object := r.Kind + "/" + r.Name
+	if withNamespace && r.Namespace != "" {
+		object = r.Namespace + "/" + object
+	}
+
+	return fmt.Sprintf("%s  %s  %-20s  %s: %s", r.Time.Format(time.RFC3339), severity, r.Reason, object, strings.TrimSpace(r.Message))
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseFor(t *testing.T) {
+	tests := map[string]struct {
+		in               string
+		expKind, expName string
+		expErr           bool
+	}{
+		"full kind":        {in: "Certificate/my-crt", expKind: "Certificate", expName: "my-crt"},
+		"short name":       {in: "cr/my-crt-1", expKind: "CertificateRequest", expName: "my-crt-1"},
+		"plural":           {in: "challenges/my-chal", expKind: "Challenge", expName: "my-chal"},
+		"issuer alias":     {in: "ciss/letsencrypt", expKind: "ClusterIssuer", expName: "letsencrypt"},
+		"not cert-manager": {in: "csr/my-csr", expErr: true},
+		"missing name":     {in: "certificate/", expErr: true},
+		"missing kind":     {in: "my-crt", expErr: true},
+		"unknown kind":     {in: "pod/my-pod", expErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			kind, name, err := parseFor(test.in)
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error, expErr=%v got=%v", test.expErr, err)
+			}
+			if kind != test.expKind || name != test.expName {
+				t.Errorf("unexpected result, exp=%s/%s got=%s/%s", test.expKind, test.expName, kind, name)
+			}
+		})
+	}
+}
+
+func TestIsCertManagerEvent(t *testing.T) {
+	for apiVersion, exp := range map[string]bool{
+		"cert-manager.io/v1":      true,
+		"acme.cert-manager.io/v1": true,
+		"v1":                      false,
+		"networking.k8s.io/v1":    false,
+	} {
+		ev := &corev1.Event{InvolvedObject: corev1.ObjectReference{APIVersion: apiVersion}}
+		if got := isCertManagerEvent(ev); got != exp {
+			t.Errorf("unexpected result for %q, exp=%t got=%t", apiVersion, exp, got)
+		}
+	}
+}
+
+func TestFormatEvent(t *testing.T) {
+	ev := &corev1.Event{
+		InvolvedObject: corev1.ObjectReference{Namespace: "ns", Kind: "Certificate", Name: "my-crt"},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "Failed",
+		Message:        "The certificate request has failed\n",
+		LastTimestamp:  metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+
+	tests := map[string]struct {
+		withNamespace, color bool
+		exp                  string
+	}{
+		"plain": {
+			exp: "2024-01-01T00:00:00Z  Warning  Failed                Certificate/my-crt: The certificate request has failed",
+		},
+		"with namespace and color": {
+			withNamespace: true,
+			color:         true,
+			exp:           "2024-01-01T00:00:00Z  \033[31mWarning\033[0m  Failed                ns/Certificate/my-crt: The certificate request has failed",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := formatEvent(ev, test.withNamespace, test.color); got != test.exp {
+				t.Errorf("unexpected line,\nexp=%q\ngot=%q", test.exp, got)
+			}
+		})
+	}
+}
@@ -24,7 +24,9 @@ import (
 
 	"github.com/cert-manager/cmctl/v2/pkg/create"
 	"github.com/cert-manager/cmctl/v2/pkg/create/certificatesigningrequest"
+	"github.com/cert-manager/cmctl/v2/pkg/fakeissue"
 	"github.com/cert-manager/cmctl/v2/pkg/install"
+	"github.com/cert-manager/cmctl/v2/pkg/loadtest"
 	"github.com/cert-manager/cmctl/v2/pkg/uninstall"
 )
 
@@ -41,6 +43,8 @@ func NewCmdExperimental(ctx context.Context, ioStreams genericclioptions.IOStrea
 	cmds.AddCommand(create)
 	cmds.AddCommand(install.NewCmdInstall(ctx, ioStreams))
 	cmds.AddCommand(uninstall.NewCmd(ctx, ioStreams))
+	cmds.AddCommand(loadtest.NewCmdLoadTest(ctx, ioStreams))
+	cmds.AddCommand(fakeissue.NewCmdFakeIssue(ctx, ioStreams))
 
 	return cmds
 }
@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package explain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Describe the fields of cert-manager resources.
+
+The documentation is served from the CustomResourceDefinitions bundled with this binary,
+which match the cert-manager API version it was compiled against. No cluster connection
+is needed and the CRDs do not have to be installed.
+
+Fields are identified by a JSONPath-like identifier: <type>.<fieldName>[.<fieldName>],
+where the type can be given as kind, plural, singular or short name.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Describe the Certificate resource and its top-level fields
+{{.BuildName}} explain certificate
+
+# Describe the private key options of a Certificate
+{{.BuildName}} explain certificate.spec.privateKey
+
+# Show all fields of the ACME issuer configuration
+{{.BuildName}} explain issuer.spec.acme --recursive`)))
+)
+
+// Options is a struct to support explain command
+type Options struct {
+	// APIVersion is the version of the resource to explain, the storage
+	// version is used if empty.
+	APIVersion string
+	// Recursive prints the names and types of all nested fields.
+	Recursive bool
+
+	genericclioptions.IOStreams
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdExplain returns a cobra command for documenting cert-manager resource fields
+func NewCmdExplain(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "explain <type>[.<fieldName>...]",
+		Short:   "Get documentation for cert-manager resources",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate(args)))
+			cmcmdutil.CheckErr(o.Run(args))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.APIVersion, "api-version", o.APIVersion, "Get documentation for a particular API version, e.g. v1. Defaults to the storage version.")
+	cmd.Flags().BoolVar(&o.Recursive, "recursive", o.Recursive, "If true, print the names and types of all nested fields.")
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) != 1 {
+		return errors.New("exactly one resource or field has to be provided as argument, e.g. certificate.spec")
+	}
+	return nil
+}
+
+// Run executes explain command
+func (o *Options) Run(args []string) error {
+	all, err := loadCRDs()
+	if err != nil {
+		return err
+	}
+
+	parts := strings.Split(strings.Trim(args[0], "."), ".")
+	crd, err := findCRD(all, parts[0])
+	if err != nil {
+		return err
+	}
+	version, schema, err := versionSchema(crd, o.APIVersion)
+	if err != nil {
+		return err
+	}
+	field, err := lookup(schema, parts[1:])
+	if err != nil {
+		return fmt.Errorf("%s: %w", crd.Spec.Names.Kind, err)
+	}
+
+	out, closePager := cmcmdutil.StartPager(o.Out)
+	defer closePager()
+	o.Out = out
+
+	fmt.Fprintf(o.Out, "GROUP:      %s\n", crd.Spec.Group)
+	fmt.Fprintf(o.Out, "KIND:       %s\n", crd.Spec.Names.Kind)
+	fmt.Fprintf(o.Out, "VERSION:    %s\n\n", version)
+
+	name := ""
+	if len(parts) > 1 {
+		name = parts[len(parts)-1]
+	}
+	if !o.Recursive {
+		printField(o.Out, name, field)
+		return nil
+	}
+
+	if name != "" {
+		fmt.Fprintf(o.Out, "FIELD: %s <%s>\n\n", name, typeString(field))
+	}
+	fmt.Fprintln(o.Out, "DESCRIPTION:")
+	printDescription(o.Out, field.Description, "    ")
+	fmt.Fprintln(o.Out)
+	fmt.Fprintln(o.Out, "FIELDS:")
+	printTree(o.Out, field, "  ")
+	return nil
+}
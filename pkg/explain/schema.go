@@ -0,0 +1,210 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package explain
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// crds contains the CustomResourceDefinitions of the cert-manager version this
+// binary is compiled against. They are kept up to date with
+// 'make generate-explain-crds'.
+//
+//go:embed crds/*.yaml
+var crds embed.FS
+
+// loadCRDs decodes all bundled CustomResourceDefinitions.
+func loadCRDs() ([]apiextensionsv1.CustomResourceDefinition, error) {
+	entries, err := crds.ReadDir("crds")
+	if err != nil {
+		return nil, err
+	}
+
+	var out []apiextensionsv1.CustomResourceDefinition
+	for _, entry := range entries {
+		data, err := crds.ReadFile(path.Join("crds", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var crd apiextensionsv1.CustomResourceDefinition
+		if err := yaml.Unmarshal(data, &crd); err != nil {
+			return nil, fmt.Errorf("failed to decode bundled CRD %s: %w", entry.Name(), err)
+		}
+		out = append(out, crd)
+	}
+	return out, nil
+}
+
+// findCRD returns the CRD whose kind, plural, singular or short name matches
+// resource, case-insensitively.
+func findCRD(all []apiextensionsv1.CustomResourceDefinition, resource string) (*apiextensionsv1.CustomResourceDefinition, error) {
+	resource = strings.ToLower(resource)
+	for i := range all {
+		names := all[i].Spec.Names
+		candidates := append([]string{names.Kind, names.Plural, names.Singular}, names.ShortNames...)
+		for _, c := range candidates {
+			if strings.ToLower(c) == resource {
+				return &all[i], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("the server doesn't have a cert-manager resource type %q", resource)
+}
+
+// versionSchema returns the schema of the given version of crd, or of the
+// storage version if version is empty.
+func versionSchema(crd *apiextensionsv1.CustomResourceDefinition, version string) (string, *apiextensionsv1.JSONSchemaProps, error) {
+	for _, v := range crd.Spec.Versions {
+		if (version == "" && v.Storage) || v.Name == version {
+			if v.Schema == nil || v.Schema.OpenAPIV3Schema == nil {
+				return "", nil, fmt.Errorf("%s %s has no schema", crd.Spec.Names.Kind, v.Name)
+			}
+			return v.Name, v.Schema.OpenAPIV3Schema, nil
+		}
+	}
+	return "", nil, fmt.Errorf("%s does not have API version %q", crd.Spec.Names.Kind, version)
+}
+
+// lookup walks the field path through schema, descending into array items.
+func lookup(schema *apiextensionsv1.JSONSchemaProps, fields []string) (*apiextensionsv1.JSONSchemaProps, error) {
+	current := schema
+	for i, field := range fields {
+		current = elem(current)
+		next, ok := current.Properties[field]
+		if !ok {
+			return nil, fmt.Errorf("field %q does not exist", strings.Join(fields[:i+1], "."))
+		}
+		current = &next
+	}
+	return current, nil
+}
+
+// elem returns the schema of the elements of an array, or schema itself.
+func elem(schema *apiextensionsv1.JSONSchemaProps) *apiextensionsv1.JSONSchemaProps {
+	for schema.Type == "array" && schema.Items != nil && schema.Items.Schema != nil {
+		schema = schema.Items.Schema
+	}
+	return schema
+}
+
+// typeString returns the type of a field in the notation of kubectl explain.
+func typeString(schema *apiextensionsv1.JSONSchemaProps) string {
+	switch schema.Type {
+	case "array":
+		if schema.Items != nil && schema.Items.Schema != nil {
+			return "[]" + typeString(schema.Items.Schema)
+		}
+		return "[]Object"
+	case "object":
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			return "map[string]" + typeString(schema.AdditionalProperties.Schema)
+		}
+		return "Object"
+	case "":
+		if schema.XIntOrString {
+			return "IntOrString"
+		}
+		return "Object"
+	default:
+		return schema.Type
+	}
+}
+
+func sortedFields(schema *apiextensionsv1.JSONSchemaProps) []string {
+	fields := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func isRequired(schema *apiextensionsv1.JSONSchemaProps, field string) bool {
+	for _, r := range schema.Required {
+		if r == field {
+			return true
+		}
+	}
+	return false
+}
+
+// printField writes the documentation of a field and its direct children.
+func printField(w io.Writer, name string, schema *apiextensionsv1.JSONSchemaProps) {
+	if name != "" {
+		fmt.Fprintf(w, "FIELD: %s <%s>\n\n", name, typeString(schema))
+	}
+
+	fmt.Fprintln(w, "DESCRIPTION:")
+	printDescription(w, schema.Description, "    ")
+
+	if len(schema.Enum) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "ENUM:")
+		for _, e := range schema.Enum {
+			fmt.Fprintf(w, "    %s\n", e.Raw)
+		}
+	}
+
+	children := elem(schema)
+	if len(children.Properties) == 0 {
+		return
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "FIELDS:")
+	for _, field := range sortedFields(children) {
+		child := children.Properties[field]
+		required := ""
+		if isRequired(children, field) {
+			required = " -required-"
+		}
+		fmt.Fprintf(w, "  %s\t<%s>%s\n", field, typeString(&child), required)
+		printDescription(w, child.Description, "    ")
+		fmt.Fprintln(w)
+	}
+}
+
+// printTree writes the names and types of all nested fields of schema.
+func printTree(w io.Writer, schema *apiextensionsv1.JSONSchemaProps, indent string) {
+	children := elem(schema)
+	for _, field := range sortedFields(children) {
+		child := children.Properties[field]
+		required := ""
+		if isRequired(children, field) {
+			required = " -required-"
+		}
+		fmt.Fprintf(w, "%s%s\t<%s>%s\n", indent, field, typeString(&child), required)
+		printTree(w, &child, indent+"  ")
+	}
+}
+
+func printDescription(w io.Writer, description, indent string) {
+	if description == "" {
+		fmt.Fprintf(w, "%s<empty>\n", indent)
+		return
+	}
+	for _, line := range strings.Split(strings.TrimSpace(description), "\n") {
+		fmt.Fprintf(w, "%s%s\n", indent, line)
+	}
+}
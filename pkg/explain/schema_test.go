@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package explain
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestBundledCRDs(t *testing.T) {
+	all, err := loadCRDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 6 {
+		t.Fatalf("expected 6 bundled CRDs, got %d", len(all))
+	}
+
+	tests := map[string]struct {
+		resource string
+		fields   []string
+		expType  string
+		expErr   bool
+	}{
+		"kind": {
+			resource: "Certificate",
+			expType:  "Object",
+		},
+		"short name and nested field": {
+			resource: "cert",
+			fields:   []string{"spec", "privateKey", "algorithm"},
+			expType:  "string",
+		},
+		"fields of array elements": {
+			resource: "issuers",
+			fields:   []string{"spec", "acme", "solvers", "http01"},
+			expType:  "Object",
+		},
+		"array": {
+			resource: "certificate",
+			fields:   []string{"spec", "dnsNames"},
+			expType:  "[]string",
+		},
+		"unknown field": {
+			resource: "certificate",
+			fields:   []string{"spec", "doesNotExist"},
+			expErr:   true,
+		},
+		"unknown resource": {
+			resource: "ingress",
+			expErr:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crd, err := findCRD(all, test.resource)
+			if err != nil {
+				if !test.expErr {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			_, schema, err := versionSchema(crd, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			field, err := lookup(schema, test.fields)
+			if (err != nil) != test.expErr {
+				t.Fatalf("expected error=%t, got %v", test.expErr, err)
+			}
+			if err == nil && typeString(field) != test.expType {
+				t.Errorf("expected type %s, got %s", test.expType, typeString(field))
+			}
+		})
+	}
+}
+
+func TestPrintField(t *testing.T) {
+	all, err := loadCRDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	crd, err := findCRD(all, "certificate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, schema, err := versionSchema(crd, "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	field, err := lookup(schema, []string{"spec"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	printField(&buf, "spec", field)
+	out := buf.String()
+	for _, exp := range []string{"FIELD: spec <Object>", "FIELDS:", "  secretName\t<string> -required-", "  privateKey\t<Object>"} {
+		if !strings.Contains(out, exp) {
+			t.Errorf("expected output to contain %q, got:\n%s", exp, out)
+		}
+	}
+}
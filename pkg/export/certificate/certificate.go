@@ -0,0 +1,278 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Export the private key and certificate chain issued for a cert-manager Certificate to local files.
+
+Supported formats are:
+  pem: PKCS#8 private key, certificate chain and CA certificate as separate PEM files
+  der: PKCS#8 private key and leaf certificate as separate DER files
+  p12: PKCS#12 keystore containing the private key and the full chain, optionally protected by --password
+  jks: JKS keystore containing the private key, the full chain and the CA certificate, optionally protected by --password
+
+Files are named after the Certificate and are created with permissions 0600. When several
+Certificates are exported, --out-template can be used to write the files of every Certificate
+to its own directory.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Export the Certificate 'my-crt' in namespace 'my-namespace' as PEM files to the current directory
+{{.BuildName}} export certificate my-crt --namespace my-namespace
+
+# Export the Certificate 'my-crt' as a password protected PKCS#12 keystore to the directory 'certs'
+{{.BuildName}} export certificate my-crt --format p12 --password changeit --out ./certs
+
+# Export the Certificate 'my-crt' as a password protected JKS keystore for a Java application
+{{.BuildName}} export certificate my-crt --format jks --password changeit
+
+# Export all Certificates in all namespaces to the directory 'backup', one directory per Certificate
+{{.BuildName}} export certificate --all --all-namespaces --out ./backup --out-template '{{"{{.Namespace}}/{{.Name}}/{{.File}}"}}'`)))
+)
+
+// Options is a struct to support export certificate command
+type Options struct {
+	// Format is the container format of the exported files.
+	Format string
+	// OutDir is the directory the files are written to.
+	OutDir string
+	// OutTemplate is the template of the path of every file, relative to
+	// OutDir.
+	OutTemplate string
+	// Password protects the exported PKCS#12 or JKS keystore.
+	Password string
+	// Overwrite allows replacing existing files.
+	Overwrite bool
+
+	All           bool
+	AllNamespaces bool
+
+	outTemplate *cmcmdutil.OutTemplate
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		Format:    FormatPEM,
+		OutDir:    ".",
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdExportCert returns a cobra command for export certificate
+func NewCmdExportCert(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:               "certificate",
+		Aliases:           cmcmdutil.KindAliases(cmapi.CertificateKind, "certificate"),
+		Short:             "Export the key material of Certificates to local files",
+		Long:              long,
+		Example:           example,
+		ValidArgsFunction: factory.ValidArgsListCertificates(ctx, &o.Factory),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate(args)))
+			cmcmdutil.CheckErr(o.Run(ctx, args))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.Format, "format", o.Format, "Format of the exported files, one of: "+strings.Join(formats, "|"))
+	cmd.Flags().StringVar(&o.OutDir, "out", o.OutDir, "Directory to write the exported files to, will be created if it does not exist")
+	cmcmdutil.AddOutTemplateFlag(cmd.Flags(), &o.OutTemplate)
+	cmd.Flags().StringVar(&o.Password, "password", o.Password, "Password to protect the exported PKCS#12 or JKS keystore with")
+	cmd.Flags().BoolVar(&o.Overwrite, "overwrite", o.Overwrite, "If true, overwrite existing files")
+	cmd.Flags().BoolVar(&o.All, "all", o.All, "Export all Certificates in the given Namespace, or all namespaces with --all-namespaces enabled.")
+	factory.AddAllNamespacesFlag(cmd, &o.AllNamespaces, "export Certificates when used with --all")
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if o.All && len(args) > 0 {
+		return errors.New("cannot specify Certificate names in conjunction with --all flag")
+	}
+	if !o.All && len(args) < 1 {
+		return errors.New("please supply one or more Certificate names or use the --all flag")
+	}
+	if o.AllNamespaces && !o.All {
+		return errors.New("--all-namespaces can only be used in conjunction with --all flag")
+	}
+
+	valid := false
+	for _, f := range formats {
+		if o.Format == f {
+			valid = true
+		}
+	}
+	if !valid {
+		return fmt.Errorf("unsupported format %q, must be one of: %s", o.Format, strings.Join(formats, ", "))
+	}
+
+	if len(o.Password) > 0 && o.Format != FormatP12 && o.Format != FormatJKS {
+		return fmt.Errorf("--password is only supported with --format %s or %s", FormatP12, FormatJKS)
+	}
+
+	if len(o.OutDir) == 0 {
+		return errors.New("--out must not be empty")
+	}
+
+	var err error
+	o.outTemplate, err = cmcmdutil.ParseOutTemplate(o.OutTemplate)
+	return err
+}
+
+// Run executes export certificate command
+func (o *Options) Run(ctx context.Context, args []string) error {
+	crts, err := o.certificates(ctx, args)
+	if err != nil {
+		return err
+	}
+	if len(crts) == 0 {
+		fmt.Fprintln(o.ErrOut, "No Certificates found")
+		return nil
+	}
+
+	var outputs []output
+	for _, crt := range crts {
+		secret, err := o.KubeClient.CoreV1().Secrets(crt.Namespace).Get(ctx, crt.Spec.SecretName, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("error when getting Secret %q: %v", crt.Spec.SecretName, err)
+		}
+
+		m, err := decodeSecret(secret)
+		if err != nil {
+			return fmt.Errorf("error when exporting Certificate %s/%s: %w", crt.Namespace, crt.Name, err)
+		}
+
+		files, err := encode(o.Format, crt.Name, o.Password, m)
+		if err != nil {
+			return fmt.Errorf("error when exporting Certificate %s/%s: %w", crt.Namespace, crt.Name, err)
+		}
+
+		for _, f := range files {
+			path, err := o.outTemplate.Path(o.OutDir, cmcmdutil.OutputFile{
+				Namespace:  crt.Namespace,
+				Name:       crt.Name,
+				SecretName: crt.Spec.SecretName,
+				File:       f.Name,
+			})
+			if err != nil {
+				return err
+			}
+			outputs = append(outputs, output{path: path, data: f.Data})
+		}
+	}
+
+	if err := checkOutputs(outputs, o.Overwrite); err != nil {
+		return err
+	}
+
+	for _, out := range outputs {
+		if err := os.MkdirAll(filepath.Dir(out.path), 0700); err != nil {
+			return err
+		}
+		if err := os.WriteFile(out.path, out.data, 0600); err != nil {
+			return fmt.Errorf("error when writing %q: %w", out.path, err)
+		}
+		fmt.Fprintf(o.Out, "Wrote %s\n", out.path)
+	}
+
+	return nil
+}
+
+// certificates returns the Certificates selected by args or --all.
+func (o *Options) certificates(ctx context.Context, args []string) ([]cmapi.Certificate, error) {
+	if o.All {
+		ns := o.NamespaceOrAll(o.AllNamespaces)
+		var crts []cmapi.Certificate
+		err := cmcmdutil.ListPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+			list, err := o.CMClient.CertmanagerV1().Certificates(ns).List(ctx, opts)
+			if err != nil {
+				return "", err
+			}
+			crts = append(crts, list.Items...)
+			return list.Continue, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error when listing Certificate resources: %w", err)
+		}
+		return crts, nil
+	}
+
+	crts := make([]cmapi.Certificate, 0, len(args))
+	for _, name := range args {
+		crt, err := o.CMClient.CertmanagerV1().Certificates(o.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("error when getting Certificate resource: %w", err)
+		}
+		crts = append(crts, *crt)
+	}
+	return crts, nil
+}
+
+// output is a file at its final path.
+type output struct {
+	path string
+	data []byte
+}
+
+// checkOutputs makes sure that no two files are written to the same path, and
+// that no existing file is replaced unless overwrite is set. It runs before
+// anything is written, so that a failing export leaves no partial result.
+func checkOutputs(outputs []output, overwrite bool) error {
+	seen := map[string]bool{}
+	for _, out := range outputs {
+		if seen[out.path] {
+			return fmt.Errorf("more than one file would be written to %q, use --out-template to give every file a unique path", out.path)
+		}
+		seen[out.path] = true
+
+		if overwrite {
+			continue
+		}
+		if _, err := os.Stat(out.path); err == nil {
+			return fmt.Errorf("file %q already exists, use --overwrite to replace it", out.path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	jks "github.com/pavlo-v-chernykh/keystore-go/v4"
+	corev1 "k8s.io/api/core/v1"
+	"software.sslmate.com/src/go-pkcs12"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// Supported export formats.
+const (
+	FormatPEM = "pem"
+	FormatDER = "der"
+	FormatP12 = "p12"
+	FormatJKS = "jks"
+)
+
+var formats = []string{FormatPEM, FormatDER, FormatP12, FormatJKS}
+
+// file is a single file written by the export.
+type file struct {
+	Name string
+	Data []byte
+}
+
+// material is the key material decoded from a certificate Secret.
+type material struct {
+	key   crypto.Signer
+	chain []*x509.Certificate
+	ca    []*x509.Certificate
+}
+
+func decodeSecret(secret *corev1.Secret) (*material, error) {
+	if len(secret.Data[corev1.TLSCertKey]) == 0 {
+		return nil, fmt.Errorf("the Secret %q does not contain a certificate, it may not have been issued yet", secret.Name)
+	}
+	if len(secret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+		return nil, fmt.Errorf("the Secret %q does not contain a private key", secret.Name)
+	}
+
+	m := &material{}
+	var err error
+	if m.chain, err = pki.DecodeX509CertificateChainBytes(secret.Data[corev1.TLSCertKey]); err != nil {
+		return nil, fmt.Errorf("error when decoding certificate chain: %w", err)
+	}
+	if m.key, err = pki.DecodePrivateKeyBytes(secret.Data[corev1.TLSPrivateKeyKey]); err != nil {
+		return nil, fmt.Errorf("error when decoding private key: %w", err)
+	}
+	if caData := secret.Data[cmmeta.TLSCAKey]; len(caData) > 0 {
+		if m.ca, err = pki.DecodeX509CertificateChainBytes(caData); err != nil {
+			return nil, fmt.Errorf("error when decoding CA certificate: %w", err)
+		}
+	}
+	return m, nil
+}
+
+// encode returns the files for name in the requested format.
+func encode(format, name, password string, m *material) ([]file, error) {
+	switch format {
+	case FormatPEM:
+		keyDER, err := x509.MarshalPKCS8PrivateKey(m.key)
+		if err != nil {
+			return nil, err
+		}
+		files := []file{
+			{Name: name + ".crt", Data: encodePEM(m.chain)},
+			{Name: name + ".key", Data: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})},
+		}
+		if len(m.ca) > 0 {
+			files = append(files, file{Name: name + "-ca.crt", Data: encodePEM(m.ca)})
+		}
+		return files, nil
+
+	case FormatDER:
+		// DER can only hold a single certificate, so only the leaf is exported.
+		keyDER, err := x509.MarshalPKCS8PrivateKey(m.key)
+		if err != nil {
+			return nil, err
+		}
+		return []file{
+			{Name: name + ".der", Data: m.chain[0].Raw},
+			{Name: name + "-key.der", Data: keyDER},
+		}, nil
+
+	case FormatP12:
+		// The CA is included so that the keystore contains the full chain.
+		caCerts := append(append([]*x509.Certificate{}, m.chain[1:]...), m.ca...)
+		data, err := pkcs12.Modern.Encode(m.key, m.chain[0], caCerts, password)
+		if err != nil {
+			return nil, fmt.Errorf("error when encoding PKCS#12 keystore: %w", err)
+		}
+		return []file{{Name: name + ".p12", Data: data}}, nil
+
+	case FormatJKS:
+		data, err := encodeJKS(m, password)
+		if err != nil {
+			return nil, fmt.Errorf("error when encoding JKS keystore: %w", err)
+		}
+		return []file{{Name: name + ".jks", Data: data}}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported format %q", format)
+}
+
+// encodeJKS returns a JKS keystore with the same entries as the keystores
+// that cert-manager writes when spec.keystores.jks is enabled: the private key
+// with the certificate chain as "certificate", and the first CA certificate
+// as the trusted certificate "ca".
+func encodeJKS(m *material, password string) ([]byte, error) {
+	keyDER, err := x509.MarshalPKCS8PrivateKey(m.key)
+	if err != nil {
+		return nil, err
+	}
+	chain := make([]jks.Certificate, len(m.chain))
+	for i, cert := range m.chain {
+		chain[i] = jks.Certificate{Type: "X509", Content: cert.Raw}
+	}
+
+	now := time.Now()
+	ks := jks.New()
+	err = ks.SetPrivateKeyEntry("certificate", jks.PrivateKeyEntry{
+		CreationTime:     now,
+		PrivateKey:       keyDER,
+		CertificateChain: chain,
+	}, []byte(password))
+	if err != nil {
+		return nil, err
+	}
+	if len(m.ca) > 0 {
+		err = ks.SetTrustedCertificateEntry("ca", jks.TrustedCertificateEntry{
+			CreationTime: now,
+			Certificate:  jks.Certificate{Type: "X509", Content: m.ca[0].Raw},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := ks.Store(&buf, []byte(password)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encodePEM(certs []*x509.Certificate) []byte {
+	var out []byte
+	for _, cert := range certs {
+		out = append(out, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	return out
+}
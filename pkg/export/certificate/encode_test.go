@@ -0,0 +1,214 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	jks "github.com/pavlo-v-chernykh/keystore-go/v4"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"software.sslmate.com/src/go-pkcs12"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func testSecret(t *testing.T) *corev1.Secret {
+	key, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template, err := pki.GenerateTemplate(&cmapi.Certificate{
+		Spec: cmapi.CertificateSpec{CommonName: "example.com", IsCA: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM, _, err := pki.SignCertificate(template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM, err := pki.EncodeECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-crt-tls"},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+			"ca.crt":                certPEM,
+		},
+	}
+}
+
+func names(files []file) []string {
+	var out []string
+	for _, f := range files {
+		out = append(out, f.Name)
+	}
+	return out
+}
+
+func TestEncode(t *testing.T) {
+	secret := testSecret(t)
+	m, err := decodeSecret(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("pem", func(t *testing.T) {
+		files, err := encode(FormatPEM, "my-crt", "", m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := names(files); len(got) != 3 || got[0] != "my-crt.crt" || got[1] != "my-crt.key" || got[2] != "my-crt-ca.crt" {
+			t.Fatalf("unexpected files %v", got)
+		}
+		block, _ := pem.Decode(files[1].Data)
+		if block == nil || block.Type != "PRIVATE KEY" {
+			t.Fatalf("expected a PKCS#8 PEM private key, got %q", files[1].Data)
+		}
+	})
+
+	t.Run("der", func(t *testing.T) {
+		files, err := encode(FormatDER, "my-crt", "", m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := x509.ParseCertificate(files[0].Data); err != nil {
+			t.Errorf("failed to parse DER certificate: %v", err)
+		}
+		if _, err := x509.ParsePKCS8PrivateKey(files[1].Data); err != nil {
+			t.Errorf("failed to parse DER private key: %v", err)
+		}
+	})
+
+	t.Run("p12", func(t *testing.T) {
+		files, err := encode(FormatP12, "my-crt", "secret", m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, cert, ca, err := pkcs12.DecodeChain(files[0].Data, "secret")
+		if err != nil {
+			t.Fatalf("failed to decode PKCS#12 keystore: %v", err)
+		}
+		if !bytes.Equal(cert.Raw, m.chain[0].Raw) || len(ca) != 1 {
+			t.Errorf("unexpected keystore contents, got %d CA certificates", len(ca))
+		}
+	})
+
+	t.Run("jks", func(t *testing.T) {
+		files, err := encode(FormatJKS, "my-crt", "secret", m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ks := jks.New()
+		if err := ks.Load(bytes.NewReader(files[0].Data), []byte("secret")); err != nil {
+			t.Fatalf("failed to load JKS keystore: %v", err)
+		}
+		entry, err := ks.GetPrivateKeyEntry("certificate", []byte("secret"))
+		if err != nil {
+			t.Fatalf("failed to get private key entry: %v", err)
+		}
+		if len(entry.CertificateChain) != 1 || !bytes.Equal(entry.CertificateChain[0].Content, m.chain[0].Raw) {
+			t.Errorf("unexpected certificate chain with %d certificates", len(entry.CertificateChain))
+		}
+		if _, err := x509.ParsePKCS8PrivateKey(entry.PrivateKey); err != nil {
+			t.Errorf("failed to parse private key: %v", err)
+		}
+		ca, err := ks.GetTrustedCertificateEntry("ca")
+		if err != nil {
+			t.Fatalf("failed to get CA entry: %v", err)
+		}
+		if !bytes.Equal(ca.Certificate.Content, m.ca[0].Raw) {
+			t.Error("unexpected CA certificate")
+		}
+	})
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		format      string
+		password    string
+		outTemplate string
+		all         bool
+		args        []string
+		expErr      bool
+	}{
+		"pem is the default format":      {format: FormatPEM, args: []string{"my-crt"}},
+		"p12 with password":              {format: FormatP12, password: "changeit", args: []string{"my-crt"}},
+		"jks with password":              {format: FormatJKS, password: "changeit", args: []string{"my-crt"}},
+		"unknown format":                 {format: "pfx", args: []string{"my-crt"}, expErr: true},
+		"password with pem":              {format: FormatPEM, password: "changeit", args: []string{"my-crt"}, expErr: true},
+		"missing Certificate name":       {format: FormatPEM, expErr: true},
+		"more than one Certificate name": {format: FormatPEM, args: []string{"a", "b"}},
+		"all with Certificate names":     {format: FormatPEM, all: true, args: []string{"a"}, expErr: true},
+		"all Certificates":               {format: FormatPEM, all: true, outTemplate: "{{.Name}}/{{.File}}"},
+		"invalid out template":           {format: FormatPEM, args: []string{"my-crt"}, outTemplate: "{{.Name", expErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := &Options{Format: test.format, Password: test.password, OutDir: ".", OutTemplate: test.outTemplate, All: test.all}
+			err := o.Validate(test.args)
+			if (err != nil) != test.expErr {
+				t.Errorf("unexpected error, expErr=%v got=%v", test.expErr, err)
+			}
+		})
+	}
+}
+
+func TestCheckOutputs(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "existing.crt")
+	if err := os.WriteFile(existing, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		paths     []string
+		overwrite bool
+		expErr    bool
+	}{
+		"unique new files":               {paths: []string{filepath.Join(dir, "a.crt"), filepath.Join(dir, "b.crt")}},
+		"duplicate paths":                {paths: []string{filepath.Join(dir, "a.crt"), filepath.Join(dir, "a.crt")}, expErr: true},
+		"existing file":                  {paths: []string{existing}, expErr: true},
+		"existing file with overwrite":   {paths: []string{existing}, overwrite: true},
+		"duplicate paths with overwrite": {paths: []string{existing, existing}, overwrite: true, expErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var outputs []output
+			for _, p := range test.paths {
+				outputs = append(outputs, output{path: p})
+			}
+			err := checkOutputs(outputs, test.overwrite)
+			if (err != nil) != test.expErr {
+				t.Errorf("unexpected error, expErr=%v got=%v", test.expErr, err)
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@ package factory
 
 import (
 	"context"
+	"os"
+	"sync"
 
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -29,6 +31,7 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
 )
 
 var (
@@ -62,15 +65,44 @@ type Factory struct {
 	// RESTClientGetter is used to get RESTConfig, DiscoveryClients and
 	// RESTMapper implementations
 	RESTClientGetter genericclioptions.RESTClientGetter
+
+	// skip reports whether the command can run without a cluster, in which
+	// case the Factory is not populated.
+	skip func() bool
+
+	completeOnce sync.Once
+	completeErr  error
+}
+
+// Option configures a Factory.
+type Option func(*Factory)
+
+// SkipWhen returns an Option that leaves the Factory unpopulated when skip
+// returns true before the command runs. It is used by commands that only need
+// a cluster for some of their flags, e.g. 'version --client', so that they
+// neither load a kubeconfig nor build clients when they run without one.
+func SkipWhen(skip func() bool) Option {
+	return func(f *Factory) {
+		f.skip = skip
+	}
 }
 
 // New returns a new Factory. The supplied command will have flags registered
 // for interacting with the Kubernetes access options. Factory will be
-// populated when the command is executed using the cobra PreRun. If a PreRun
-// is already defined, it will be executed _after_ Factory has been populated,
-// making it available.
-func New(ctx context.Context, cmd *cobra.Command) *Factory {
+// populated when the command is executed using the cobra PreRun, after flags
+// that were not given have been set from their CMCTL_* environment variable.
+// If a PreRun is already defined, it will be executed _after_ Factory has
+// been populated, making it available.
+//
+// Only the kubeconfig is loaded and the clientsets are configured when the
+// Factory is populated; no requests are made to the cluster until a client
+// is used. Discovery and the RESTMapper of RESTClientGetter are deferred
+// until they are first needed.
+func New(ctx context.Context, cmd *cobra.Command, opts ...Option) *Factory {
 	f := new(Factory)
+	for _, opt := range opts {
+		opt(f)
+	}
 
 	kubeConfigFlags.AddFlags(cmd.Flags())
 	cmd.RegisterFlagCompletionFunc("namespace", validArgsListNamespaces(ctx, f))
@@ -79,7 +111,12 @@ func New(ctx context.Context, cmd *cobra.Command) *Factory {
 	// if one was defined, and execute it second.
 	existingPreRun := cmd.PreRun
 	cmd.PreRun = func(cmd *cobra.Command, args []string) {
-		util.CheckErr(f.complete())
+		// The CMCTL_* environment variables are resolved first, as they
+		// select the namespace and context that the Factory is populated for.
+		cmcmdutil.CheckErr(cmcmdutil.ApplyEnv(cmd.Flags(), os.LookupEnv))
+		if f.skip == nil || !f.skip() {
+			cmcmdutil.CheckErr(f.complete())
+		}
 		if existingPreRun != nil {
 			existingPreRun(cmd, args)
 		}
@@ -89,8 +126,16 @@ func New(ctx context.Context, cmd *cobra.Command) *Factory {
 }
 
 // complete will populate the Factory with values using the shared Kubernetes
-// CLI factory.
+// CLI factory. The Factory is only populated once, even if complete is
+// called again, e.g. by shell completion functions.
 func (f *Factory) complete() error {
+	f.completeOnce.Do(func() {
+		f.completeErr = f.populate()
+	})
+	return f.completeErr
+}
+
+func (f *Factory) populate() error {
 	var err error
 
 	f.Namespace, f.EnforceNamespace, err = factory.ToRawKubeConfigLoader().Namespace()
@@ -0,0 +1,42 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package factory
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AddAllNamespacesFlag registers the -A/--all-namespaces flag on cmd, so that
+// every command that can operate across namespaces exposes it in the same way.
+// action completes the sentence "If present, <action> across namespaces.",
+// e.g. "list Issuers".
+func AddAllNamespacesFlag(cmd *cobra.Command, allNamespaces *bool, action string) {
+	usage := fmt.Sprintf("If present, %s across namespaces. Namespace in current context is ignored even if specified with --namespace.", action)
+	cmd.Flags().BoolVarP(allNamespaces, "all-namespaces", "A", *allNamespaces, usage)
+}
+
+// NamespaceOrAll returns the namespace that resources should be listed in:
+// all namespaces if allNamespaces is true, the requested namespace otherwise.
+func (f *Factory) NamespaceOrAll(allNamespaces bool) string {
+	if allNamespaces {
+		return metav1.NamespaceAll
+	}
+	return f.Namespace
+}
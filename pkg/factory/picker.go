@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package factory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/metadata"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+)
+
+// PickMode is how a command uses the resource that the user picks.
+type PickMode int
+
+const (
+	// PickToRead is used by read-only commands. A namespace or resource is
+	// picked without asking if it is the only one.
+	PickToRead PickMode = iota
+	// PickToChange is used by commands that change the picked resource. The
+	// user always has to select the namespace and the resource, so that a
+	// resource is never changed without being named.
+	PickToChange
+)
+
+// PickCertificate lets the user pick a Certificate if args is empty. See
+// pickName.
+func (f *Factory) PickCertificate(ctx context.Context, ioStreams genericclioptions.IOStreams, mode PickMode, args []string) ([]string, error) {
+	lister := f.metadataLister(cmapi.SchemeGroupVersion.WithResource("certificates"))
+	return f.pickName(ctx, ioStreams, cmapi.CertificateKind, mode, lister, metav1.ListOptions{}, args)
+}
+
+// PickCertificateRequest lets the user pick a CertificateRequest that is
+// neither approved nor denied yet if args is empty, for approve and deny.
+// See pickName.
+func (f *Factory) PickCertificateRequest(ctx context.Context, ioStreams genericclioptions.IOStreams, args []string) ([]string, error) {
+	lister := func(ctx context.Context, ns string, opts metav1.ListOptions, add func(namespace, name string)) (string, error) {
+		list, err := f.CMClient.CertmanagerV1().CertificateRequests(ns).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		for i := range list.Items {
+			cr := &list.Items[i]
+			if apiutil.CertificateRequestIsApproved(cr) || apiutil.CertificateRequestIsDenied(cr) {
+				continue
+			}
+			add(cr.Namespace, cr.Name)
+		}
+		return list.Continue, nil
+	}
+	return f.pickName(ctx, ioStreams, "pending "+cmapi.CertificateRequestKind, PickToChange, lister, metav1.ListOptions{}, args)
+}
+
+// PickTLSSecret lets the user pick a Secret of type kubernetes.io/tls if args
+// is empty. See pickName.
+func (f *Factory) PickTLSSecret(ctx context.Context, ioStreams genericclioptions.IOStreams, args []string) ([]string, error) {
+	opts := metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("type", string(corev1.SecretTypeTLS)).String(),
+	}
+	lister := f.metadataLister(corev1.SchemeGroupVersion.WithResource("secrets"))
+	return f.pickName(ctx, ioStreams, "Secret", PickToRead, lister, opts, args)
+}
+
+// pageLister lists a page of the resources in ns that can be picked. It calls
+// add for every resource and returns the continue token of the next page.
+type pageLister func(ctx context.Context, ns string, opts metav1.ListOptions, add func(namespace, name string)) (string, error)
+
+// metadataLister lists only the metadata of resource, so that e.g. the
+// contents of Secrets are never retrieved.
+func (f *Factory) metadataLister(resource schema.GroupVersionResource) pageLister {
+	return func(ctx context.Context, ns string, opts metav1.ListOptions, add func(namespace, name string)) (string, error) {
+		client, err := metadata.NewForConfig(f.RESTConfig)
+		if err != nil {
+			return "", err
+		}
+		list, err := client.Resource(resource).Namespace(ns).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		for _, item := range list.Items {
+			add(item.Namespace, item.Name)
+		}
+		return list.Continue, nil
+	}
+}
+
+// pickName returns args unchanged, unless it is empty and the command runs
+// interactively. In that case the user picks the namespace, unless it was
+// given with --namespace, and then a resource in that namespace. The picked
+// namespace is stored in f.Namespace and the name of the picked resource is
+// returned as the only argument.
+func (f *Factory) pickName(ctx context.Context, ioStreams genericclioptions.IOStreams, what string, mode PickMode, lister pageLister, opts metav1.ListOptions, args []string) ([]string, error) {
+	if len(args) > 0 || !cmcmdutil.Interactive(ioStreams.In, ioStreams.ErrOut) {
+		return args, nil
+	}
+
+	pickNamespace := !f.EnforceNamespace
+	ns := f.Namespace
+	if pickNamespace {
+		ns = metav1.NamespaceAll
+	}
+	names := map[string][]string{}
+	listNames := func(ns string) error {
+		return cmcmdutil.ListPages(ctx, opts, func(opts metav1.ListOptions) (string, error) {
+			return lister(ctx, ns, opts, func(namespace, name string) {
+				names[namespace] = append(names[namespace], name)
+			})
+		})
+	}
+	err := listNames(ns)
+	if apierrors.IsForbidden(err) && pickNamespace {
+		// The user may only be allowed to list resources in the namespace
+		// of the current context.
+		pickNamespace = false
+		err = listNames(f.Namespace)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no %s found", what)
+	}
+
+	explicit := mode == PickToChange
+	if pickNamespace {
+		namespaces := make([]string, 0, len(names))
+		for namespace := range names {
+			namespaces = append(namespaces, namespace)
+		}
+		sort.Strings(namespaces)
+
+		f.Namespace, err = cmcmdutil.Pick(ioStreams.In, ioStreams.ErrOut, "namespace", namespaces, explicit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Strings(names[f.Namespace])
+	name, err := cmcmdutil.Pick(ioStreams.In, ioStreams.ErrOut, what, names[f.Namespace], explicit)
+	if err != nil {
+		return nil, err
+	}
+	return []string{name}, nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package factory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// WaitForSecret waits until condition returns true for the Secret
+// namespace/name and returns it. The Secret does not have to exist yet.
+func (f *Factory) WaitForSecret(ctx context.Context, namespace, name string, timeout time.Duration, condition func(*corev1.Secret) bool) (*corev1.Secret, error) {
+	secrets := f.KubeClient.CoreV1().Secrets(namespace)
+	return waitFor(ctx, timeout, name, &corev1.Secret{}, condition,
+		func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return secrets.List(ctx, opts)
+		},
+		secrets.Watch)
+}
+
+// WaitForCertificateRequest waits until condition returns true for the
+// CertificateRequest namespace/name and returns it.
+func (f *Factory) WaitForCertificateRequest(ctx context.Context, namespace, name string, timeout time.Duration, condition func(*cmapi.CertificateRequest) bool) (*cmapi.CertificateRequest, error) {
+	reqs := f.CMClient.CertmanagerV1().CertificateRequests(namespace)
+	return waitFor(ctx, timeout, name, &cmapi.CertificateRequest{}, condition,
+		func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return reqs.List(ctx, opts)
+		},
+		reqs.Watch)
+}
+
+// WaitForCertificateSigningRequest waits until condition returns true for the
+// CertificateSigningRequest name and returns it.
+func (f *Factory) WaitForCertificateSigningRequest(ctx context.Context, name string, timeout time.Duration, condition func(*certificatesv1.CertificateSigningRequest) bool) (*certificatesv1.CertificateSigningRequest, error) {
+	csrs := f.KubeClient.CertificatesV1().CertificateSigningRequests()
+	return waitFor(ctx, timeout, name, &certificatesv1.CertificateSigningRequest{}, condition,
+		func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return csrs.List(ctx, opts)
+		},
+		csrs.Watch)
+}
+
+// waitFor waits up to timeout until condition returns true for the object
+// called name. Instead of polling the API server with repeated GET calls, the
+// object is read from an informer that is kept up to date by a single watch,
+// which is restarted if the connection to the API server is interrupted.
+// The informer needs permission to list and watch the object. If that is
+// denied, waitFor fails immediately instead of retrying until the timeout.
+func waitFor[T runtime.Object](
+	ctx context.Context, timeout time.Duration, name string, objType T, condition func(T) bool,
+	list func(context.Context, metav1.ListOptions) (runtime.Object, error),
+	watchFunc func(context.Context, metav1.ListOptions) (watch.Interface, error),
+) (T, error) {
+	ctx, cancel := watchtools.ContextWithOptionalTimeout(ctx, timeout)
+	defer cancel()
+
+	// The informer retries every error, so errors that a retry cannot fix
+	// stop the wait from here.
+	var (
+		deniedOnce sync.Once
+		deniedErr  error
+	)
+	stopIfDenied := func(err error) {
+		if apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err) {
+			deniedOnce.Do(func() {
+				deniedErr = err
+				cancel()
+			})
+		}
+	}
+
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", name).String()
+	lw := &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			opts.FieldSelector = fieldSelector
+			obj, err := list(ctx, opts)
+			stopIfDenied(err)
+			return obj, err
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			opts.FieldSelector = fieldSelector
+			w, err := watchFunc(ctx, opts)
+			stopIfDenied(err)
+			return w, err
+		},
+	}
+
+	var result T
+	_, err := watchtools.UntilWithSync(ctx, lw, objType, nil, func(event watch.Event) (bool, error) {
+		obj, ok := event.Object.(T)
+		if !ok || event.Type == watch.Deleted || !condition(obj) {
+			return false, nil
+		}
+		result = obj
+		return true, nil
+	})
+	// Completes the Once if it was not used, so that deniedErr is safe to
+	// read even if an informer goroutine is still returning.
+	deniedOnce.Do(func() {})
+	if deniedErr != nil {
+		return result, fmt.Errorf("cannot wait for %q, the informer needs permission to list and watch it: %w", name, deniedErr)
+	}
+	if ctxErr := ctx.Err(); err != nil && ctxErr != nil {
+		// Report a timeout as such rather than as the generic error of the
+		// watch, so that the exit code reflects it.
+		return result, ctxErr
+	}
+	return result, err
+}
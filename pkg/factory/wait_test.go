@@ -0,0 +1,49 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package factory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+func TestWaitForFailsWhenListIsForbidden(t *testing.T) {
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "secrets"}, "", nil)
+	list := func(context.Context, metav1.ListOptions) (runtime.Object, error) {
+		return nil, forbidden
+	}
+	watchFunc := func(context.Context, metav1.ListOptions) (watch.Interface, error) {
+		return nil, forbidden
+	}
+
+	start := time.Now()
+	_, err := waitFor(context.Background(), time.Minute, "example", &corev1.Secret{}, func(*corev1.Secret) bool { return true }, list, watchFunc)
+	if !apierrors.IsForbidden(err) {
+		t.Fatalf("waitFor() error = %v, want a Forbidden error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("waitFor() took %s, want it to fail without waiting for the timeout", elapsed)
+	}
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakeissue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/resource"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Issue the Certificates in the given manifests locally, signed by a development CA, without a cluster.
+
+The private key and X.509 certificate are generated from the Certificate spec in the same way
+as cert-manager would, including the key algorithm, subject, SANs, usages and duration. For
+every Certificate, a directory named after its secretName is created in --out, containing the
+files tls.crt, tls.key and ca.crt as they would appear in the Secret. The layout can be changed
+with --out-template.
+
+The development CA is read from the ca.crt and ca.key files in the --ca directory. If the
+directory does not contain a CA yet, a new one is created. Never use it in production.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Issue the Certificate in 'certificate.yaml' using the development CA in './dev-ca'
+{{.BuildName}} x fake-issue -f certificate.yaml --ca ./dev-ca
+
+# Issue all Certificates in the 'manifests' directory to the directory 'certs'
+{{.BuildName}} x fake-issue -f ./manifests --recursive --ca ./dev-ca --out ./certs
+
+# Issue all Certificates in the 'manifests' directory to one directory per namespace and Certificate
+{{.BuildName}} x fake-issue -f ./manifests --recursive --ca ./dev-ca --out-template '{{"{{.Namespace}}/{{.Name}}/{{.File}}"}}'`)))
+)
+
+// Options is a struct to support fake-issue command
+type Options struct {
+	// CADir is the directory containing the development CA.
+	CADir string
+	// OutDir is the directory the issued certificates are written to.
+	OutDir string
+	// OutTemplate is the template of the path of every file, relative to
+	// OutDir.
+	OutTemplate string
+
+	outTemplate *cmcmdutil.OutTemplate
+
+	resource.FilenameOptions
+	genericclioptions.IOStreams
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		OutDir:      ".",
+		OutTemplate: "{{.SecretName}}/{{.File}}",
+		IOStreams:   ioStreams,
+	}
+}
+
+// NewCmdFakeIssue returns a cobra command for fake-issue
+func NewCmdFakeIssue(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "fake-issue",
+		Short:   "Issue Certificates locally using a development CA",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate(args)))
+			cmcmdutil.CheckErr(o.Run())
+		},
+	}
+
+	cmd.Flags().StringVar(&o.CADir, "ca", o.CADir, "Directory containing the development CA as ca.crt and ca.key, a new CA is created if they do not exist")
+	cmd.Flags().StringVar(&o.OutDir, "out", o.OutDir, "Directory to write the issued certificates to")
+	cmcmdutil.AddOutTemplateFlag(cmd.Flags(), &o.OutTemplate)
+	cmdutil.AddFilenameOptionFlags(cmd, &o.FilenameOptions, "Path to a file containing the Certificates to issue.")
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("fake-issue does not accept arguments, use -f to pass Certificate manifests")
+	}
+	if len(o.CADir) == 0 {
+		return errors.New("the directory of the development CA has to be provided with --ca")
+	}
+	if len(o.OutDir) == 0 {
+		return errors.New("--out must not be empty")
+	}
+	var err error
+	if o.outTemplate, err = cmcmdutil.ParseOutTemplate(o.OutTemplate); err != nil {
+		return err
+	}
+	return o.FilenameOptions.RequireFilenameOrKustomize()
+}
+
+// Run executes fake-issue command
+func (o *Options) Run() error {
+	crts, err := o.readCertificates()
+	if err != nil {
+		return err
+	}
+	if len(crts) == 0 {
+		return errors.New("no cert-manager.io/v1 Certificates found in the given manifests")
+	}
+
+	ca, created, err := loadOrCreateCA(o.CADir)
+	if err != nil {
+		return err
+	}
+	if created {
+		fmt.Fprintf(o.ErrOut, "Created development CA in %s\n", o.CADir)
+	}
+
+	for _, crt := range crts {
+		data, err := issue(crt, ca)
+		if err != nil {
+			return fmt.Errorf("failed to issue Certificate %q: %w", crt.Name, err)
+		}
+
+		keys := make([]string, 0, len(data))
+		for k := range data {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		paths := make([]string, 0, len(keys))
+		for _, k := range keys {
+			path, err := o.outTemplate.Path(o.OutDir, cmcmdutil.OutputFile{
+				Namespace:  crt.Namespace,
+				Name:       crt.Name,
+				SecretName: crt.Spec.SecretName,
+				File:       k,
+			})
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+				return err
+			}
+			if err := os.WriteFile(path, data[k], 0600); err != nil {
+				return err
+			}
+			paths = append(paths, path)
+		}
+
+		fmt.Fprintf(o.Out, "Issued Certificate %q to %s\n", crt.Name, strings.Join(paths, ", "))
+	}
+
+	return nil
+}
+
+func (o *Options) readCertificates() ([]*cmapi.Certificate, error) {
+	r := resource.NewLocalBuilder().
+		Unstructured().
+		FilenameParam(false, &o.FilenameOptions).
+		Flatten().
+		Do()
+	if err := r.Err(); err != nil {
+		return nil, err
+	}
+
+	infos, err := r.Infos()
+	if err != nil {
+		return nil, err
+	}
+
+	var crts []*cmapi.Certificate
+	for _, info := range infos {
+		u, ok := info.Object.(*unstructured.Unstructured)
+		if !ok || u.GetAPIVersion() != cmapi.SchemeGroupVersion.String() || u.GetKind() != cmapi.CertificateKind {
+			continue
+		}
+
+		crt := &cmapi.Certificate{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, crt); err != nil {
+			return nil, fmt.Errorf("%s: failed to decode Certificate: %w", info.Source, err)
+		}
+		if len(crt.Spec.SecretName) == 0 {
+			return nil, fmt.Errorf("%s: Certificate %q has no secretName", info.Source, crt.Name)
+		}
+		crts = append(crts, crt)
+	}
+	return crts, nil
+}
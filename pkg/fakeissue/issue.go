@@ -0,0 +1,160 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakeissue
+
+import (
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	caCertFile = "ca.crt"
+	caKeyFile  = "ca.key"
+
+	caCommonName = "cmctl development CA"
+	caDuration   = 10 * 365 * 24 * time.Hour
+)
+
+// devCA is a development CA used to sign certificates locally.
+type devCA struct {
+	cert    *x509.Certificate
+	certPEM []byte
+	key     crypto.Signer
+}
+
+// loadOrCreateCA loads the development CA from dir, or creates a new one if
+// dir does not contain a CA yet. It returns whether a new CA was created.
+func loadOrCreateCA(dir string) (*devCA, bool, error) {
+	certPEM, certErr := os.ReadFile(filepath.Join(dir, caCertFile))
+	keyPEM, keyErr := os.ReadFile(filepath.Join(dir, caKeyFile))
+
+	switch {
+	case certErr == nil && keyErr == nil:
+		ca, err := decodeCA(certPEM, keyPEM)
+		return ca, false, err
+	case errors.Is(certErr, fs.ErrNotExist) && errors.Is(keyErr, fs.ErrNotExist):
+	case certErr != nil:
+		return nil, false, certErr
+	default:
+		return nil, false, keyErr
+	}
+
+	ca, keyPEM, err := newCA()
+	if err != nil {
+		return nil, false, err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, false, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, caKeyFile), keyPEM, 0600); err != nil {
+		return nil, false, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, caCertFile), ca.certPEM, 0600); err != nil {
+		return nil, false, err
+	}
+	return ca, true, nil
+}
+
+func decodeCA(certPEM, keyPEM []byte) (*devCA, error) {
+	cert, err := pki.DecodeX509CertificateBytes(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("error when decoding CA certificate: %w", err)
+	}
+	key, err := pki.DecodePrivateKeyBytes(keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("error when decoding CA private key: %w", err)
+	}
+	if ok, err := pki.PublicKeyMatchesCertificate(key.Public(), cert); err != nil || !ok {
+		return nil, errors.New("the CA private key does not match the CA certificate")
+	}
+	return &devCA{cert: cert, certPEM: certPEM, key: key}, nil
+}
+
+func newCA() (*devCA, []byte, error) {
+	spec := &cmapi.Certificate{
+		Spec: cmapi.CertificateSpec{
+			CommonName: caCommonName,
+			IsCA:       true,
+			Duration:   &metav1.Duration{Duration: caDuration},
+			PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: pki.ECCurve256},
+		},
+	}
+
+	key, err := pki.GeneratePrivateKeyForCertificate(spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	template, err := pki.GenerateTemplate(spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	certPEM, cert, err := pki.SignCertificate(template, template, key.Public(), key)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyPEM, err := pki.EncodePrivateKey(key, cmapi.PKCS8)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &devCA{cert: cert, certPEM: certPEM, key: key}, keyPEM, nil
+}
+
+// issue generates a private key and signs a certificate for crt with ca. It
+// returns the data of the Secret cert-manager would have created.
+func issue(crt *cmapi.Certificate, ca *devCA) (map[string][]byte, error) {
+	key, err := pki.GeneratePrivateKeyForCertificate(crt)
+	if err != nil {
+		return nil, fmt.Errorf("error when generating private key: %w", err)
+	}
+
+	encoding := cmapi.PKCS1
+	if crt.Spec.PrivateKey != nil && crt.Spec.PrivateKey.Encoding != "" {
+		encoding = crt.Spec.PrivateKey.Encoding
+	}
+	keyPEM, err := pki.EncodePrivateKey(key, encoding)
+	if err != nil {
+		return nil, fmt.Errorf("error when encoding private key: %w", err)
+	}
+
+	template, err := pki.GenerateTemplate(crt)
+	if err != nil {
+		return nil, fmt.Errorf("error when generating certificate template: %w", err)
+	}
+	certPEM, _, err := pki.SignCertificate(template, ca.cert, key.Public(), ca.key)
+	if err != nil {
+		return nil, fmt.Errorf("error when signing certificate: %w", err)
+	}
+
+	return map[string][]byte{
+		corev1.TLSCertKey:       certPEM,
+		corev1.TLSPrivateKeyKey: keyPEM,
+		cmmeta.TLSCAKey:         ca.certPEM,
+	}, nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakeissue
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func TestLoadOrCreateCA(t *testing.T) {
+	dir := t.TempDir()
+
+	ca, created, err := loadOrCreateCA(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !created || !ca.cert.IsCA || ca.cert.Subject.CommonName != caCommonName {
+		t.Fatalf("expected a new development CA, got created=%t cert=%v", created, ca.cert.Subject)
+	}
+
+	loaded, created, err := loadOrCreateCA(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created || !loaded.cert.Equal(ca.cert) {
+		t.Errorf("expected the existing CA to be loaded")
+	}
+}
+
+func TestIssue(t *testing.T) {
+	ca, _, err := loadOrCreateCA(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	crt := &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-crt"},
+		Spec: cmapi.CertificateSpec{
+			SecretName: "my-crt-tls",
+			CommonName: "app.example.com",
+			DNSNames:   []string{"app.example.com", "www.example.com"},
+			Duration:   &metav1.Duration{Duration: 24 * time.Hour},
+			Usages:     []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageServerAuth},
+			PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.RSAKeyAlgorithm, Size: 3072, Encoding: cmapi.PKCS8},
+		},
+	}
+
+	data, err := issue(crt, ca)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := pki.DecodeX509CertificateBytes(data[corev1.TLSCertKey])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cert.CheckSignatureFrom(ca.cert); err != nil {
+		t.Errorf("certificate is not signed by the development CA: %v", err)
+	}
+	if !reflect.DeepEqual(cert.DNSNames, crt.Spec.DNSNames) || cert.Subject.CommonName != crt.Spec.CommonName {
+		t.Errorf("unexpected subject %v and DNS names %v", cert.Subject, cert.DNSNames)
+	}
+	if validity := cert.NotAfter.Sub(cert.NotBefore); validity != 24*time.Hour {
+		t.Errorf("unexpected validity %s", validity)
+	}
+	if !reflect.DeepEqual(cert.ExtKeyUsage, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}) {
+		t.Errorf("unexpected extended key usages %v", cert.ExtKeyUsage)
+	}
+
+	key, err := pki.DecodePrivateKeyBytes(data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rsaKey, ok := key.(*rsa.PrivateKey); !ok || rsaKey.N.BitLen() != 3072 {
+		t.Errorf("expected a 3072 bit RSA key, got %T", key)
+	}
+	if string(data["ca.crt"]) != string(ca.certPEM) {
+		t.Errorf("expected ca.crt to contain the development CA")
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewals
+
+import (
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// renewals returns the times at which crt will be renewed between now and
+// end. The first renewal is based on the currently issued certificate, and
+// every following renewal assumes a certificate with the duration from the
+// Certificate spec that is issued at the previous renewal.
+// Certificates that have not been issued yet return no renewals.
+func renewals(crt *cmapi.Certificate, now, end time.Time) []time.Time {
+	if crt.Status.NotAfter == nil {
+		return nil
+	}
+
+	var next time.Time
+	switch {
+	case crt.Status.RenewalTime != nil:
+		next = crt.Status.RenewalTime.Time
+	case crt.Status.NotBefore != nil:
+		next = pki.RenewalTime(crt.Status.NotBefore.Time, crt.Status.NotAfter.Time, crt.Spec.RenewBefore).Time
+	default:
+		return nil
+	}
+
+	duration := cmapi.DefaultCertificateDuration
+	if crt.Spec.Duration != nil && crt.Spec.Duration.Duration > 0 {
+		duration = crt.Spec.Duration.Duration
+	}
+
+	// A renewal that is overdue happens as soon as possible.
+	if next.Before(now) {
+		next = now
+	}
+
+	var out []time.Time
+	for !next.After(end) {
+		out = append(out, next)
+		renewal := pki.RenewalTime(next, next.Add(duration), crt.Spec.RenewBefore).Time
+		if !renewal.After(next) {
+			// Guard against a renewBefore that would renew continuously.
+			break
+		}
+		next = renewal
+	}
+	return out
+}
+
+// bucket is the number of renewals in a period of the forecast.
+type bucket struct {
+	Start time.Time
+	Count int
+}
+
+// histogram counts times into consecutive buckets of the given size, from
+// start until end.
+func histogram(times []time.Time, start, end time.Time, size time.Duration) []bucket {
+	var buckets []bucket
+	for t := start; t.Before(end); t = t.Add(size) {
+		buckets = append(buckets, bucket{Start: t})
+	}
+	for _, t := range times {
+		if t.Before(start) || !t.Before(end) {
+			continue
+		}
+		buckets[int(t.Sub(start)/size)].Count++
+	}
+	return buckets
+}
+
+// startOfDay returns midnight of the day of t, in the location of t.
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewals
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestRenewals(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	ptr := func(t time.Time) *metav1.Time { mt := metav1.NewTime(t); return &mt }
+
+	tests := map[string]struct {
+		status  cmapi.CertificateStatus
+		spec    cmapi.CertificateSpec
+		horizon time.Duration
+		exp     []time.Time
+	}{
+		"not issued": {
+			horizon: 90 * day,
+		},
+		"renewal time from status and default duration": {
+			status:  cmapi.CertificateStatus{NotAfter: ptr(now.Add(40 * day)), RenewalTime: ptr(now.Add(10 * day))},
+			horizon: 90 * day,
+			// 90 day certificates are renewed after 60 days.
+			exp: []time.Time{now.Add(10 * day), now.Add(70 * day)},
+		},
+		"renewal time computed from validity and renewBefore": {
+			status:  cmapi.CertificateStatus{NotBefore: ptr(now.Add(-10 * day)), NotAfter: ptr(now.Add(20 * day))},
+			spec:    cmapi.CertificateSpec{Duration: &metav1.Duration{Duration: 30 * day}, RenewBefore: &metav1.Duration{Duration: 5 * day}},
+			horizon: 60 * day,
+			exp:     []time.Time{now.Add(15 * day), now.Add(40 * day)},
+		},
+		"overdue renewal happens now": {
+			status:  cmapi.CertificateStatus{NotAfter: ptr(now.Add(day)), RenewalTime: ptr(now.Add(-day))},
+			horizon: 30 * day,
+			exp:     []time.Time{now},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := &cmapi.Certificate{Spec: test.spec, Status: test.status}
+			got := renewals(crt, now, now.Add(test.horizon))
+			if !reflect.DeepEqual(got, test.exp) {
+				t.Errorf("unexpected renewals, exp=%v got=%v", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	got := histogram([]time.Time{
+		start.Add(time.Hour),
+		start.Add(2 * time.Hour),
+		start.Add(2*day + time.Hour),
+		start.Add(10 * day),
+	}, start, start.Add(3*day), day)
+
+	exp := []bucket{{Start: start, Count: 2}, {Start: start.Add(day)}, {Start: start.Add(2 * day), Count: 1}}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("unexpected histogram, exp=%v got=%v", exp, got)
+	}
+}
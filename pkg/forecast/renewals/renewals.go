@@ -0,0 +1,215 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewals
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Forecast when Certificates will be renewed, and show the number of renewals per day or week.
+
+The first renewal of a Certificate is taken from its status. Following renewals are computed
+from the duration and renewBefore in the Certificate spec, using the same rules as cert-manager.
+Overdue renewals are counted at the start of the forecast. This helps to spot spikes of
+renewals, e.g. after many Certificates were issued at the same time.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Show the renewals per week for the next 90 days in the current namespace
+{{.BuildName}} forecast renewals
+
+# Show the renewals per day for the next 30 days in all namespaces
+{{.BuildName}} forecast renewals --horizon 30d --bucket day --all-namespaces
+
+# Show the renewals of the Certificates issued by the ClusterIssuer 'letsencrypt'
+{{.BuildName}} forecast renewals --all-namespaces --field-selector spec.issuerRef.kind=ClusterIssuer,spec.issuerRef.name=letsencrypt`)))
+)
+
+const maxBarWidth = 50
+
+// Options is a struct to support forecast renewals command
+type Options struct {
+	// Horizon is how far into the future renewals are forecast, e.g. 90d.
+	Horizon string
+	// Bucket is the size of the histogram buckets, either day or week.
+	Bucket        string
+	LabelSelector string
+	FieldSelector string
+	AllNamespaces bool
+
+	horizon       time.Duration
+	fieldSelector *cmcmdutil.CertificateFieldSelector
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		Horizon:   "90d",
+		Bucket:    "week",
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdForecastRenewals returns a cobra command for forecast renewals
+func NewCmdForecastRenewals(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "renewals",
+		Short:   "Forecast when Certificates will be renewed",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate(args)))
+			cmcmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.Horizon, "horizon", o.Horizon, "How far into the future to forecast, e.g. 90d or 720h")
+	cmd.Flags().StringVar(&o.Bucket, "bucket", o.Bucket, "Group renewals per 'day' or per 'week'")
+	cmd.Flags().StringVarP(&o.LabelSelector, "selector", "l", o.LabelSelector, "Selector (label query) to filter on, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
+	cmd.Flags().StringVar(&o.FieldSelector, "field-selector", o.FieldSelector, cmcmdutil.FieldSelectorUsage)
+	factory.AddAllNamespacesFlag(cmd, &o.AllNamespaces, "forecast renewals")
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("forecast renewals does not accept arguments")
+	}
+
+	var err error
+	o.horizon, err = cmcmdutil.ParseDays(o.Horizon)
+	if err != nil {
+		return fmt.Errorf("invalid --horizon: %w", err)
+	}
+	if o.horizon <= 0 {
+		return errors.New("--horizon must be greater than zero")
+	}
+
+	switch o.Bucket {
+	case "day", "week":
+	default:
+		return errors.New(`--bucket must be 'day' or 'week'`)
+	}
+
+	o.fieldSelector, err = cmcmdutil.ParseCertificateFieldSelector(o.FieldSelector)
+	return err
+}
+
+// Run executes forecast renewals command
+func (o *Options) Run(ctx context.Context) error {
+	ns := o.NamespaceOrAll(o.AllNamespaces)
+
+	now := time.Now()
+	start := startOfDay(now)
+	end := now.Add(o.horizon)
+
+	// Only the renewal times are kept, so every page of Certificates can be
+	// dropped as soon as it has been processed.
+	var (
+		times     []time.Time
+		total     int
+		notIssued int
+	)
+	opts := metav1.ListOptions{
+		LabelSelector: o.LabelSelector,
+		FieldSelector: o.fieldSelector.ServerSide(),
+	}
+	cmcmdutil.LogListFilters(ctx, "Certificates", ns, opts, o.fieldSelector.ClientSide())
+	err := cmcmdutil.ListPages(ctx, opts, func(opts metav1.ListOptions) (string, error) {
+		list, err := o.CMClient.CertmanagerV1().Certificates(ns).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		crts := o.fieldSelector.Filter(list.Items)
+		total += len(crts)
+		for i := range crts {
+			crt := &crts[i]
+			if crt.Status.NotAfter == nil {
+				notIssued++
+				continue
+			}
+			times = append(times, renewals(crt, now, end)...)
+		}
+		return list.Continue, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error when listing Certificate resources: %w", err)
+	}
+
+	size := 24 * time.Hour
+	if o.Bucket == "week" {
+		size *= 7
+	}
+	buckets := histogram(times, start, end, size)
+
+	fmt.Fprintf(o.Out, "Forecast of %d renewal(s) for %d Certificate(s) until %s\n", len(times), total-notIssued, end.Format(time.RFC3339))
+	if notIssued > 0 {
+		fmt.Fprintf(o.Out, "%d Certificate(s) have not been issued yet and are not included\n", notIssued)
+	}
+	fmt.Fprintln(o.Out)
+
+	peak := bucket{}
+	for _, b := range buckets {
+		if b.Count > peak.Count {
+			peak = b
+		}
+	}
+
+	w := util.NewTabWriter(o.Out)
+	fmt.Fprintf(w, "%s\tRENEWALS\t\n", strings.ToUpper(o.Bucket))
+	for _, b := range buckets {
+		bar := 0
+		if peak.Count > 0 {
+			bar = (b.Count*maxBarWidth + peak.Count - 1) / peak.Count
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\n", b.Start.Format(time.DateOnly), b.Count, strings.Repeat("#", bar))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if peak.Count > 0 {
+		fmt.Fprintf(o.Out, "\nBusiest %s: %s with %d renewal(s)\n", o.Bucket, peak.Start.Format(time.DateOnly), peak.Count)
+	}
+
+	return nil
+}
@@ -0,0 +1,302 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Delete completed and failed CertificateRequests, Orders and Challenges that are older
+than the given age.
+
+The most recent CertificateRequests of every Certificate are always kept, up to the
+Certificate's revisionHistoryLimit (or only the latest one if no limit is set).
+CertificateRequests, Orders and Challenges that are still in progress are never deleted.
+Confirmation is required before anything is deleted unless --yes is given.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Show which resources older than 30 days would be deleted in the current namespace
+{{.BuildName}} gc --dry-run
+
+# Check that the API server would admit deleting the stale resources, without deleting them
+{{.BuildName}} gc --dry-run=server
+
+# Delete failed resources older than 7 days in all namespaces
+{{.BuildName}} gc --older-than 168h --failed-only --all-namespaces
+
+# Delete stale resources without asking for confirmation, e.g. from a CronJob
+{{.BuildName}} gc --yes`)))
+)
+
+// Options is a struct to support gc command
+type Options struct {
+	// OlderThan is the minimum age of a resource before it is considered for
+	// deletion.
+	OlderThan time.Duration
+	// FailedOnly restricts deletion to failed or denied resources.
+	FailedOnly bool
+	// DryRun only prints what would be deleted.
+	DryRun        cmcmdutil.DryRun
+	AllNamespaces bool
+	// Yes skips the confirmation prompt before deleting.
+	Yes bool
+	// Concurrency is the maximum number of resources that are deleted at the
+	// same time.
+	Concurrency int
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdGC returns a cobra command for garbage collecting stale requests and orders
+func NewCmdGC(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "gc",
+		Short:   "Delete stale CertificateRequests, Orders and Challenges",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate(args)))
+			cmcmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+
+	cmd.Flags().DurationVar(&o.OlderThan, "older-than", 720*time.Hour, "Only delete resources that were created longer ago than this, must include unit, e.g. 168h")
+	cmd.Flags().BoolVar(&o.FailedOnly, "failed-only", o.FailedOnly, "If true, only delete failed or denied resources.")
+	cmcmdutil.AddDryRunFlag(cmd.Flags(), &o.DryRun)
+	factory.AddAllNamespacesFlag(cmd, &o.AllNamespaces, "delete stale resources")
+	cmcmdutil.AddConfirmFlags(cmd.Flags(), &o.Yes)
+	cmcmdutil.AddConcurrencyFlag(cmd.Flags(), &o.Concurrency)
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("gc does not accept arguments")
+	}
+	if o.OlderThan < 0 {
+		return errors.New("--older-than must not be negative")
+	}
+	return cmcmdutil.ValidateConcurrency(o.Concurrency)
+}
+
+// Run executes gc command
+func (o *Options) Run(ctx context.Context) error {
+	log := logf.FromContext(ctx, "gc")
+
+	ns := o.NamespaceOrAll(o.AllNamespaces)
+
+	var (
+		crts       []cmapi.Certificate
+		reqs       []cmapi.CertificateRequest
+		orders     []cmacme.Order
+		challenges []cmacme.Challenge
+	)
+	err := cmcmdutil.ListPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+		list, err := o.CMClient.CertmanagerV1().Certificates(ns).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		crts = append(crts, list.Items...)
+		return list.Continue, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error when listing Certificate resources: %w", err)
+	}
+	err = cmcmdutil.ListPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+		list, err := o.CMClient.CertmanagerV1().CertificateRequests(ns).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		reqs = append(reqs, list.Items...)
+		return list.Continue, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error when listing CertificateRequest resources: %w", err)
+	}
+	err = cmcmdutil.ListPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+		list, err := o.CMClient.AcmeV1().Orders(ns).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		orders = append(orders, list.Items...)
+		return list.Continue, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error when listing Order resources: %w", err)
+	}
+	err = cmcmdutil.ListPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+		list, err := o.CMClient.AcmeV1().Challenges(ns).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		challenges = append(challenges, list.Items...)
+		return list.Continue, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error when listing Challenge resources: %w", err)
+	}
+
+	sel := selector{
+		cutoff:     time.Now().Add(-o.OlderThan),
+		failedOnly: o.FailedOnly,
+	}
+	candidates := sel.selectCertificateRequests(crts, reqs)
+	candidates = append(candidates, sel.selectOrders(orders, reqs, candidates)...)
+	candidates = append(candidates, sel.selectChallenges(challenges, orders, candidates)...)
+
+	if !o.DryRun.Enabled() && len(candidates) > 0 {
+		prompt := fmt.Sprintf("%d stale resource(s) will be deleted.", len(candidates))
+		if err := cmcmdutil.Confirm(o.In, o.ErrOut, o.Yes, prompt); err != nil {
+			return err
+		}
+	}
+
+	var progress *cmcmdutil.Progress
+	if !o.DryRun.Enabled() && len(candidates) > 1 {
+		progress = cmcmdutil.NewProgress(o.ErrOut, "Deleting stale resources", len(candidates))
+		defer progress.Finish()
+	}
+
+	var mu sync.Mutex
+	summaries := map[string]*summary{}
+	bulk := cmcmdutil.Bulk{Concurrency: o.Concurrency, Out: o.Out, Progress: progress}
+	deleteErr := cmcmdutil.ForEach(ctx, bulk, candidates, candidate.String, func(ctx context.Context, out io.Writer, c candidate) error {
+		if !o.DryRun.SkipRequest() {
+			log.V(2).Info("Deleting", "kind", c.Kind, "namespace", c.Namespace, "name", c.Name, "dryRun", o.DryRun.Enabled())
+			// Orders and Challenges may already have been removed by the
+			// garbage collector when their owner was deleted.
+			if err := o.delete(ctx, c); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete %s: %w", c, err)
+			}
+		}
+		if o.DryRun.Enabled() {
+			fmt.Fprintf(out, "Would delete %s%s\n", c, o.DryRun.Suffix())
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		s, ok := summaries[c.Namespace]
+		if !ok {
+			s = &summary{}
+			summaries[c.Namespace] = s
+		}
+		s.add(c.Kind)
+		return nil
+	})
+	progress.Finish()
+
+	if len(summaries) == 0 {
+		if deleteErr == nil {
+			fmt.Fprintln(o.ErrOut, "No stale resources found")
+		}
+		return deleteErr
+	}
+
+	namespaces := make([]string, 0, len(summaries))
+	for ns := range summaries {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	if o.DryRun.Enabled() {
+		fmt.Fprintln(o.Out)
+	}
+	w := util.NewTabWriter(o.Out)
+	fmt.Fprint(w, "NAMESPACE\tCERTIFICATEREQUESTS\tORDERS\tCHALLENGES\n")
+	for _, ns := range namespaces {
+		s := summaries[ns]
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\n", ns, s.certificateRequests, s.orders, s.challenges)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	// The resources that were deleted are summarized before the resources
+	// that could not be deleted are reported.
+	return deleteErr
+}
+
+func (o *Options) delete(ctx context.Context, c candidate) error {
+	propagation := metav1.DeletePropagationBackground
+	opts := metav1.DeleteOptions{PropagationPolicy: &propagation, DryRun: o.DryRun.Options()}
+
+	switch c.Kind {
+	case kindCertificateRequest:
+		return o.CMClient.CertmanagerV1().CertificateRequests(c.Namespace).Delete(ctx, c.Name, opts)
+	case kindOrder:
+		return o.CMClient.AcmeV1().Orders(c.Namespace).Delete(ctx, c.Name, opts)
+	case kindChallenge:
+		return o.CMClient.AcmeV1().Challenges(c.Namespace).Delete(ctx, c.Name, opts)
+	default:
+		return fmt.Errorf("unknown kind %q", c.Kind)
+	}
+}
+
+// summary counts the deleted resources of a single namespace.
+type summary struct {
+	certificateRequests int
+	orders              int
+	challenges          int
+}
+
+func (s *summary) add(kind string) {
+	switch kind {
+	case kindCertificateRequest:
+		s.certificateRequests++
+	case kindOrder:
+		s.orders++
+	case kindChallenge:
+		s.challenges++
+	}
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/cert-manager/cert-manager/pkg/acme"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+const (
+	kindCertificateRequest = "CertificateRequest"
+	kindOrder              = "Order"
+	kindChallenge          = "Challenge"
+)
+
+// candidate is a resource that has been selected for deletion.
+type candidate struct {
+	Kind      string
+	Namespace string
+	Name      string
+	UID       types.UID
+}
+
+// String returns the kind, namespace and name of c, e.g.
+// "Order my-namespace/my-order".
+func (c candidate) String() string {
+	return c.Kind + " " + c.Namespace + "/" + c.Name
+}
+
+// selector decides which resources are stale.
+type selector struct {
+	// cutoff is the time before which a resource must have been created to
+	// be considered for deletion.
+	cutoff time.Time
+	// failedOnly restricts the selection to failed resources.
+	failedOnly bool
+}
+
+// selectCertificateRequests returns the CertificateRequests that are finished,
+// older than the cutoff and not part of the revision history that should be
+// retained for their owning Certificate.
+func (s selector) selectCertificateRequests(crts []cmapi.Certificate, reqs []cmapi.CertificateRequest) []candidate {
+	limits := map[types.UID]int{}
+	for _, crt := range crts {
+		limit := 1
+		if crt.Spec.RevisionHistoryLimit != nil && *crt.Spec.RevisionHistoryLimit > 1 {
+			limit = int(*crt.Spec.RevisionHistoryLimit)
+		}
+		limits[crt.UID] = limit
+	}
+
+	// Group the requests by owning Certificate so that the most recent
+	// revisions can be retained.
+	owned := map[types.UID][]*cmapi.CertificateRequest{}
+	for i := range reqs {
+		req := &reqs[i]
+		owner := certificateOwner(req.OwnerReferences)
+		owned[owner] = append(owned[owner], req)
+	}
+
+	var out []candidate
+	for owner, group := range owned {
+		sort.SliceStable(group, func(i, j int) bool {
+			return revision(group[i]) > revision(group[j])
+		})
+
+		keep := 0
+		if owner != "" {
+			keep = limits[owner]
+			if keep == 0 {
+				// The owning Certificate is outside of the listed scope or
+				// already gone, be conservative and keep the latest request.
+				keep = 1
+			}
+		}
+
+		for i, req := range group {
+			if i < keep {
+				continue
+			}
+			if !s.old(req.ObjectMeta) {
+				continue
+			}
+
+			failed := certificateRequestFailed(req)
+			if !failed && (s.failedOnly || !certificateRequestReady(req)) {
+				continue
+			}
+
+			out = append(out, candidate{Kind: kindCertificateRequest, Namespace: req.Namespace, Name: req.Name, UID: req.UID})
+		}
+	}
+
+	sortCandidates(out)
+	return out
+}
+
+// selectOrders returns the Orders in a final state that are older than the
+// cutoff and whose CertificateRequest is not retained.
+func (s selector) selectOrders(orders []cmacme.Order, reqs []cmapi.CertificateRequest, deleted []candidate) []candidate {
+	retained := retainedUIDs(kindCertificateRequest, reqs, deleted)
+
+	var out []candidate
+	for _, order := range orders {
+		if !s.old(order.ObjectMeta) || !s.finished(order.Status.State) {
+			continue
+		}
+		if ownedByAny(order.OwnerReferences, retained) {
+			continue
+		}
+		out = append(out, candidate{Kind: kindOrder, Namespace: order.Namespace, Name: order.Name, UID: order.UID})
+	}
+
+	sortCandidates(out)
+	return out
+}
+
+// selectChallenges returns the Challenges in a final state that are older than
+// the cutoff and whose Order is not retained.
+func (s selector) selectChallenges(challenges []cmacme.Challenge, orders []cmacme.Order, deleted []candidate) []candidate {
+	retained := map[types.UID]bool{}
+	deletedUIDs := candidateUIDs(kindOrder, deleted)
+	for _, order := range orders {
+		if !deletedUIDs[order.UID] {
+			retained[order.UID] = true
+		}
+	}
+
+	var out []candidate
+	for _, ch := range challenges {
+		if !s.old(ch.ObjectMeta) || !s.finished(ch.Status.State) {
+			continue
+		}
+		if ownedByAny(ch.OwnerReferences, retained) {
+			continue
+		}
+		out = append(out, candidate{Kind: kindChallenge, Namespace: ch.Namespace, Name: ch.Name, UID: ch.UID})
+	}
+
+	sortCandidates(out)
+	return out
+}
+
+func (s selector) old(meta metav1.ObjectMeta) bool {
+	return meta.CreationTimestamp.Time.Before(s.cutoff)
+}
+
+func (s selector) finished(state cmacme.State) bool {
+	if s.failedOnly {
+		return acme.IsFailureState(state)
+	}
+	return acme.IsFinalState(state)
+}
+
+func certificateRequestReady(req *cmapi.CertificateRequest) bool {
+	return apiutil.CertificateRequestHasCondition(req, cmapi.CertificateRequestCondition{
+		Type:   cmapi.CertificateRequestConditionReady,
+		Status: cmmeta.ConditionTrue,
+	})
+}
+
+func certificateRequestFailed(req *cmapi.CertificateRequest) bool {
+	if apiutil.CertificateRequestIsDenied(req) {
+		return true
+	}
+	if apiutil.CertificateRequestHasInvalidRequest(req) {
+		return true
+	}
+	cond := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionReady)
+	return cond != nil && cond.Status == cmmeta.ConditionFalse && cond.Reason == cmapi.CertificateRequestReasonFailed
+}
+
+// revision returns the revision annotated on req, or 0 if it is missing.
+func revision(req *cmapi.CertificateRequest) int {
+	rev, err := strconv.Atoi(req.Annotations[cmapi.CertificateRequestRevisionAnnotationKey])
+	if err != nil {
+		return 0
+	}
+	return rev
+}
+
+func certificateOwner(refs []metav1.OwnerReference) types.UID {
+	for _, ref := range refs {
+		if ref.Kind == cmapi.CertificateKind {
+			return ref.UID
+		}
+	}
+	return ""
+}
+
+func ownedByAny(refs []metav1.OwnerReference, uids map[types.UID]bool) bool {
+	for _, ref := range refs {
+		if uids[ref.UID] {
+			return true
+		}
+	}
+	return false
+}
+
+func retainedUIDs(kind string, reqs []cmapi.CertificateRequest, deleted []candidate) map[types.UID]bool {
+	deletedUIDs := candidateUIDs(kind, deleted)
+	retained := map[types.UID]bool{}
+	for _, req := range reqs {
+		if !deletedUIDs[req.UID] {
+			retained[req.UID] = true
+		}
+	}
+	return retained
+}
+
+func candidateUIDs(kind string, candidates []candidate) map[types.UID]bool {
+	uids := map[types.UID]bool{}
+	for _, c := range candidates {
+		if c.Kind == kind {
+			uids[c.UID] = true
+		}
+	}
+	return uids
+}
+
+func sortCandidates(c []candidate) {
+	sort.Slice(c, func(i, j int) bool {
+		if c[i].Namespace != c[j].Namespace {
+			return c[i].Namespace < c[j].Namespace
+		}
+		return c[i].Name < c[j].Name
+	})
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+var (
+	now    = time.Now()
+	old    = metav1.NewTime(now.Add(-48 * time.Hour))
+	recent = metav1.NewTime(now.Add(-time.Minute))
+)
+
+func certificate(uid string, limit *int32) cmapi.Certificate {
+	return cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: uid, UID: types.UID(uid)},
+		Spec:       cmapi.CertificateSpec{RevisionHistoryLimit: limit},
+	}
+}
+
+func request(name, owner string, rev int, created metav1.Time, reason string) cmapi.CertificateRequest {
+	status := cmmeta.ConditionTrue
+	if reason != cmapi.CertificateRequestReasonIssued {
+		status = cmmeta.ConditionFalse
+	}
+	req := cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "ns",
+			Name:              name,
+			UID:               types.UID(name),
+			CreationTimestamp: created,
+			Annotations:       map[string]string{cmapi.CertificateRequestRevisionAnnotationKey: strconv.Itoa(rev)},
+		},
+		Status: cmapi.CertificateRequestStatus{
+			Conditions: []cmapi.CertificateRequestCondition{{
+				Type:   cmapi.CertificateRequestConditionReady,
+				Status: status,
+				Reason: reason,
+			}},
+		},
+	}
+	if owner != "" {
+		req.OwnerReferences = []metav1.OwnerReference{{Kind: cmapi.CertificateKind, UID: types.UID(owner)}}
+	}
+	return req
+}
+
+func names(candidates []candidate) []string {
+	var out []string
+	for _, c := range candidates {
+		out = append(out, c.Kind+"/"+c.Name)
+	}
+	return out
+}
+
+func TestSelectCertificateRequests(t *testing.T) {
+	limit3 := int32(3)
+
+	tests := map[string]struct {
+		crts       []cmapi.Certificate
+		reqs       []cmapi.CertificateRequest
+		failedOnly bool
+		exp        []string
+	}{
+		"the latest revision is kept when no revision history limit is set": {
+			crts: []cmapi.Certificate{certificate("crt", nil)},
+			reqs: []cmapi.CertificateRequest{
+				request("crt-1", "crt", 1, old, cmapi.CertificateRequestReasonIssued),
+				request("crt-2", "crt", 2, old, cmapi.CertificateRequestReasonIssued),
+			},
+			exp: []string{"CertificateRequest/crt-1"},
+		},
+		"revisions within the history limit are kept": {
+			crts: []cmapi.Certificate{certificate("crt", &limit3)},
+			reqs: []cmapi.CertificateRequest{
+				request("crt-1", "crt", 1, old, cmapi.CertificateRequestReasonIssued),
+				request("crt-2", "crt", 2, old, cmapi.CertificateRequestReasonIssued),
+				request("crt-3", "crt", 3, old, cmapi.CertificateRequestReasonIssued),
+				request("crt-4", "crt", 4, old, cmapi.CertificateRequestReasonIssued),
+			},
+			exp: []string{"CertificateRequest/crt-1"},
+		},
+		"recent and pending requests are never deleted": {
+			crts: []cmapi.Certificate{certificate("crt", nil)},
+			reqs: []cmapi.CertificateRequest{
+				request("crt-1", "crt", 1, recent, cmapi.CertificateRequestReasonIssued),
+				request("crt-2", "crt", 2, old, cmapi.CertificateRequestReasonPending),
+				request("crt-3", "crt", 3, old, cmapi.CertificateRequestReasonIssued),
+			},
+		},
+		"only failed requests are deleted with failed-only": {
+			crts: []cmapi.Certificate{certificate("crt", nil)},
+			reqs: []cmapi.CertificateRequest{
+				request("crt-1", "crt", 1, old, cmapi.CertificateRequestReasonIssued),
+				request("crt-2", "crt", 2, old, cmapi.CertificateRequestReasonFailed),
+				request("crt-3", "crt", 3, old, cmapi.CertificateRequestReasonIssued),
+			},
+			failedOnly: true,
+			exp:        []string{"CertificateRequest/crt-2"},
+		},
+		"requests without an owning Certificate are all considered": {
+			reqs: []cmapi.CertificateRequest{
+				request("manual", "", 0, old, cmapi.CertificateRequestReasonIssued),
+			},
+			exp: []string{"CertificateRequest/manual"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := selector{cutoff: now.Add(-24 * time.Hour), failedOnly: test.failedOnly}
+			got := names(s.selectCertificateRequests(test.crts, test.reqs))
+			if !reflect.DeepEqual(got, test.exp) {
+				t.Errorf("unexpected selection, exp=%v got=%v", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestSelectOrders(t *testing.T) {
+	retained := request("retained", "crt", 2, old, cmapi.CertificateRequestReasonIssued)
+	deleted := request("deleted", "crt", 1, old, cmapi.CertificateRequestReasonIssued)
+
+	order := func(name string, owner types.UID, state cmacme.State) cmacme.Order {
+		return cmacme.Order{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "ns",
+				Name:              name,
+				CreationTimestamp: old,
+				OwnerReferences:   []metav1.OwnerReference{{Kind: cmapi.CertificateRequestKind, UID: owner}},
+			},
+			Status: cmacme.OrderStatus{State: state},
+		}
+	}
+
+	s := selector{cutoff: now.Add(-24 * time.Hour)}
+	got := names(s.selectOrders(
+		[]cmacme.Order{
+			order("of-retained", retained.UID, cmacme.Valid),
+			order("of-deleted", deleted.UID, cmacme.Valid),
+			order("pending", deleted.UID, cmacme.Pending),
+		},
+		[]cmapi.CertificateRequest{retained, deleted},
+		[]candidate{{Kind: kindCertificateRequest, Namespace: "ns", Name: "deleted", UID: deleted.UID}},
+	))
+
+	exp := []string{"Order/of-deleted"}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("unexpected selection, exp=%v got=%v", exp, got)
+	}
+}
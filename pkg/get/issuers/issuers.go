@@ -0,0 +1,229 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cmctl/v2/internal/printers"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+List the Issuers in the current namespace and all ClusterIssuers, with their type and readiness.
+
+With --summary, the ACME account status and the number of Certificates referencing each
+issuer are shown as well. Certificates are only counted in the namespaces that are listed,
+so use --all-namespaces to get the full count for ClusterIssuers.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# List the Issuers in the current namespace and all ClusterIssuers
+{{.BuildName}} get issuers
+
+# Show an overview of all issuers in the cluster as JSON
+{{.BuildName}} get issuers --summary --all-namespaces -o json
+
+# Print the names of all ClusterIssuers
+{{.BuildName}} get issuers -o jsonpath='{range [?(@.kind=="ClusterIssuer")]}{.name}{"\n"}{end}'
+
+# List the issuers that are not ready, sorted by the number of Certificates referencing them
+{{.BuildName}} get issuers --summary --all-namespaces --filter '{.ready}!=True' --sort-by '{.certificates}'`)))
+)
+
+// Options is a struct to support get issuers command
+type Options struct {
+	// Summary adds the ACME account status and the Certificate count.
+	Summary       bool
+	AllNamespaces bool
+	// Output is the target output format. This may be of value "", "wide",
+	// or one of the formats supported by printers.NewPrinter.
+	Output string
+
+	printer *printers.Printer
+
+	printers.TableOptions
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdGetIssuers returns a cobra command for get issuers
+func NewCmdGetIssuers(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "issuers",
+		Aliases: cmcmdutil.KindAliases(cmapi.IssuerKind, "issuers"),
+		Short:   "List Issuers and ClusterIssuers",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate(args)))
+			cmcmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.Summary, "summary", o.Summary, "If true, include the ACME account status and the number of Certificates referencing each issuer.")
+	factory.AddAllNamespacesFlag(cmd, &o.AllNamespaces, "list Issuers")
+	cmd.Flags().StringVarP(&o.Output, "output", "o", o.Output, "One of 'wide', "+printers.PrinterFormats+". 'wide' adds the time the Ready condition last changed.")
+	cmcmdutil.AllowOutputFromEnv(cmd.Flags(), "json", "yaml")
+	o.TableOptions.AddFlags(cmd.Flags())
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("get issuers does not accept arguments")
+	}
+
+	switch o.Output {
+	case "":
+	case "wide":
+		o.Wide = true
+	default:
+		var err error
+		if o.printer, err = printers.NewPrinter(o.Output); err != nil {
+			return err
+		}
+	}
+
+	if o.Output != "" && o.Output != "wide" && (o.NoHeaders || o.Quiet || o.SortBy != "" || o.Filter != "") {
+		return errors.New("--no-headers, --quiet, --sort-by and --filter can only be used with table output")
+	}
+
+	return o.TableOptions.Validate()
+}
+
+// Run executes get issuers command
+func (o *Options) Run(ctx context.Context) error {
+	ns := o.NamespaceOrAll(o.AllNamespaces)
+
+	var summaries []IssuerSummary
+	err := cmcmdutil.ListPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+		list, err := o.CMClient.CertmanagerV1().ClusterIssuers().List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		for i := range list.Items {
+			summaries = append(summaries, summarize(cmapi.ClusterIssuerKind, &list.Items[i]))
+		}
+		return list.Continue, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error when listing ClusterIssuer resources: %w", err)
+	}
+	err = cmcmdutil.ListPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+		list, err := o.CMClient.CertmanagerV1().Issuers(ns).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		for i := range list.Items {
+			summaries = append(summaries, summarize(cmapi.IssuerKind, &list.Items[i]))
+		}
+		return list.Continue, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error when listing Issuer resources: %w", err)
+	}
+	sortSummaries(summaries)
+
+	if o.Summary {
+		err := cmcmdutil.ListPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+			list, err := o.CMClient.CertmanagerV1().Certificates(ns).List(ctx, opts)
+			if err != nil {
+				return "", err
+			}
+			countCertificates(summaries, list.Items)
+			return list.Continue, nil
+		})
+		if err != nil {
+			return fmt.Errorf("error when listing Certificate resources: %w", err)
+		}
+	}
+
+	if o.printer != nil {
+		return o.printer.Print(o.Out, summaries)
+	}
+
+	if len(summaries) == 0 {
+		fmt.Fprintln(o.ErrOut, "No Issuers or ClusterIssuers found")
+		return nil
+	}
+	return o.printTable(summaries)
+}
+
+func (o *Options) printTable(summaries []IssuerSummary) error {
+	headers := []string{"NAMESPACE", "NAME", "KIND", "TYPE", "READY"}
+	if o.Summary {
+		headers = append(headers, "ACME ACCOUNT", "CERTIFICATES")
+	}
+	table := o.NewTable(headers...).SetNameColumns(0, 1).WideColumns("LAST TRANSITION")
+
+	for _, s := range summaries {
+		namespace := s.Namespace
+		if namespace == "" {
+			namespace = "-"
+		}
+		ready := s.Ready
+		if s.Reason != "" {
+			ready += " (" + s.Reason + ")"
+		}
+
+		transition := "-"
+		if s.LastTransitionTime != nil {
+			transition = s.LastTransitionTime.Time.UTC().Format(time.RFC3339)
+		}
+
+		if !o.Summary {
+			table.AddObjectRow(s, namespace, s.Name, s.Kind, s.Type, ready, transition)
+			continue
+		}
+
+		account := s.ACMEAccount
+		if account == "" {
+			account = "-"
+		}
+		table.AddObjectRow(s, namespace, s.Name, s.Kind, s.Type, ready, account, strconv.Itoa(*s.Certificates), transition)
+	}
+
+	return table.Print(o.Out)
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuers
+
+import (
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// IssuerSummary is the overview of a single Issuer or ClusterIssuer.
+type IssuerSummary struct {
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Kind      string `json:"kind"`
+	Type      string `json:"type"`
+	Ready     string `json:"ready"`
+	Reason    string `json:"reason,omitempty"`
+	// LastTransitionTime is when the Ready condition last changed.
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+	// ACMEAccount is the registration status of the ACME account, only set
+	// for ACME issuers.
+	ACMEAccount string `json:"acmeAccount,omitempty"`
+	// Certificates is the number of Certificates referencing the issuer.
+	// Only set with --summary.
+	Certificates *int `json:"certificates,omitempty"`
+}
+
+func summarize(kind string, iss cmapi.GenericIssuer) IssuerSummary {
+	s := IssuerSummary{
+		Namespace: iss.GetNamespace(),
+		Name:      iss.GetName(),
+		Kind:      kind,
+		Type:      issuerType(iss.GetSpec()),
+		Ready:     "Unknown",
+	}
+
+	for _, cond := range iss.GetStatus().Conditions {
+		if cond.Type == cmapi.IssuerConditionReady {
+			s.Ready = string(cond.Status)
+			s.Reason = cond.Reason
+			s.LastTransitionTime = cond.LastTransitionTime
+		}
+	}
+
+	if iss.GetSpec().ACME != nil {
+		s.ACMEAccount = "Unregistered"
+		if acme := iss.GetStatus().ACME; acme != nil && acme.URI != "" {
+			s.ACMEAccount = "Registered"
+			if acme.LastRegisteredEmail != "" {
+				s.ACMEAccount += " (" + acme.LastRegisteredEmail + ")"
+			}
+		}
+	}
+
+	return s
+}
+
+func issuerType(spec *cmapi.IssuerSpec) string {
+	switch {
+	case spec.ACME != nil:
+		return "ACME"
+	case spec.CA != nil:
+		return "CA"
+	case spec.Vault != nil:
+		return "Vault"
+	case spec.SelfSigned != nil:
+		return "SelfSigned"
+	case spec.Venafi != nil:
+		return "Venafi"
+	default:
+		return "Unknown"
+	}
+}
+
+// countCertificates adds the number of Certificates in crts that reference
+// each of the summarized issuers to their count, so it can be called once for
+// every page of Certificates.
+func countCertificates(summaries []IssuerSummary, crts []cmapi.Certificate) {
+	type issuerKey struct{ namespace, name, kind string }
+
+	counts := map[issuerKey]int{}
+	for _, crt := range crts {
+		ref := crt.Spec.IssuerRef
+		if ref.Group != "" && ref.Group != "cert-manager.io" {
+			continue
+		}
+		key := issuerKey{namespace: crt.Namespace, name: ref.Name, kind: cmapi.IssuerKind}
+		switch ref.Kind {
+		case "", cmapi.IssuerKind:
+		case cmapi.ClusterIssuerKind:
+			key = issuerKey{name: ref.Name, kind: cmapi.ClusterIssuerKind}
+		default:
+			continue
+		}
+		counts[key]++
+	}
+
+	for i := range summaries {
+		count := counts[issuerKey{namespace: summaries[i].Namespace, name: summaries[i].Name, kind: summaries[i].Kind}]
+		if summaries[i].Certificates != nil {
+			count += *summaries[i].Certificates
+		}
+		summaries[i].Certificates = &count
+	}
+}
+
+func sortSummaries(summaries []IssuerSummary) {
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Kind != summaries[j].Kind {
+			// ClusterIssuers are listed first.
+			return summaries[i].Kind == cmapi.ClusterIssuerKind
+		}
+		if summaries[i].Namespace != summaries[j].Namespace {
+			return summaries[i].Namespace < summaries[j].Namespace
+		}
+		return summaries[i].Name < summaries[j].Name
+	})
+}
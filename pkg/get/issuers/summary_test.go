@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuers
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+func TestSummarize(t *testing.T) {
+	acme := &cmapi.ClusterIssuer{
+		ObjectMeta: metav1.ObjectMeta{Name: "letsencrypt"},
+		Spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{
+			ACME: &cmacme.ACMEIssuer{},
+		}},
+		Status: cmapi.IssuerStatus{
+			Conditions: []cmapi.IssuerCondition{{Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionTrue, Reason: "ACMEAccountRegistered"}},
+			ACME:       &cmacme.ACMEIssuerStatus{URI: "https://acme.example.com/acct/1", LastRegisteredEmail: "admin@example.com"},
+		},
+	}
+	ca := &cmapi.Issuer{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ca"},
+		Spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{
+			CA: &cmapi.CAIssuer{SecretName: "ca"},
+		}},
+	}
+
+	tests := map[string]struct {
+		kind string
+		iss  cmapi.GenericIssuer
+		exp  IssuerSummary
+	}{
+		"registered ACME ClusterIssuer": {
+			kind: cmapi.ClusterIssuerKind,
+			iss:  acme,
+			exp: IssuerSummary{
+				Name:        "letsencrypt",
+				Kind:        cmapi.ClusterIssuerKind,
+				Type:        "ACME",
+				Ready:       "True",
+				Reason:      "ACMEAccountRegistered",
+				ACMEAccount: "Registered (admin@example.com)",
+			},
+		},
+		"CA Issuer without conditions": {
+			kind: cmapi.IssuerKind,
+			iss:  ca,
+			exp: IssuerSummary{
+				Namespace: "ns",
+				Name:      "ca",
+				Kind:      cmapi.IssuerKind,
+				Type:      "CA",
+				Ready:     "Unknown",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := summarize(test.kind, test.iss); !reflect.DeepEqual(got, test.exp) {
+				t.Errorf("unexpected summary,\nexp=%+v\ngot=%+v", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestCountCertificates(t *testing.T) {
+	crt := func(ns, name, kind, group string) cmapi.Certificate {
+		return cmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns},
+			Spec: cmapi.CertificateSpec{
+				IssuerRef: cmmeta.ObjectReference{Name: name, Kind: kind, Group: group},
+			},
+		}
+	}
+
+	summaries := []IssuerSummary{
+		{Name: "letsencrypt", Kind: cmapi.ClusterIssuerKind},
+		{Namespace: "a", Name: "ca", Kind: cmapi.IssuerKind},
+		{Namespace: "b", Name: "ca", Kind: cmapi.IssuerKind},
+	}
+	countCertificates(summaries, []cmapi.Certificate{
+		crt("a", "letsencrypt", cmapi.ClusterIssuerKind, ""),
+		crt("b", "letsencrypt", cmapi.ClusterIssuerKind, "cert-manager.io"),
+		crt("a", "ca", "", ""),
+		crt("a", "ca", cmapi.IssuerKind, ""),
+		crt("b", "ca", "", "awspca.cert-manager.io"),
+	})
+	// Counts of later pages are added to the previous counts.
+	countCertificates(summaries, []cmapi.Certificate{
+		crt("b", "ca", cmapi.IssuerKind, ""),
+	})
+
+	var got []int
+	for _, s := range summaries {
+		got = append(got, *s.Certificates)
+	}
+	if exp := []int{2, 2, 1}; !reflect.DeepEqual(got, exp) {
+		t.Errorf("unexpected counts, exp=%v got=%v", exp, got)
+	}
+}
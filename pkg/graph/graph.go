@@ -0,0 +1,243 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	gwclient "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Print the dependency graph of cert-manager resources in DOT or Mermaid format.
+
+The graph links Issuers and ClusterIssuers to the Certificates they sign, Certificates to
+their Secrets, and Secrets to the Ingresses, Gateways and Pods (via volume mounts) that
+consume them. The Secrets of CA issuers are linked to those issuers. Pods are grouped by
+their controlling owner, e.g. their ReplicaSet.
+
+Use --from to only show what is affected by a change to a single resource, e.g. the blast
+radius of rotating a CA.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Render the graph of all namespaces as an SVG image using Graphviz
+{{.BuildName}} graph --all-namespaces | dot -Tsvg > graph.svg
+
+# Show everything affected by rotating the CA of the ClusterIssuer 'my-ca' as a Mermaid flowchart
+{{.BuildName}} graph --all-namespaces --from clusterissuer/my-ca --format mermaid
+
+# Show everything that depends on the Secret 'root-ca' in namespace 'cert-manager'
+{{.BuildName}} graph --all-namespaces --from secret/cert-manager/root-ca`)))
+)
+
+// Options is a struct to support graph command
+type Options struct {
+	// Format is the output format, dot or mermaid.
+	Format string
+	// From restricts the graph to the resources reachable from a resource in
+	// the format kind/name or kind/namespace/name.
+	From string
+	// ClusterResourceNamespace is the namespace of the Secrets referenced by
+	// ClusterIssuers.
+	ClusterResourceNamespace string
+	AllNamespaces            bool
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		Format:                   "dot",
+		ClusterResourceNamespace: "cert-manager",
+		IOStreams:                ioStreams,
+	}
+}
+
+// NewCmdGraph returns a cobra command for printing the dependency graph of cert-manager resources
+func NewCmdGraph(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "graph",
+		Short:   "Print the dependency graph of issuers, Certificates, Secrets and their consumers",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate(args)))
+			cmcmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.Format, "format", o.Format, "Output format, one of 'dot' or 'mermaid'.")
+	cmd.Flags().StringVar(&o.From, "from", o.From, "Only show resources affected by the given resource, as kind/name or kind/namespace/name.")
+	cmd.Flags().StringVar(&o.ClusterResourceNamespace, "cluster-resource-namespace", o.ClusterResourceNamespace, "Namespace of the Secrets referenced by ClusterIssuers.")
+	factory.AddAllNamespacesFlag(cmd, &o.AllNamespaces, "include resources")
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("graph does not accept arguments")
+	}
+	switch o.Format {
+	case "dot", "mermaid":
+	default:
+		return errors.New(`--format must be 'dot' or 'mermaid'`)
+	}
+	if o.From != "" {
+		if n := len(strings.Split(o.From, "/")); n < 2 || n > 3 {
+			return fmt.Errorf("--from must be kind/name or kind/namespace/name, got %q", o.From)
+		}
+	}
+	return nil
+}
+
+// Run executes graph command
+func (o *Options) Run(ctx context.Context) error {
+	ns := o.NamespaceOrAll(o.AllNamespaces)
+
+	in := inputs{ClusterResourceNamespace: o.ClusterResourceNamespace}
+
+	err := cmcmdutil.ListPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+		list, err := o.CMClient.CertmanagerV1().Issuers(ns).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		in.Issuers = append(in.Issuers, list.Items...)
+		return list.Continue, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error when listing Issuer resources: %w", err)
+	}
+
+	err = cmcmdutil.ListPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+		list, err := o.CMClient.CertmanagerV1().ClusterIssuers().List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		in.ClusterIssuers = append(in.ClusterIssuers, list.Items...)
+		return list.Continue, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error when listing ClusterIssuer resources: %w", err)
+	}
+
+	err = cmcmdutil.ListPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+		list, err := o.CMClient.CertmanagerV1().Certificates(ns).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		in.Certificates = append(in.Certificates, list.Items...)
+		return list.Continue, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error when listing Certificate resources: %w", err)
+	}
+
+	err = cmcmdutil.ListPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+		list, err := o.KubeClient.NetworkingV1().Ingresses(ns).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		in.Ingresses = append(in.Ingresses, list.Items...)
+		return list.Continue, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error when listing Ingress resources: %w", err)
+	}
+
+	gwcl, err := gwclient.NewForConfig(o.RESTConfig)
+	if err != nil {
+		return err
+	}
+	err = cmcmdutil.ListPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+		list, err := gwcl.GatewayV1().Gateways(ns).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		in.Gateways = append(in.Gateways, list.Items...)
+		return list.Continue, nil
+	})
+	switch {
+	case apierrors.IsNotFound(err):
+		// The Gateway API CRDs are not installed.
+	case err != nil:
+		return fmt.Errorf("error when listing Gateway resources: %w", err)
+	}
+
+	err = cmcmdutil.ListPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+		list, err := o.KubeClient.CoreV1().Pods(ns).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		in.Pods = append(in.Pods, list.Items...)
+		return list.Continue, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error when listing Pod resources: %w", err)
+	}
+
+	g := buildGraph(in)
+
+	if o.From != "" {
+		root, err := o.findRoot(g)
+		if err != nil {
+			return err
+		}
+		g = g.reachable(root)
+	}
+
+	if o.Format == "mermaid" {
+		renderMermaid(o.Out, g)
+	} else {
+		renderDOT(o.Out, g)
+	}
+	return nil
+}
+
+func (o *Options) findRoot(g *graph) (string, error) {
+	parts := strings.Split(o.From, "/")
+	kind, ns, name := parts[0], o.Namespace, parts[len(parts)-1]
+	if len(parts) == 3 {
+		ns = parts[1]
+	}
+	if strings.EqualFold(kind, cmapi.ClusterIssuerKind) {
+		ns = ""
+	}
+	return g.find(kind, ns, name)
+}
@@ -0,0 +1,276 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+const kindSecret = "Secret"
+
+// node is a resource in the dependency graph.
+type node struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+func (n node) id() string {
+	if n.Namespace == "" {
+		return n.Kind + "/" + n.Name
+	}
+	return n.Kind + "/" + n.Namespace + "/" + n.Name
+}
+
+func (n node) label(newline string) string {
+	if n.Namespace == "" {
+		return n.Kind + newline + n.Name
+	}
+	return n.Kind + newline + n.Namespace + "/" + n.Name
+}
+
+// graph is a directed graph from issuers to the workloads that consume the
+// certificates they sign.
+type graph struct {
+	nodes map[string]node
+	edges map[string]map[string]bool
+}
+
+func newGraph() *graph {
+	return &graph{nodes: map[string]node{}, edges: map[string]map[string]bool{}}
+}
+
+func (g *graph) addEdge(from, to node) {
+	g.nodes[from.id()] = from
+	g.nodes[to.id()] = to
+	if g.edges[from.id()] == nil {
+		g.edges[from.id()] = map[string]bool{}
+	}
+	g.edges[from.id()][to.id()] = true
+}
+
+// inputs are the resources the graph is built from.
+type inputs struct {
+	// ClusterResourceNamespace is the namespace of the Secrets referenced
+	// by ClusterIssuers.
+	ClusterResourceNamespace string
+
+	Issuers        []cmapi.Issuer
+	ClusterIssuers []cmapi.ClusterIssuer
+	Certificates   []cmapi.Certificate
+	Ingresses      []networkingv1.Ingress
+	Gateways       []gwapi.Gateway
+	Pods           []corev1.Pod
+}
+
+// buildGraph links issuers to Certificates, Certificates to their Secrets and
+// Secrets to the Ingresses, Gateways and Pods that use them. Secrets of CA
+// issuers are linked to those issuers. Only Secrets that are managed by
+// cert-manager or used by an issuer are included.
+func buildGraph(in inputs) *graph {
+	g := newGraph()
+
+	for _, crt := range in.Certificates {
+		kind := crt.Spec.IssuerRef.Kind
+		if kind == "" {
+			kind = cmapi.IssuerKind
+		}
+		issuer := node{Kind: kind, Name: crt.Spec.IssuerRef.Name}
+		if kind != cmapi.ClusterIssuerKind {
+			issuer.Namespace = crt.Namespace
+		}
+		certificate := node{Kind: cmapi.CertificateKind, Namespace: crt.Namespace, Name: crt.Name}
+		g.addEdge(issuer, certificate)
+		g.addEdge(certificate, node{Kind: kindSecret, Namespace: crt.Namespace, Name: crt.Spec.SecretName})
+	}
+
+	for _, issuer := range in.Issuers {
+		n := node{Kind: cmapi.IssuerKind, Namespace: issuer.Namespace, Name: issuer.Name}
+		g.nodes[n.id()] = n
+		if issuer.Spec.CA != nil {
+			g.addEdge(node{Kind: kindSecret, Namespace: issuer.Namespace, Name: issuer.Spec.CA.SecretName}, n)
+		}
+	}
+
+	for _, issuer := range in.ClusterIssuers {
+		n := node{Kind: cmapi.ClusterIssuerKind, Name: issuer.Name}
+		g.nodes[n.id()] = n
+		if issuer.Spec.CA != nil {
+			g.addEdge(node{Kind: kindSecret, Namespace: in.ClusterResourceNamespace, Name: issuer.Spec.CA.SecretName}, n)
+		}
+	}
+
+	// Consumers are only linked to Secrets that are already part of the graph.
+	secret := func(ns, name string) (node, bool) {
+		n := node{Kind: kindSecret, Namespace: ns, Name: name}
+		_, ok := g.nodes[n.id()]
+		return n, ok
+	}
+
+	for _, ing := range in.Ingresses {
+		for _, tls := range ing.Spec.TLS {
+			if s, ok := secret(ing.Namespace, tls.SecretName); ok {
+				g.addEdge(s, node{Kind: "Ingress", Namespace: ing.Namespace, Name: ing.Name})
+			}
+		}
+	}
+
+	for _, gw := range in.Gateways {
+		for _, l := range gw.Spec.Listeners {
+			if l.TLS == nil {
+				continue
+			}
+			for _, ref := range l.TLS.CertificateRefs {
+				if (ref.Group != nil && *ref.Group != "") || (ref.Kind != nil && *ref.Kind != kindSecret) {
+					continue
+				}
+				ns := gw.Namespace
+				if ref.Namespace != nil {
+					ns = string(*ref.Namespace)
+				}
+				if s, ok := secret(ns, string(ref.Name)); ok {
+					g.addEdge(s, node{Kind: "Gateway", Namespace: gw.Namespace, Name: gw.Name})
+				}
+			}
+		}
+	}
+
+	for _, pod := range in.Pods {
+		for _, name := range podSecrets(pod) {
+			if s, ok := secret(pod.Namespace, name); ok {
+				g.addEdge(s, workload(pod))
+			}
+		}
+	}
+
+	return g
+}
+
+// podSecrets returns the names of the Secrets mounted as volumes by pod.
+func podSecrets(pod corev1.Pod) []string {
+	var names []string
+	for _, v := range pod.Spec.Volumes {
+		if v.Secret != nil {
+			names = append(names, v.Secret.SecretName)
+		}
+		if v.Projected != nil {
+			for _, source := range v.Projected.Sources {
+				if source.Secret != nil {
+					names = append(names, source.Secret.Name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// workload returns the controller of pod, so that the replicas of a workload
+// are shown as a single node.
+func workload(pod corev1.Pod) node {
+	if owner := metav1.GetControllerOf(&pod); owner != nil {
+		return node{Kind: owner.Kind, Namespace: pod.Namespace, Name: owner.Name}
+	}
+	return node{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name}
+}
+
+// reachable returns the subgraph of the nodes that can be reached from root,
+// i.e. everything affected by a change to root.
+func (g *graph) reachable(root string) *graph {
+	sub := newGraph()
+	sub.nodes[root] = g.nodes[root]
+	queue := []string{root}
+	for len(queue) > 0 {
+		from := queue[0]
+		queue = queue[1:]
+		for to := range g.edges[from] {
+			if _, seen := sub.nodes[to]; !seen {
+				queue = append(queue, to)
+			}
+			sub.addEdge(g.nodes[from], g.nodes[to])
+		}
+	}
+	return sub
+}
+
+// find returns the id of the node with the given kind, namespace and name,
+// where kind is matched case-insensitively.
+func (g *graph) find(kind, namespace, name string) (string, error) {
+	for id, n := range g.nodes {
+		if strings.EqualFold(n.Kind, kind) && n.Namespace == namespace && n.Name == name {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("%s %q not found in the graph", kind, strings.TrimPrefix(namespace+"/"+name, "/"))
+}
+
+func (g *graph) sortedNodes() []string {
+	ids := make([]string, 0, len(g.nodes))
+	for id := range g.nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (g *graph) sortedEdges(from string) []string {
+	tos := make([]string, 0, len(g.edges[from]))
+	for to := range g.edges[from] {
+		tos = append(tos, to)
+	}
+	sort.Strings(tos)
+	return tos
+}
+
+func renderDOT(w io.Writer, g *graph) {
+	fmt.Fprintln(w, "digraph cert_manager {")
+	fmt.Fprintln(w, "  rankdir=LR;")
+	fmt.Fprintln(w, "  node [shape=box];")
+	for _, id := range g.sortedNodes() {
+		fmt.Fprintf(w, "  %q [label=%q];\n", id, g.nodes[id].label("\n"))
+	}
+	for _, from := range g.sortedNodes() {
+		for _, to := range g.sortedEdges(from) {
+			fmt.Fprintf(w, "  %q -> %q;\n", from, to)
+		}
+	}
+	fmt.Fprintln(w, "}")
+}
+
+func renderMermaid(w io.Writer, g *graph) {
+	ids := g.sortedNodes()
+	short := make(map[string]string, len(ids))
+	fmt.Fprintln(w, "flowchart LR")
+	for i, id := range ids {
+		short[id] = fmt.Sprintf("n%d", i)
+		fmt.Fprintf(w, "  %s[\"%s\"]\n", short[id], g.nodes[id].label("<br/>"))
+	}
+	for _, from := range ids {
+		for _, to := range g.sortedEdges(from) {
+			fmt.Fprintf(w, "  %s --> %s\n", short[from], short[to])
+		}
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"bytes"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+func testInputs() inputs {
+	controller := true
+	certificate := func(name, secret string, issuer cmmeta.ObjectReference) cmapi.Certificate {
+		return cmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: name},
+			Spec:       cmapi.CertificateSpec{SecretName: secret, IssuerRef: issuer},
+		}
+	}
+
+	return inputs{
+		ClusterResourceNamespace: "cert-manager",
+		ClusterIssuers: []cmapi.ClusterIssuer{{
+			ObjectMeta: metav1.ObjectMeta{Name: "root"},
+			Spec:       cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{SelfSigned: &cmapi.SelfSignedIssuer{}}},
+		}},
+		Issuers: []cmapi.Issuer{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "ca"},
+			Spec:       cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{CA: &cmapi.CAIssuer{SecretName: "ca-tls"}}},
+		}},
+		Certificates: []cmapi.Certificate{
+			certificate("ca", "ca-tls", cmmeta.ObjectReference{Name: "root", Kind: cmapi.ClusterIssuerKind}),
+			certificate("web", "web-tls", cmmeta.ObjectReference{Name: "ca"}),
+		},
+		Ingresses: []networkingv1.Ingress{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "web"},
+			Spec:       networkingv1.IngressSpec{TLS: []networkingv1.IngressTLS{{SecretName: "web-tls"}, {SecretName: "unmanaged"}}},
+		}},
+		Pods: []corev1.Pod{{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       "app",
+				Name:            "web-7d9f-abcde",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-7d9f", Controller: &controller}},
+			},
+			Spec: corev1.PodSpec{Volumes: []corev1.Volume{{
+				Name:         "tls",
+				VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "web-tls"}},
+			}}},
+		}},
+	}
+}
+
+func TestRenderDOT(t *testing.T) {
+	var buf bytes.Buffer
+	renderDOT(&buf, buildGraph(testInputs()))
+
+	exp := `digraph cert_manager {
+  rankdir=LR;
+  node [shape=box];
+  "Certificate/app/ca" [label="Certificate\napp/ca"];
+  "Certificate/app/web" [label="Certificate\napp/web"];
+  "ClusterIssuer/root" [label="ClusterIssuer\nroot"];
+  "Ingress/app/web" [label="Ingress\napp/web"];
+  "Issuer/app/ca" [label="Issuer\napp/ca"];
+  "ReplicaSet/app/web-7d9f" [label="ReplicaSet\napp/web-7d9f"];
+  "Secret/app/ca-tls" [label="Secret\napp/ca-tls"];
+  "Secret/app/web-tls" [label="Secret\napp/web-tls"];
+  "Certificate/app/ca" -> "Secret/app/ca-tls";
+  "Certificate/app/web" -> "Secret/app/web-tls";
+  "ClusterIssuer/root" -> "Certificate/app/ca";
+  "Issuer/app/ca" -> "Certificate/app/web";
+  "Secret/app/ca-tls" -> "Issuer/app/ca";
+  "Secret/app/web-tls" -> "Ingress/app/web";
+  "Secret/app/web-tls" -> "ReplicaSet/app/web-7d9f";
+}
+`
+	if buf.String() != exp {
+		t.Errorf("unexpected output:\n%s\nexpected:\n%s", buf.String(), exp)
+	}
+}
+
+func TestReachableMermaid(t *testing.T) {
+	g := buildGraph(testInputs())
+	root, err := g.find("issuer", "app", "ca")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	renderMermaid(&buf, g.reachable(root))
+
+	exp := `flowchart LR
+  n0["Certificate<br/>app/web"]
+  n1["Ingress<br/>app/web"]
+  n2["Issuer<br/>app/ca"]
+  n3["ReplicaSet<br/>app/web-7d9f"]
+  n4["Secret<br/>app/web-tls"]
+  n0 --> n4
+  n2 --> n0
+  n4 --> n1
+  n4 --> n3
+`
+	if buf.String() != exp {
+		t.Errorf("unexpected output:\n%s\nexpected:\n%s", buf.String(), exp)
+	}
+
+	if _, err := g.find("Issuer", "other", "ca"); err == nil {
+		t.Error("expected an error for a resource that is not in the graph")
+	}
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/cert-manager/cmctl/v2/pkg/importer/pem"
+	"github.com/cert-manager/cmctl/v2/pkg/importer/vault"
+)
+
+func NewCmdImport(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	cmds := &cobra.Command{
+		Use:   "import",
+		Short: "Generate cert-manager manifests for certificates from an existing PKI",
+		Long:  `Generate cert-manager Certificate and Issuer manifests matching certificates from an existing PKI inventory, e.g. a directory of PEM files or a Vault PKI secrets engine`,
+	}
+
+	cmds.AddCommand(pem.NewCmdImportPEM(ctx, ioStreams))
+	cmds.AddCommand(vault.NewCmdImportVault(ctx, ioStreams))
+
+	return cmds
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pem
+
+import (
+	"crypto"
+	"encoding/pem"
+	"errors"
+	"strings"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// extractKey returns the first PEM encoded private key block in data.
+func extractKey(data []byte) []byte {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil
+		}
+		if strings.HasSuffix(block.Type, "PRIVATE KEY") {
+			return pem.EncodeToMemory(block)
+		}
+	}
+}
+
+// checkKey verifies that keyPEM is the private key for pub.
+func checkKey(keyPEM []byte, pub crypto.PublicKey) error {
+	pk, err := pki.DecodePrivateKeyBytes(keyPEM)
+	if err != nil {
+		return err
+	}
+	equal, err := pki.PublicKeysEqual(pk.Public(), pub)
+	if err != nil {
+		return err
+	}
+	if !equal {
+		return errors.New("private key does not match the certificate")
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pem
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func TestExtractKey(t *testing.T) {
+	pk, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM, err := pki.EncodeECPrivateKey(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bundle := append([]byte("-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n"), keyPEM...)
+	if got := extractKey(bundle); !bytes.Equal(got, keyPEM) {
+		t.Errorf("expected the key to be extracted from the bundle, got %q", got)
+	}
+	if got := extractKey([]byte("-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n")); got != nil {
+		t.Errorf("expected no key, got %q", got)
+	}
+
+	if err := checkKey(keyPEM, pk.Public()); err != nil {
+		t.Errorf("expected the key to match, got %v", err)
+	}
+	if err := checkKey(keyPEM, other.Public()); err == nil {
+		t.Error("expected an error for a mismatching key")
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/importer/util"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Generate cert-manager Certificate manifests for the PEM encoded certificates in a directory.
+
+Every file with a .pem, .crt or .cert extension is read; the first certificate in the file
+is the leaf and the remaining certificates form its chain. A private key is taken from the
+same file, or from a file with the same base name and a .key extension.
+
+With --with-secrets, a kubernetes.io/tls Secret containing the existing certificate and
+private key is generated as well. Applying it before the Certificate lets cert-manager
+adopt the existing certificate instead of issuing a new one.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Generate Certificates referencing the ClusterIssuer 'my-ca' for the PEM files in ./certs
+{{.BuildName}} import pem ./certs --issuer my-ca --issuer-kind ClusterIssuer
+
+# Also generate the Secrets holding the existing key material and apply everything
+{{.BuildName}} import pem ./certs --issuer my-ca --with-secrets -n my-app | kubectl apply -f -`)))
+)
+
+var certExtensions = map[string]bool{".pem": true, ".crt": true, ".cert": true}
+
+// Options is a struct to support import pem command
+type Options struct {
+	*util.Options
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		Options: util.NewOptions(ioStreams),
+	}
+}
+
+// NewCmdImportPEM returns a cobra command for importing a directory of PEM files
+func NewCmdImportPEM(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "pem <directory>",
+		Short:   "Generate Certificate manifests for a directory of PEM files",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate(args)))
+			cmcmdutil.CheckErr(o.Complete())
+			cmcmdutil.CheckErr(o.Run(args))
+		},
+	}
+
+	o.AddFlags(cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) != 1 {
+		return errors.New("exactly one directory has to be provided as argument")
+	}
+	return o.Options.Validate()
+}
+
+// Run executes import pem command
+func (o *Options) Run(args []string) error {
+	imported, err := scan(args[0])
+	if err != nil {
+		return err
+	}
+	if len(imported) == 0 {
+		fmt.Fprintf(o.ErrOut, "No certificates found in %s\n", args[0])
+		return nil
+	}
+
+	for _, imp := range imported {
+		if o.WithSecrets && len(imp.KeyPEM) == 0 {
+			fmt.Fprintf(o.ErrOut, "No private key found for %q, not generating a Secret\n", imp.Name)
+		}
+	}
+
+	objs, err := o.Manifests(imported)
+	if err != nil {
+		return err
+	}
+	return o.Print(objs)
+}
+
+// scan reads all certificate files in dir, in lexical order.
+func scan(dir string) ([]util.Imported, error) {
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && certExtensions[strings.ToLower(filepath.Ext(path))] {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	used := map[string]bool{}
+	var imported []util.Imported
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		chain, err := pki.DecodeX509CertificateChainBytes(data)
+		if err != nil {
+			// Files that only contain a private key, or are not PEM at all,
+			// can share an extension with certificates.
+			continue
+		}
+
+		keyPEM, err := findKey(path, data)
+		if err != nil {
+			return nil, err
+		}
+		if keyPEM != nil {
+			if err := checkKey(keyPEM, chain[0].PublicKey); err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+		}
+
+		base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		imported = append(imported, util.Imported{
+			Name:   util.NameFor(base, used),
+			Chain:  chain,
+			KeyPEM: keyPEM,
+		})
+	}
+
+	return imported, nil
+}
+
+// findKey returns the PEM encoded private key in data, or in the .key file
+// next to path. It returns nil if there is no private key.
+func findKey(path string, data []byte) ([]byte, error) {
+	if key := extractKey(data); key != nil {
+		return key, nil
+	}
+
+	keyPath := strings.TrimSuffix(path, filepath.Ext(path)) + ".key"
+	data, err := os.ReadFile(keyPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return extractKey(data), nil
+}
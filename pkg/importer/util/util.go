@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	adoptutil "github.com/cert-manager/cmctl/v2/pkg/adopt/util"
+)
+
+// Imported is a certificate read from an external PKI inventory.
+type Imported struct {
+	// Name is used for both the Certificate and its Secret.
+	Name string
+	// Chain is the certificate chain, starting with the leaf certificate.
+	Chain []*x509.Certificate
+	// KeyPEM is the PEM encoded private key, if it is known.
+	KeyPEM []byte
+}
+
+// Options is a struct to support the import subcommands
+type Options struct {
+	// Namespace is the namespace of the generated resources.
+	Namespace string
+
+	IssuerName  string
+	IssuerKind  string
+	IssuerGroup string
+
+	// WithSecrets adds a Secret with the existing key material for every
+	// certificate whose private key is known, so that cert-manager does not
+	// re-issue it.
+	WithSecrets bool
+
+	PrintFlags *genericclioptions.PrintFlags
+	Printer    printers.ResourcePrinter
+
+	genericclioptions.IOStreams
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		Namespace:  metav1.NamespaceDefault,
+		IssuerKind: cmapi.IssuerKind,
+		IOStreams:  ioStreams,
+		PrintFlags: genericclioptions.NewPrintFlags("").WithDefaultOutput("yaml"),
+	}
+}
+
+// AddFlags registers the flags shared by all import subcommands
+func (o *Options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", o.Namespace, "Namespace of the generated resources")
+	cmd.Flags().StringVar(&o.IssuerName, "issuer", o.IssuerName, "Name of the issuer referenced by the generated Certificates.")
+	cmd.Flags().StringVar(&o.IssuerKind, "issuer-kind", o.IssuerKind, "Kind of the issuer referenced by the generated Certificates, e.g. Issuer (iss) or ClusterIssuer (ciss).")
+	cmd.Flags().StringVar(&o.IssuerGroup, "issuer-group", o.IssuerGroup, "API group of the issuer referenced by the generated Certificates.")
+	cmd.Flags().BoolVar(&o.WithSecrets, "with-secrets", o.WithSecrets, "If true, also generate Secrets containing the existing certificates and private keys, so that they are not re-issued.")
+	o.PrintFlags.AddFlags(cmd)
+}
+
+// Validate validates the shared options
+func (o *Options) Validate() error {
+	if o.IssuerName == "" {
+		return errors.New("the issuer of the generated Certificates has to be provided with --issuer")
+	}
+	if o.Namespace == "" {
+		return errors.New("--namespace must not be empty")
+	}
+	o.IssuerKind = cmcmdutil.NormalizeIssuerKind(o.IssuerKind, o.IssuerGroup)
+	return nil
+}
+
+// Complete builds the printer
+func (o *Options) Complete() error {
+	var err error
+	o.Printer, err = o.PrintFlags.ToPrinter()
+	return err
+}
+
+// IssuerRef returns the issuer referenced by the generated Certificates.
+func (o *Options) IssuerRef() cmmeta.ObjectReference {
+	return cmmeta.ObjectReference{Name: o.IssuerName, Kind: o.IssuerKind, Group: o.IssuerGroup}
+}
+
+// Manifests returns a Certificate, and with --with-secrets a Secret, for
+// every imported certificate.
+func (o *Options) Manifests(imported []Imported) ([]runtime.Object, error) {
+	issuerRef := o.IssuerRef()
+
+	var objs []runtime.Object
+	for _, imp := range imported {
+		crt := adoptutil.BuildCertificate(adoptutil.TLSEntry{Namespace: o.Namespace, SecretName: imp.Name}, issuerRef, imp.Chain[0])
+		objs = append(objs, crt)
+
+		if !o.WithSecrets || len(imp.KeyPEM) == 0 {
+			continue
+		}
+
+		chainPEM, err := pki.EncodeX509Chain(imp.Chain)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, &corev1.Secret{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      imp.Name,
+				Namespace: o.Namespace,
+				// The issuer annotations prevent cert-manager from re-issuing
+				// the certificate because of an issuer mismatch.
+				Annotations: map[string]string{
+					cmapi.IssuerNameAnnotationKey:  issuerRef.Name,
+					cmapi.IssuerKindAnnotationKey:  issuerRef.Kind,
+					cmapi.IssuerGroupAnnotationKey: issuerRef.Group,
+				},
+			},
+			Type: corev1.SecretTypeTLS,
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       chainPEM,
+				corev1.TLSPrivateKeyKey: imp.KeyPEM,
+			},
+		})
+	}
+	return objs, nil
+}
+
+// Print prints objs with the configured printer.
+func (o *Options) Print(objs []runtime.Object) error {
+	for _, obj := range objs {
+		if err := o.Printer.PrintObj(obj, o.Out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// NameFor returns a valid resource name derived from s, which is unique
+// amongst the names in used. The returned name is added to used.
+func NameFor(s string, used map[string]bool) string {
+	name := strings.ToLower(strings.ReplaceAll(s, "*", "wildcard"))
+	name = invalidNameChars.ReplaceAllString(name, "-")
+	name = strings.Trim(name, "-")
+	if len(name) > 240 {
+		name = strings.Trim(name[:240], "-")
+	}
+	if name == "" {
+		name = "imported"
+	}
+
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = fmt.Sprintf("%s-%d", name, i)
+	}
+	used[unique] = true
+	return unique
+}
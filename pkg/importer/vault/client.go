@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// client is a minimal client for the Vault PKI secrets engine HTTP API.
+type client struct {
+	addr      string
+	token     string
+	namespace string
+	mount     string
+
+	httpClient *http.Client
+}
+
+// vaultCert is a certificate stored by the PKI secrets engine.
+type vaultCert struct {
+	Serial         string
+	Certificate    string
+	RevocationTime int64
+}
+
+// nonCertificateKeys are returned by the certs LIST endpoint alongside the
+// serial numbers but do not refer to issued certificates.
+var nonCertificateKeys = map[string]bool{"ca": true, "ca_chain": true, "crl": true, "delta-crl": true}
+
+// listCertificates returns all certificates known to the PKI mount.
+func (c *client) listCertificates(ctx context.Context) ([]vaultCert, error) {
+	var list struct {
+		Data struct {
+			Keys []string `json:"keys"`
+		} `json:"data"`
+	}
+	if err := c.get(ctx, "certs?list=true", &list); err != nil {
+		return nil, err
+	}
+
+	var certs []vaultCert
+	for _, serial := range list.Data.Keys {
+		if nonCertificateKeys[serial] {
+			continue
+		}
+
+		var cert struct {
+			Data struct {
+				Certificate    string `json:"certificate"`
+				RevocationTime int64  `json:"revocation_time"`
+			} `json:"data"`
+		}
+		if err := c.get(ctx, "cert/"+url.PathEscape(serial), &cert); err != nil {
+			return nil, err
+		}
+		certs = append(certs, vaultCert{
+			Serial:         serial,
+			Certificate:    cert.Data.Certificate,
+			RevocationTime: cert.Data.RevocationTime,
+		})
+	}
+	return certs, nil
+}
+
+func (c *client) get(ctx context.Context, path string, out interface{}) error {
+	u := strings.TrimSuffix(c.addr, "/") + "/v1/" + strings.Trim(c.mount, "/") + "/" + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	if c.token != "" {
+		req.Header.Set("X-Vault-Token", c.token)
+	}
+	if c.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.namespace)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response from Vault for %s: %s", u, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response from Vault for %s: %w", u, err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestListCertificates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/pki/certs":
+			if r.URL.Query().Get("list") != "true" {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":{"keys":["ca","01:02","03:04"]}}`))
+		case "/v1/pki/cert/01:02":
+			_, _ = w.Write([]byte(`{"data":{"certificate":"one","revocation_time":0}}`))
+		case "/v1/pki/cert/03:04":
+			_, _ = w.Write([]byte(`{"data":{"certificate":"two","revocation_time":1700000000}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := &client{addr: server.URL, token: "token", mount: "/pki/", httpClient: server.Client()}
+	certs, err := c.listCertificates(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := []vaultCert{
+		{Serial: "01:02", Certificate: "one"},
+		{Serial: "03:04", Certificate: "two", RevocationTime: 1700000000},
+	}
+	if !reflect.DeepEqual(certs, exp) {
+		t.Errorf("unexpected certificates, exp=%v got=%v", exp, certs)
+	}
+
+	c.token = "wrong"
+	if _, err := c.listCertificates(context.Background()); err == nil {
+		t.Error("expected an error for a forbidden request")
+	}
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/importer/util"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Generate cert-manager Certificate manifests for the certificates issued by a Vault PKI
+secrets engine.
+
+Revoked, expired and CA certificates are skipped. When --role is given, a Vault Issuer
+that signs with that role is generated as well; its token Secret has to be created
+separately, or the auth section replaced by another authentication method.
+
+Vault does not return the private keys of issued certificates, so --with-secrets has no
+effect and cert-manager issues new certificates for the generated Certificates.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Generate Certificates and a Vault Issuer for the certificates in the 'pki' mount
+{{.BuildName}} import vault --vault-addr https://vault.example.com:8200 --issuer vault --role my-role
+`)))
+)
+
+// Options is a struct to support import vault command
+type Options struct {
+	*util.Options
+
+	Addr           string
+	Token          string
+	VaultNamespace string
+	Mount          string
+	Role           string
+	Timeout        time.Duration
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		Options: util.NewOptions(ioStreams),
+		Addr:    os.Getenv("VAULT_ADDR"),
+		Token:   os.Getenv("VAULT_TOKEN"),
+		Mount:   "pki",
+		Timeout: 30 * time.Second,
+	}
+}
+
+// NewCmdImportVault returns a cobra command for importing from Vault PKI
+func NewCmdImportVault(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "vault",
+		Short:   "Generate Certificate and Issuer manifests for a Vault PKI secrets engine",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate(args)))
+			cmcmdutil.CheckErr(o.Complete())
+			cmcmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+
+	o.AddFlags(cmd)
+	cmd.Flags().StringVar(&o.Addr, "vault-addr", o.Addr, "Address of the Vault server, defaults to $VAULT_ADDR.")
+	cmd.Flags().StringVar(&o.Token, "vault-token", o.Token, "Token used to read from Vault, defaults to $VAULT_TOKEN.")
+	cmd.Flags().StringVar(&o.VaultNamespace, "vault-namespace", o.VaultNamespace, "Vault namespace of the PKI secrets engine.")
+	cmd.Flags().StringVar(&o.Mount, "mount", o.Mount, "Path where the PKI secrets engine is mounted.")
+	cmd.Flags().StringVar(&o.Role, "role", o.Role, "If set, generate a Vault Issuer that signs with this PKI role.")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", o.Timeout, "Time to wait for Vault to list all certificates.")
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("import vault does not accept arguments")
+	}
+	if o.Addr == "" {
+		return errors.New("the address of the Vault server has to be provided with --vault-addr or $VAULT_ADDR")
+	}
+	if o.Mount == "" {
+		return errors.New("--mount must not be empty")
+	}
+	return o.Options.Validate()
+}
+
+// Run executes import vault command
+func (o *Options) Run(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+
+	c := &client{
+		addr:       o.Addr,
+		token:      o.Token,
+		namespace:  o.VaultNamespace,
+		mount:      o.Mount,
+		httpClient: http.DefaultClient,
+	}
+	certs, err := c.listCertificates(ctx)
+	if err != nil {
+		return err
+	}
+
+	imported := o.selectCertificates(certs, time.Now())
+	if o.WithSecrets {
+		fmt.Fprintln(o.ErrOut, "Vault does not return private keys, not generating Secrets")
+	}
+
+	var objs []runtime.Object
+	if o.Role != "" {
+		objs = append(objs, o.issuer())
+	}
+	crts, err := o.Manifests(imported)
+	if err != nil {
+		return err
+	}
+	objs = append(objs, crts...)
+
+	if len(imported) == 0 {
+		fmt.Fprintf(o.ErrOut, "No active certificates found in Vault mount %q\n", o.Mount)
+	}
+	return o.Print(objs)
+}
+
+// selectCertificates returns the active leaf certificates in certs, named
+// after their common name or first DNS name.
+func (o *Options) selectCertificates(certs []vaultCert, now time.Time) []util.Imported {
+	used := map[string]bool{}
+	var imported []util.Imported
+	for _, vc := range certs {
+		if vc.RevocationTime != 0 {
+			continue
+		}
+		chain, err := pki.DecodeX509CertificateChainBytes([]byte(vc.Certificate))
+		if err != nil {
+			fmt.Fprintf(o.ErrOut, "Skipping certificate %s: %v\n", vc.Serial, err)
+			continue
+		}
+		cert := chain[0]
+		if cert.IsCA || now.After(cert.NotAfter) {
+			continue
+		}
+
+		name := cert.Subject.CommonName
+		if name == "" && len(cert.DNSNames) > 0 {
+			name = cert.DNSNames[0]
+		}
+		imported = append(imported, util.Imported{
+			Name: util.NameFor(name, used),
+			// Vault does not return the private keys of issued certificates.
+			Chain: chain,
+		})
+	}
+	return imported
+}
+
+// issuer returns a Vault issuer that signs with the configured role.
+func (o *Options) issuer() runtime.Object {
+	spec := cmapi.IssuerSpec{
+		IssuerConfig: cmapi.IssuerConfig{
+			Vault: &cmapi.VaultIssuer{
+				Server:    o.Addr,
+				Namespace: o.VaultNamespace,
+				Path:      path.Join(o.Mount, "sign", o.Role),
+				Auth: cmapi.VaultAuth{
+					TokenSecretRef: &cmmeta.SecretKeySelector{
+						LocalObjectReference: cmmeta.LocalObjectReference{Name: o.IssuerName + "-token"},
+						Key:                  "token",
+					},
+				},
+			},
+		},
+	}
+
+	if o.IssuerKind == cmapi.ClusterIssuerKind {
+		return &cmapi.ClusterIssuer{
+			TypeMeta:   metav1.TypeMeta{APIVersion: cmapi.SchemeGroupVersion.String(), Kind: cmapi.ClusterIssuerKind},
+			ObjectMeta: metav1.ObjectMeta{Name: o.IssuerName},
+			Spec:       spec,
+		}
+	}
+	return &cmapi.Issuer{
+		TypeMeta:   metav1.TypeMeta{APIVersion: cmapi.SchemeGroupVersion.String(), Kind: cmapi.IssuerKind},
+		ObjectMeta: metav1.ObjectMeta{Name: o.IssuerName, Namespace: o.Namespace},
+		Spec:       spec,
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configmap
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// entry is a certificate in a CA bundle.
+type entry struct {
+	Cert *x509.Certificate
+	// Root is true if the certificate is self-signed.
+	Root bool
+	// DuplicateOf is the 1-based position of the first certificate in the
+	// bundle that is identical to this one, or 0 if there is none.
+	DuplicateOf int
+}
+
+// parseBundle decodes all PEM encoded certificates in data. Other PEM blocks
+// are ignored.
+func parseBundle(data []byte) ([]entry, error) {
+	var (
+		entries []entry
+		seen    = map[[sha256.Size]byte]int{}
+	)
+	for {
+		block, rest := pem.Decode(data)
+		if block == nil {
+			break
+		}
+		data = rest
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error when parsing certificate %d: %w", len(entries)+1, err)
+		}
+
+		e := entry{
+			Cert: cert,
+			Root: bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignatureFrom(cert) == nil,
+		}
+		fingerprint := sha256.Sum256(cert.Raw)
+		if first, ok := seen[fingerprint]; ok {
+			e.DuplicateOf = first
+		} else {
+			seen[fingerprint] = len(entries) + 1
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// expiryWarnings returns a warning for every certificate that has expired and
+// for every root certificate that expires within the given duration.
+// Duplicates are not warned about twice.
+func expiryWarnings(entries []entry, now time.Time, within time.Duration) []string {
+	var warnings []string
+	for i, e := range entries {
+		if e.DuplicateOf > 0 {
+			continue
+		}
+		subject := e.Cert.Subject.String()
+		left := e.Cert.NotAfter.Sub(now)
+		switch {
+		case left <= 0:
+			warnings = append(warnings, fmt.Sprintf("certificate %d %q expired %s ago", i+1, subject, duration.HumanDuration(-left)))
+		case e.Root && left <= within:
+			warnings = append(warnings, fmt.Sprintf("root certificate %d %q expires in %s", i+1, subject, duration.HumanDuration(left)))
+		}
+	}
+	return warnings
+}
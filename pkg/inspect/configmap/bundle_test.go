@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configmap
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var now = time.Now()
+
+func mustCreateCertificate(t *testing.T, name string, notAfter time.Time, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(now.UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestParseBundle(t *testing.T) {
+	root, rootKey, rootPEM := mustCreateCertificate(t, "root", now.Add(24*time.Hour), nil, nil)
+	_, _, intermediatePEM := mustCreateCertificate(t, "intermediate", now.Add(24*time.Hour), root, rootKey)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("ignored")})
+
+	var bundle []byte
+	for _, b := range [][]byte{rootPEM, keyPEM, intermediatePEM, rootPEM} {
+		bundle = append(bundle, b...)
+	}
+
+	entries, err := parseBundle(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type result struct {
+		Subject     string
+		Root        bool
+		DuplicateOf int
+	}
+	var got []result
+	for _, e := range entries {
+		got = append(got, result{e.Cert.Subject.CommonName, e.Root, e.DuplicateOf})
+	}
+	exp := []result{
+		{"root", true, 0},
+		{"intermediate", false, 0},
+		{"root", true, 1},
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("unexpected entries, exp=%v got=%v", exp, got)
+	}
+}
+
+func TestExpiryWarnings(t *testing.T) {
+	expiring, expiringKey, _ := mustCreateCertificate(t, "expiring", now.Add(10*24*time.Hour+12*time.Hour), nil, nil)
+	later, _, _ := mustCreateCertificate(t, "later", now.Add(100*24*time.Hour), nil, nil)
+	intermediate, _, _ := mustCreateCertificate(t, "intermediate", now.Add(10*24*time.Hour), expiring, expiringKey)
+	expired, _, _ := mustCreateCertificate(t, "expired", now.Add(-48*time.Hour), nil, nil)
+
+	entries := []entry{
+		{Cert: expiring, Root: true},
+		{Cert: later, Root: true},
+		{Cert: intermediate},
+		{Cert: expired, Root: true},
+		{Cert: expiring, Root: true, DuplicateOf: 1},
+	}
+
+	got := expiryWarnings(entries, now, 30*24*time.Hour)
+	exp := []string{
+		`root certificate 1 "CN=expiring" expires in 10d`,
+		`certificate 4 "CN=expired" expired 2d ago`,
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("unexpected warnings, exp=%q got=%q", exp, got)
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configmap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Get details about the CA certificates in a bundle that is stored in a ConfigMap, e.g. a
+trust-manager Bundle target or the root certificates distributed by istio.
+
+Every certificate in the bundle is listed with its subject and expiry, and duplicates are
+marked. A warning is printed for every certificate that has expired and for every root
+certificate that expires within the duration given by --warn-within.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Inspect the CA bundle in the 'ca.crt' key of the ConfigMap 'my-bundle' in namespace 'my-namespace'
+{{.BuildName}} inspect configmap my-bundle --namespace my-namespace
+
+# Inspect the root certificates distributed by istio, and warn about roots expiring within 90 days
+{{.BuildName}} inspect configmap istio-ca-root-cert --key root-cert.pem --warn-within 90d
+`)))
+)
+
+// Options is a struct to support inspect configmap command
+type Options struct {
+	// Key is the key in the ConfigMap that holds the PEM encoded bundle.
+	Key string
+	// WarnWithin is the duration, e.g. 30d, within which an expiring root
+	// certificate is warned about.
+	WarnWithin string
+	// TimeFormat is the format in which timestamps are printed
+	TimeFormat util.TimeFormat
+
+	warnWithin time.Duration
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		Key:        "ca.crt",
+		WarnWithin: "30d",
+		TimeFormat: util.TimeFormatRFC1123,
+		IOStreams:  ioStreams,
+	}
+}
+
+// NewCmdInspectConfigMap returns a cobra command for inspect configmap
+func NewCmdInspectConfigMap(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "configmap",
+		Aliases: []string{"cm"},
+		Short:   "Get details about the CA certificates in a ConfigMap bundle",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate(args)))
+			cmcmdutil.CheckErr(o.Run(ctx, args))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.Key, "key", o.Key, "The key in the ConfigMap that holds the PEM encoded CA bundle.")
+	cmd.Flags().StringVar(&o.WarnWithin, "warn-within", o.WarnWithin, "Warn about root certificates that expire within this duration, e.g. 30d or 72h.")
+	o.TimeFormat.AddFlag(cmd.Flags())
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) < 1 {
+		return errors.New("the name of the ConfigMap has to be provided as argument")
+	}
+	if len(args) > 1 {
+		return errors.New("only one argument can be passed in: the name of the ConfigMap")
+	}
+	if o.Key == "" {
+		return errors.New("--key must not be empty")
+	}
+
+	var err error
+	o.warnWithin, err = cmcmdutil.ParseDays(o.WarnWithin)
+	if err != nil {
+		return fmt.Errorf("invalid --warn-within: %w", err)
+	}
+	if o.warnWithin < 0 {
+		return errors.New("--warn-within must not be negative")
+	}
+	return nil
+}
+
+// Run executes inspect configmap command
+func (o *Options) Run(ctx context.Context, args []string) error {
+	cm, err := o.KubeClient.CoreV1().ConfigMaps(o.Namespace).Get(ctx, args[0], metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error when finding ConfigMap %q: %w", args[0], err)
+	}
+
+	var data []byte
+	if value, ok := cm.Data[o.Key]; ok {
+		data = []byte(value)
+	} else if value, ok := cm.BinaryData[o.Key]; ok {
+		data = value
+	} else {
+		keys := make([]string, 0, len(cm.Data)+len(cm.BinaryData))
+		for key := range cm.Data {
+			keys = append(keys, key)
+		}
+		for key := range cm.BinaryData {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return cmcmdutil.NotFoundError(fmt.Errorf("key %q not found in ConfigMap %q, available keys: %s", o.Key, cm.Name, strings.Join(keys, ", ")))
+	}
+
+	entries, err := parseBundle(data)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("no PEM encoded certificates found in key %q of ConfigMap %q", o.Key, cm.Name)
+	}
+
+	duplicates := 0
+	w := util.NewTabWriter(o.Out)
+	fmt.Fprint(w, "#\tSUBJECT\tCA\tROOT\tNOT AFTER\tDUPLICATE OF\n")
+	for i, e := range entries {
+		duplicateOf := ""
+		if e.DuplicateOf > 0 {
+			duplicates++
+			duplicateOf = strconv.Itoa(e.DuplicateOf)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%t\t%t\t%s\t%s\n", i+1, e.Cert.Subject, e.Cert.IsCA, e.Root, o.TimeFormat.Format(e.Cert.NotAfter), duplicateOf)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.Out, "\n%d certificate(s) in key %q of ConfigMap %s/%s, %d duplicate(s)\n", len(entries), o.Key, cm.Namespace, cm.Name, duplicates)
+
+	for _, warning := range expiryWarnings(entries, time.Now(), o.warnWithin) {
+		fmt.Fprintf(o.ErrOut, "Warning: %s\n", warning)
+	}
+
+	return nil
+}
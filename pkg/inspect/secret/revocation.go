@@ -0,0 +1,199 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+const (
+	// maxRevocationRequests is the maximum number of CRL and OCSP requests
+	// that are in flight at the same time.
+	maxRevocationRequests = 8
+
+	revocationRequestTimeout = 30 * time.Second
+)
+
+// revocation checks the revocation status of all inspected certificates. It
+// is shared by all Secrets that are inspected in a single run, so that
+// certificates issued by the same CA only fetch every CRL and OCSP response
+// once.
+var revocation = newRevocationChecker(&http.Client{Timeout: revocationRequestTimeout}, maxRevocationRequests)
+
+// revocationChecker fetches CRLs and OCSP responses. Requests share one HTTP
+// client so that connections are reused, at most a fixed number of requests
+// are in flight at the same time, and every CRL and OCSP response is only
+// fetched once: concurrent callers wait for the request that is in flight,
+// and later callers get the cached result.
+type revocationChecker struct {
+	client *http.Client
+	sem    chan struct{}
+
+	mu    sync.Mutex
+	calls map[string]*revocationCall
+}
+
+// revocationCall is a CRL or OCSP request whose result is cached. done is
+// closed once val and err are set.
+type revocationCall struct {
+	done chan struct{}
+	val  interface{}
+	err  error
+}
+
+func newRevocationChecker(client *http.Client, maxRequests int) *revocationChecker {
+	return &revocationChecker{
+		client: client,
+		sem:    make(chan struct{}, maxRequests),
+		calls:  map[string]*revocationCall{},
+	}
+}
+
+// do returns the result of fn for key, calling fn only if it has not been
+// called for key before.
+func (c *revocationChecker) do(key string, fn func() (interface{}, error)) (interface{}, error) {
+	c.mu.Lock()
+	call, ok := c.calls[key]
+	if !ok {
+		call = &revocationCall{done: make(chan struct{})}
+		c.calls[key] = call
+	}
+	c.mu.Unlock()
+
+	if ok {
+		<-call.done
+		return call.val, call.err
+	}
+
+	c.sem <- struct{}{}
+	call.val, call.err = fn()
+	<-c.sem
+	close(call.done)
+
+	return call.val, call.err
+}
+
+// crl returns the CRL that is published at url.
+func (c *revocationChecker) crl(url string) (*x509.RevocationList, error) {
+	crl, err := c.do("crl "+url, func() (interface{}, error) {
+		resp, err := c.client.Get(url)
+		if err != nil {
+			return nil, fmt.Errorf("error getting HTTP response: %w", err)
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("error reading HTTP body: %w", err)
+		}
+
+		crl, err := x509.ParseRevocationList(body)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing HTTP body: %w", err)
+		}
+		return crl, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return crl.(*x509.RevocationList), nil
+}
+
+// ocsp returns the response of ocspServer for the status of leafCert.
+func (c *revocationChecker) ocsp(ocspServer string, leafCert, issuerCert *x509.Certificate) (*ocsp.Response, error) {
+	key := fmt.Sprintf("ocsp %s %s %s", ocspServer, hex.EncodeToString(issuerCert.RawSubjectPublicKeyInfo), leafCert.SerialNumber)
+	resp, err := c.do(key, func() (interface{}, error) {
+		buffer, err := ocsp.CreateRequest(leafCert, issuerCert, &ocsp.RequestOptions{Hash: crypto.SHA1})
+		if err != nil {
+			return nil, fmt.Errorf("error creating OCSP request: %w", err)
+		}
+
+		httpRequest, err := http.NewRequest(http.MethodPost, ocspServer, bytes.NewBuffer(buffer))
+		if err != nil {
+			return nil, fmt.Errorf("error creating HTTP request: %w", err)
+		}
+		httpRequest.Header.Add("Content-Type", "application/ocsp-request")
+		httpRequest.Header.Add("Accept", "application/ocsp-response")
+		httpResponse, err := c.client.Do(httpRequest)
+		if err != nil {
+			return nil, fmt.Errorf("error making HTTP request: %w", err)
+		}
+		defer httpResponse.Body.Close()
+
+		output, err := io.ReadAll(httpResponse.Body)
+		if err != nil {
+			return nil, fmt.Errorf("error reading HTTP body: %w", err)
+		}
+		ocspResponse, err := ocsp.ParseResponse(output, issuerCert)
+		if err != nil {
+			return nil, fmt.Errorf("error reading OCSP response: %w", err)
+		}
+		return ocspResponse, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ocsp.Response), nil
+}
+
+func (c *revocationChecker) checkOCSPValidCert(leafCert, issuerCert *x509.Certificate) (bool, error) {
+	if len(leafCert.OCSPServer) < 1 {
+		return false, errors.New("No OCSP Server set")
+	}
+
+	for _, ocspServer := range leafCert.OCSPServer {
+		ocspResponse, err := c.ocsp(ocspServer, leafCert, issuerCert)
+		if err != nil {
+			return false, err
+		}
+
+		if ocspResponse.Status == ocsp.Revoked {
+			// one OCSP revoked it do not trust
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (c *revocationChecker) checkCRLValidCert(cert *x509.Certificate, url string) (bool, error) {
+	crl, err := c.crl(url)
+	if err != nil {
+		return false, err
+	}
+
+	// TODO: check CRL signature
+
+	for _, revoked := range crl.RevokedCertificateEntries {
+		if cert.SerialNumber.Cmp(revoked.SerialNumber) == 0 {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
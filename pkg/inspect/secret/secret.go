@@ -22,24 +22,27 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"io"
 	"net/url"
+	"os"
 	"strings"
 	"text/template"
-	"time"
 
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
-	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
 	k8sclock "k8s.io/utils/clock"
 
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
 	"github.com/cert-manager/cmctl/v2/pkg/build"
 	"github.com/cert-manager/cmctl/v2/pkg/factory"
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
 )
 
 var clock k8sclock.Clock = k8sclock.RealClock{}
@@ -81,6 +84,17 @@ const debuggingTemplate = `Debugging:
 	CRL Status:	{{ .CRLStatus }}
 	OCSP Status:	{{ .OCSPStatus }}`
 
+// The templates are parsed once, as the describe functions are called for
+// every inspected Secret.
+var (
+	validForTmpl       = template.Must(template.New("validForTemplate").Parse(validForTemplate))
+	validityPeriodTmpl = template.Must(template.New("validityPeriodTemplate").Parse(validityPeriodTemplate))
+	issuedByTmpl       = template.Must(template.New("issuedByTemplate").Parse(issuedByTemplate))
+	issuedForTmpl      = template.Must(template.New("issuedForTemplate").Parse(issuedForTemplate))
+	certificateTmpl    = template.Must(template.New("certificateTemplate").Parse(certificateTemplate))
+	debuggingTmpl      = template.Must(template.New("debuggingTemplate").Parse(debuggingTemplate))
+)
+
 var (
 	long = templates.LongDesc(i18n.T(`
 Get details about a kubernetes.io/tls typed secret`))
@@ -88,19 +102,59 @@ Get details about a kubernetes.io/tls typed secret`))
 	example = templates.Examples(i18n.T(build.WithTemplate(`
 # Query information about a secret with name 'my-crt' in namespace 'my-namespace'
 {{.BuildName}} inspect secret my-crt --namespace my-namespace
+
+# Print the validity period relative to now, e.g. '3h ago' or 'in 29d'
+{{.BuildName}} inspect secret my-crt --time-format relative
+
+# Check whether the certificate is trusted by the roots in a PEM file instead of by this computer
+{{.BuildName}} inspect secret my-crt --trust-store /etc/corp/roots.pem
+
+# Validate the certificate as an X509-SVID of the trust domain 'example.org'
+{{.BuildName}} inspect secret my-svid --spiffe --spiffe-trust-domain example.org --spiffe-bundle bundle.pem
+
+# Inspect the secrets whose names are read from stdin, as 'namespace/name' or 'name' per line
+{{.BuildName}} which-cert app.example.com -q | {{.BuildName}} inspect secret -
 `)))
 )
 
 // Options is a struct to support status certificate command
 type Options struct {
+	// TimeFormat is the format in which timestamps are printed
+	TimeFormat util.TimeFormat
+	// Concurrency is the maximum number of Secrets that are inspected at the
+	// same time when their names are read from stdin.
+	Concurrency int
+	// TrustStore is either TrustStoreSystem, to check whether the certificate
+	// is trusted by the platform verifier of this computer, or the path to a
+	// PEM file with the root certificates that are trusted instead.
+	TrustStore string
+	// SPIFFE validates the certificate as an X509-SVID.
+	SPIFFE bool
+	// SPIFFETrustDomain is the trust domain that the SPIFFE ID has to belong
+	// to, if set.
+	SPIFFETrustDomain string
+	// SPIFFEBundle is the path to a PEM file with the X.509 bundle of the
+	// trust domain that the SVID has to be signed by, if set.
+	SPIFFEBundle string
+
+	// roots and spiffeBundle are loaded from TrustStore and SPIFFEBundle.
+	roots        *x509.CertPool
+	spiffeBundle *x509.CertPool
+
 	genericclioptions.IOStreams
 	*factory.Factory
 }
 
+// TrustStoreSystem selects the trust store of this computer, as used by the
+// platform verifier.
+const TrustStoreSystem = "system"
+
 // NewOptions returns initialized Options
 func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
 	return &Options{
-		IOStreams: ioStreams,
+		TimeFormat: util.TimeFormatRFC1123,
+		TrustStore: TrustStoreSystem,
+		IOStreams:  ioStreams,
 	}
 }
 
@@ -115,11 +169,19 @@ func NewCmdInspectSecret(ctx context.Context, ioStreams genericclioptions.IOStre
 		Example:           example,
 		ValidArgsFunction: factory.ValidArgsListSecrets(ctx, &o.Factory),
 		Run: func(cmd *cobra.Command, args []string) {
-			cmdutil.CheckErr(o.Validate(args))
-			cmdutil.CheckErr(o.Run(ctx, args))
+			args, err := o.PickTLSSecret(ctx, o.IOStreams, args)
+			cmcmdutil.CheckErr(err)
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate(args)))
+			cmcmdutil.CheckErr(o.Run(ctx, args))
 		},
 	}
 
+	o.TimeFormat.AddFlag(cmd.Flags())
+	cmcmdutil.AddConcurrencyFlag(cmd.Flags(), &o.Concurrency)
+	cmd.Flags().StringVar(&o.TrustStore, "trust-store", o.TrustStore, fmt.Sprintf("The roots used to check whether the certificate is trusted: %q to use the trust store of this computer, verified with the %s, or the path to a PEM file with root certificates.", TrustStoreSystem, platformVerifier))
+	cmd.Flags().BoolVar(&o.SPIFFE, "spiffe", o.SPIFFE, "If true, validate the certificate as an X509-SVID, e.g. a workload identity issued by istio-csr or SPIRE.")
+	cmd.Flags().StringVar(&o.SPIFFETrustDomain, "spiffe-trust-domain", o.SPIFFETrustDomain, "The trust domain that the SPIFFE ID has to belong to, requires --spiffe.")
+	cmd.Flags().StringVar(&o.SPIFFEBundle, "spiffe-bundle", o.SPIFFEBundle, "The path to a PEM file with the X.509 bundle of the trust domain that has to sign the SVID, requires --spiffe.")
 	o.Factory = factory.New(ctx, cmd)
 
 	return cmd
@@ -133,23 +195,105 @@ func (o *Options) Validate(args []string) error {
 	if len(args) > 1 {
 		return errors.New("only one argument can be passed in: the name of the Secret")
 	}
-	return nil
+	if !o.SPIFFE && (o.SPIFFETrustDomain != "" || o.SPIFFEBundle != "") {
+		return errors.New("--spiffe-trust-domain and --spiffe-bundle can only be used with --spiffe")
+	}
+	return cmcmdutil.ValidateConcurrency(o.Concurrency)
 }
 
 // Run executes status certificate command
 func (o *Options) Run(ctx context.Context, args []string) error {
-	secret, err := o.KubeClient.CoreV1().Secrets(o.Namespace).Get(ctx, args[0], metav1.GetOptions{})
+	names := []types.NamespacedName{{Namespace: o.Namespace, Name: args[0]}}
+	if cmcmdutil.IsStdinArg(args) {
+		var err error
+		names, err = cmcmdutil.ReadNames(o.In, o.Namespace)
+		if err != nil {
+			return err
+		}
+	}
+
+	var err error
+	if o.TrustStore != TrustStoreSystem {
+		if o.roots, err = readRoots(o.TrustStore, "trust store"); err != nil {
+			return err
+		}
+	}
+	if o.SPIFFEBundle != "" {
+		if o.spiffeBundle, err = readRoots(o.SPIFFEBundle, "SPIFFE bundle"); err != nil {
+			return err
+		}
+	}
+
+	// The Secrets are inspected in parallel. Certificates issued by the same
+	// CA share the CRL and OCSP requests that check their revocation status.
+	reports := make([]string, len(names))
+	checkErrs := make([]error, len(names))
+	indexes := make([]int, len(names))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	bulk := cmcmdutil.Bulk{Concurrency: o.Concurrency, Out: io.Discard}
+	err = cmcmdutil.ForEach(ctx, bulk, indexes, func(i int) string { return names[i].String() }, func(ctx context.Context, _ io.Writer, i int) error {
+		report, checkErr, err := o.describeSecret(ctx, names[i])
+		if err != nil {
+			return err
+		}
+		if len(names) > 1 {
+			report = fmt.Sprintf("Secret: %s\n\n%s", names[i], report)
+		}
+		if checkErr != nil {
+			checkErr = fmt.Errorf("%s: %w", names[i], checkErr)
+		}
+		reports[i] = report
+		checkErrs[i] = checkErr
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("error when finding Secret %q: %w\n", args[0], err)
+		return err
+	}
+
+	pagerOut, closePager := cmcmdutil.StartPager(o.Out)
+	defer closePager()
+
+	fmt.Fprintln(pagerOut, strings.Join(reports, "\n\n---\n\n"))
+
+	// The reports are printed in full, but a revocation status that could not
+	// be checked still fails the command.
+	if err := errors.Join(checkErrs...); err != nil {
+		return cmcmdutil.NetworkError(fmt.Errorf("failed to check the revocation status: %w", err))
+	}
+	return nil
+}
+
+// readRoots returns the PEM encoded root certificates in the file at path.
+func readRoots(path, what string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error when reading %s: %w", what, err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no PEM encoded certificates found in %s %q", what, path)
+	}
+	return roots, nil
+}
+
+// describeSecret returns the description of the leaf certificate in the
+// Secret with the given name. checkErr reports the CRL and OCSP servers that
+// could not be reached, the description is still complete in that case.
+func (o *Options) describeSecret(ctx context.Context, name types.NamespacedName) (report string, checkErr error, err error) {
+	secret, err := o.KubeClient.CoreV1().Secrets(name.Namespace).Get(ctx, name.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", nil, fmt.Errorf("error when finding Secret %q: %w\n", name.Name, err)
 	}
 
 	certData := secret.Data[corev1.TLSCertKey]
 	certs, err := splitPEMs(certData)
 	if err != nil {
-		return err
+		return "", nil, err
 	}
 	if len(certs) < 1 {
-		return errors.New("no PEM data found in secret")
+		return "", nil, errors.New("no PEM data found in secret")
 	}
 
 	intermediates := [][]byte(nil)
@@ -160,26 +304,28 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 	// we only want to inspect the leaf certificate
 	x509Cert, err := pki.DecodeX509CertificateBytes(certs[0])
 	if err != nil {
-		return fmt.Errorf("error when parsing 'tls.crt': %w", err)
+		return "", nil, fmt.Errorf("error when parsing 'tls.crt': %w", err)
 	}
 
 	out := []string{
 		describeValidFor(x509Cert),
-		describeValidityPeriod(x509Cert),
+		describeValidityPeriod(x509Cert, o.TimeFormat),
 		describeIssuedBy(x509Cert),
 		describeIssuedFor(x509Cert),
 		describeCertificate(x509Cert),
-		describeDebugging(x509Cert, intermediates, secret.Data[cmmeta.TLSCAKey]),
+	}
+	debugging, checkErr := describeDebugging(x509Cert, intermediates, secret.Data[cmmeta.TLSCAKey], o.roots)
+	out = append(out, debugging)
+	if o.SPIFFE {
+		out = append(out, describeSPIFFE(x509Cert, intermediates, o.SPIFFETrustDomain, o.spiffeBundle))
 	}
 
-	fmt.Println(strings.Join(out, "\n\n"))
-
-	return nil
+	return strings.Join(out, "\n\n"), checkErr, nil
 }
 
 func describeValidFor(cert *x509.Certificate) string {
 	var b bytes.Buffer
-	template.Must(template.New("validForTemplate").Parse(validForTemplate)).Execute(&b, struct {
+	validForTmpl.Execute(&b, struct {
 		DNSNames       string
 		URIs           string
 		IPAddresses    string
@@ -196,14 +342,14 @@ func describeValidFor(cert *x509.Certificate) string {
 	return b.String()
 }
 
-func describeValidityPeriod(cert *x509.Certificate) string {
+func describeValidityPeriod(cert *x509.Certificate, format util.TimeFormat) string {
 	var b bytes.Buffer
-	template.Must(template.New("validityPeriodTemplate").Parse(validityPeriodTemplate)).Execute(&b, struct {
+	validityPeriodTmpl.Execute(&b, struct {
 		NotBefore string
 		NotAfter  string
 	}{
-		NotBefore: cert.NotBefore.Format(time.RFC1123),
-		NotAfter:  cert.NotAfter.Format(time.RFC1123),
+		NotBefore: format.Format(cert.NotBefore),
+		NotAfter:  format.Format(cert.NotAfter),
 	})
 
 	return b.String()
@@ -211,7 +357,7 @@ func describeValidityPeriod(cert *x509.Certificate) string {
 
 func describeIssuedBy(cert *x509.Certificate) string {
 	var b bytes.Buffer
-	template.Must(template.New("issuedByTemplate").Parse(issuedByTemplate)).Execute(&b, struct {
+	issuedByTmpl.Execute(&b, struct {
 		CommonName         string
 		Organization       string
 		OrganizationalUnit string
@@ -228,7 +374,7 @@ func describeIssuedBy(cert *x509.Certificate) string {
 
 func describeIssuedFor(cert *x509.Certificate) string {
 	var b bytes.Buffer
-	template.Must(template.New("issuedForTemplate").Parse(issuedForTemplate)).Execute(&b, struct {
+	issuedForTmpl.Execute(&b, struct {
 		CommonName         string
 		Organization       string
 		OrganizationalUnit string
@@ -245,7 +391,7 @@ func describeIssuedFor(cert *x509.Certificate) string {
 
 func describeCertificate(cert *x509.Certificate) string {
 	var b bytes.Buffer
-	template.Must(template.New("certificateTemplate").Parse(certificateTemplate)).Execute(&b, struct {
+	certificateTmpl.Execute(&b, struct {
 		SigningAlgorithm   string
 		PublicKeyAlgorithm string
 		SerialNumber       string
@@ -266,88 +412,104 @@ func describeCertificate(cert *x509.Certificate) string {
 	return b.String()
 }
 
-func describeDebugging(cert *x509.Certificate, intermediates [][]byte, ca []byte) string {
+func describeDebugging(cert *x509.Certificate, intermediates [][]byte, ca []byte, roots *x509.CertPool) (string, error) {
+	crlStatus, crlErr := describeCRL(cert)
+	ocspStatus, ocspErr := describeOCSP(cert, intermediates, ca)
+
 	var b bytes.Buffer
-	template.Must(template.New("debuggingTemplate").Parse(debuggingTemplate)).Execute(&b, struct {
+	debuggingTmpl.Execute(&b, struct {
 		TrustedByThisComputer string
 		CRLStatus             string
 		OCSPStatus            string
 	}{
-		TrustedByThisComputer: describeTrusted(cert, intermediates),
-		CRLStatus:             describeCRL(cert),
-		OCSPStatus:            describeOCSP(cert, intermediates, ca),
+		TrustedByThisComputer: describeTrusted(cert, intermediates, roots),
+		CRLStatus:             crlStatus,
+		OCSPStatus:            ocspStatus,
 	})
 
-	return b.String()
+	return b.String(), errors.Join(crlErr, ocspErr)
 }
 
-func describeCRL(cert *x509.Certificate) string {
+// describeCRL returns the CRL status of cert. The error is set when a CRL
+// could not be fetched.
+func describeCRL(cert *x509.Certificate) (string, error) {
 	if len(cert.CRLDistributionPoints) < 1 {
-		return "No CRL endpoints set"
+		return "No CRL endpoints set", nil
+	}
+	if cmcmdutil.Offline() {
+		return "Not checked, --offline is set", nil
 	}
 
 	hasChecked := false
 	for _, crlURL := range cert.CRLDistributionPoints {
 		u, err := url.Parse(crlURL)
 		if err != nil {
-			return fmt.Sprintf("Invalid CRL URL: %v", err)
+			return fmt.Sprintf("Invalid CRL URL: %v", err), nil
 		}
 		if u.Scheme != "ldap" && u.Scheme != "https" {
 			continue
 		}
 
 		hasChecked = true
-		valid, err := checkCRLValidCert(cert, crlURL)
+		valid, err := revocation.checkCRLValidCert(cert, crlURL)
 		if err != nil {
-			return fmt.Sprintf("Cannot check CRL: %s", err.Error())
+			return fmt.Sprintf("Cannot check CRL: %s", err.Error()), fmt.Errorf("cannot check CRL: %w", err)
 		}
 		if !valid {
-			return fmt.Sprintf("Revoked by %s", crlURL)
+			return fmt.Sprintf("Revoked by %s", crlURL), nil
 		}
 	}
 
 	if !hasChecked {
-		return "No CRL endpoints we support found"
+		return "No CRL endpoints we support found", nil
 	}
 
-	return "Valid"
+	return "Valid", nil
 }
 
-func describeOCSP(cert *x509.Certificate, intermediates [][]byte, ca []byte) string {
+// describeOCSP returns the OCSP status of cert. The error is set when the
+// OCSP server could not be queried.
+func describeOCSP(cert *x509.Certificate, intermediates [][]byte, ca []byte) (string, error) {
 	if len(ca) > 1 {
 		intermediates = append([][]byte{ca}, intermediates...)
 	}
 	if len(intermediates) < 1 {
-		return "Cannot check OCSP, does not have a CA or intermediate certificate provided"
+		return "Cannot check OCSP, does not have a CA or intermediate certificate provided", nil
+	}
+	if cmcmdutil.Offline() {
+		return "Not checked, --offline is set", nil
 	}
 	issuerCert, err := pki.DecodeX509CertificateBytes(intermediates[len(intermediates)-1])
 	if err != nil {
-		return fmt.Sprintf("Cannot parse intermediate certificate: %s", err.Error())
+		return fmt.Sprintf("Cannot parse intermediate certificate: %s", err.Error()), nil
 	}
 
-	valid, err := checkOCSPValidCert(cert, issuerCert)
+	valid, err := revocation.checkOCSPValidCert(cert, issuerCert)
 	if err != nil {
-		return fmt.Sprintf("Cannot check OCSP: %s", err.Error())
+		return fmt.Sprintf("Cannot check OCSP: %s", err.Error()), fmt.Errorf("cannot check OCSP: %w", err)
 	}
 
 	if !valid {
-		return "Marked as revoked"
+		return "Marked as revoked", nil
 	}
 
-	return "valid"
+	return "valid", nil
 }
 
-func describeTrusted(cert *x509.Certificate, intermediates [][]byte) string {
-	systemPool, err := x509.SystemCertPool()
-	if err != nil {
-		return fmt.Sprintf("Error getting system CA store: %s", err.Error())
-	}
+// describeTrusted reports whether cert is trusted by roots, or by the trust
+// store of this computer if roots is nil. The system trust store is checked by
+// the platform verifier, which is CryptoAPI on Windows and the Security
+// framework on macOS. The intermediates from the Secret are only used to build
+// the chain, they are never trusted as roots.
+func describeTrusted(cert *x509.Certificate, intermediates [][]byte, roots *x509.CertPool) string {
+	intermediatePool := x509.NewCertPool()
 	for _, intermediate := range intermediates {
-		systemPool.AppendCertsFromPEM(intermediate)
+		intermediatePool.AppendCertsFromPEM(intermediate)
 	}
-	_, err = cert.Verify(x509.VerifyOptions{
-		Roots:       systemPool,
-		CurrentTime: clock.Now(),
+	_, err := cert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediatePool,
+		CurrentTime:   clock.Now(),
 	})
 	if err == nil {
 		return "yes"
@@ -28,6 +28,7 @@ import (
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
 )
 
 var (
@@ -141,13 +142,26 @@ func Test_describeCRL(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := describeCRL(tt.cert); got != tt.want {
+			if got, _ := describeCRL(tt.cert); got != tt.want {
 				t.Errorf("describeCRL() = %v, want %v", makeInvisibleVisible(got), makeInvisibleVisible(tt.want))
 			}
 		})
 	}
 }
 
+func Test_describeCRLUnreachable(t *testing.T) {
+	cert := MustParseCertificate(t, testCert)
+	cert.CRLDistributionPoints = []string{"https://127.0.0.1:1/ca.crl"}
+
+	got, err := describeCRL(cert)
+	if err == nil {
+		t.Fatalf("describeCRL() returned no error for an unreachable CRL endpoint")
+	}
+	if !strings.HasPrefix(got, "Cannot check CRL: ") {
+		t.Errorf("describeCRL() = %v, want a \"Cannot check CRL\" status", got)
+	}
+}
+
 func Test_describeCertificate(t *testing.T) {
 	tests := []struct {
 		name string
@@ -203,7 +217,7 @@ func Test_describeDebugging(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := describeDebugging(tt.args.cert, tt.args.intermediates, tt.args.ca); got != tt.want {
+			if got, _ := describeDebugging(tt.args.cert, tt.args.intermediates, tt.args.ca, nil); got != tt.want {
 				t.Errorf("describeDebugging() = %v, want %v", makeInvisibleVisible(got), makeInvisibleVisible(tt.want))
 			}
 		})
@@ -281,7 +295,7 @@ func Test_describeOCSP(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := describeOCSP(tt.args.cert, tt.args.intermediates, tt.args.ca); got != tt.want {
+			if got, _ := describeOCSP(tt.args.cert, tt.args.intermediates, tt.args.ca); got != tt.want {
 				t.Errorf("describeOCSP() = %v, want %v", makeInvisibleVisible(got), makeInvisibleVisible(tt.want))
 			}
 		})
@@ -292,9 +306,12 @@ func Test_describeTrusted(t *testing.T) {
 	// set clock to when our test cert was trusted
 	t1, _ := time.Parse("Thu, 27 Nov 2020 10:00:00 UTC", time.RFC1123)
 	clock = fakeclock.NewFakeClock(t1)
+	roots := x509.NewCertPool()
+	roots.AddCert(MustParseCertificate(t, testCert))
 	type args struct {
 		cert          *x509.Certificate
 		intermediates [][]byte
+		roots         *x509.CertPool
 	}
 	tests := []struct {
 		name string
@@ -311,16 +328,24 @@ func Test_describeTrusted(t *testing.T) {
 		},
 		{
 			name: "Describe test certificate with adding it to the trust store",
+			args: args{
+				cert:  MustParseCertificate(t, testCert),
+				roots: roots,
+			},
+			want: "yes",
+		},
+		{
+			name: "Describe test certificate with only adding it as intermediate",
 			args: args{
 				cert:          MustParseCertificate(t, testCert),
 				intermediates: [][]byte{[]byte(testCert)},
 			},
-			want: "yes",
+			want: "no: x509: certificate signed by unknown authority",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := describeTrusted(tt.args.cert, tt.args.intermediates); got != tt.want {
+			if got := describeTrusted(tt.args.cert, tt.args.intermediates, tt.args.roots); got != tt.want {
 				t.Errorf("describeTrusted() = %v, want %v", makeInvisibleVisible(got), makeInvisibleVisible(tt.want))
 			}
 		})
@@ -377,7 +402,7 @@ func Test_describeValidityPeriod(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := describeValidityPeriod(tt.cert); got != tt.want {
+			if got := describeValidityPeriod(tt.cert, util.TimeFormatRFC1123); got != tt.want {
 				t.Errorf("describeValidityPeriod() = %v, want %v", makeInvisibleVisible(got), makeInvisibleVisible(tt.want))
 			}
 		})
@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"bytes"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+)
+
+const spiffeTemplate = `SPIFFE:
+	SPIFFE ID:	{{ .ID }}
+	Trust Domain:	{{ .TrustDomain }}
+	Valid X509-SVID:	{{ .Valid }}`
+
+var spiffeTmpl = template.Must(template.New("spiffeTemplate").Parse(spiffeTemplate))
+
+// describeSPIFFE validates cert as the leaf of an X509-SVID. If trustDomain
+// is set, the SPIFFE ID must belong to it. If bundle is set, the chain of the
+// SVID must verify against it, which is the X.509 bundle of its trust domain.
+func describeSPIFFE(cert *x509.Certificate, intermediates [][]byte, trustDomain string, bundle *x509.CertPool) string {
+	id, problems := validateSVID(cert)
+
+	var idTrustDomain string
+	if id != nil {
+		idTrustDomain = id.Host
+		if trustDomain != "" && idTrustDomain != trustDomain {
+			problems = append(problems, fmt.Sprintf("trust domain %q does not match the expected trust domain %q", idTrustDomain, trustDomain))
+		}
+	}
+
+	if bundle != nil {
+		intermediatePool := x509.NewCertPool()
+		for _, intermediate := range intermediates {
+			intermediatePool.AppendCertsFromPEM(intermediate)
+		}
+		_, err := cert.Verify(x509.VerifyOptions{
+			Roots:         bundle,
+			Intermediates: intermediatePool,
+			CurrentTime:   clock.Now(),
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		})
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("not signed by the trust bundle: %s", err))
+		}
+	}
+
+	valid := "yes"
+	if len(problems) > 0 {
+		valid = "no" + printSlice(problems)
+	}
+
+	var idString string
+	if id != nil {
+		idString = id.String()
+	}
+
+	var b bytes.Buffer
+	spiffeTmpl.Execute(&b, struct {
+		ID          string
+		TrustDomain string
+		Valid       string
+	}{
+		ID:          printOrNone(idString),
+		TrustDomain: printOrNone(idTrustDomain),
+		Valid:       valid,
+	})
+
+	return b.String()
+}
+
+// validateSVID checks the requirements of the X509-SVID specification for a
+// leaf SVID. It returns the SPIFFE ID of cert, or nil if it does not have a
+// valid one, and the requirements that cert does not meet.
+func validateSVID(cert *x509.Certificate) (*url.URL, []string) {
+	var problems []string
+
+	var id *url.URL
+	switch len(cert.URIs) {
+	case 0:
+		problems = append(problems, "has no URI SAN with a SPIFFE ID")
+	case 1:
+		if err := validateSPIFFEID(cert.URIs[0]); err != nil {
+			problems = append(problems, fmt.Sprintf("invalid SPIFFE ID %q: %s", cert.URIs[0], err))
+		} else {
+			id = cert.URIs[0]
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("has %d URI SANs, but must have exactly one", len(cert.URIs)))
+	}
+
+	if cert.IsCA {
+		problems = append(problems, "is a CA certificate")
+	}
+	if cert.KeyUsage&x509.KeyUsageDigitalSignature == 0 {
+		problems = append(problems, "key usage does not include digital signature")
+	}
+	if cert.KeyUsage&x509.KeyUsageCertSign != 0 {
+		problems = append(problems, "key usage includes cert sign")
+	}
+	if cert.KeyUsage&x509.KeyUsageCRLSign != 0 {
+		problems = append(problems, "key usage includes crl sign")
+	}
+
+	return id, problems
+}
+
+// validateSPIFFEID returns an error if id is not a valid SPIFFE ID as defined
+// by the SPIFFE ID specification.
+func validateSPIFFEID(id *url.URL) error {
+	if id.Scheme != "spiffe" {
+		return errors.New("scheme must be 'spiffe'")
+	}
+	if id.User != nil || id.Port() != "" {
+		return errors.New("must not have user info or a port")
+	}
+	if id.RawQuery != "" || id.Fragment != "" {
+		return errors.New("must not have a query or fragment")
+	}
+	if id.Host == "" {
+		return errors.New("trust domain is missing")
+	}
+	for _, c := range id.Host {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '.' || c == '-' || c == '_') {
+			return errors.New("trust domain must only contain lowercase letters, digits, '.', '-' and '_'")
+		}
+	}
+	if id.Path == "" {
+		return nil
+	}
+	for _, segment := range strings.Split(strings.TrimPrefix(id.Path, "/"), "/") {
+		switch segment {
+		case "":
+			return errors.New("path must not contain empty segments or a trailing '/'")
+		case ".", "..":
+			return errors.New("path must not contain '.' or '..' segments")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"crypto/x509"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func Test_validateSPIFFEID(t *testing.T) {
+	tests := []struct {
+		id      string
+		wantErr bool
+	}{
+		{id: "spiffe://example.org/ns/default/sa/app"},
+		{id: "spiffe://example.org"},
+		{id: "https://example.org/ns/default", wantErr: true},
+		{id: "spiffe://Example.org/app", wantErr: true},
+		{id: "spiffe://example.org:8443/app", wantErr: true},
+		{id: "spiffe://user@example.org/app", wantErr: true},
+		{id: "spiffe://example.org/app?x=1", wantErr: true},
+		{id: "spiffe://example.org/app/", wantErr: true},
+		{id: "spiffe://example.org/a//b", wantErr: true},
+		{id: "spiffe://example.org/a/../b", wantErr: true},
+		{id: "spiffe:///app", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			u, err := url.Parse(tt.id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := validateSPIFFEID(u); (err != nil) != tt.wantErr {
+				t.Errorf("validateSPIFFEID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_validateSVID(t *testing.T) {
+	mustParseURL := func(s string) *url.URL {
+		u, err := url.Parse(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return u
+	}
+	id := mustParseURL("spiffe://example.org/app")
+
+	tests := []struct {
+		name         string
+		cert         *x509.Certificate
+		wantID       *url.URL
+		wantProblems []string
+	}{
+		{
+			name:   "Valid SVID",
+			cert:   &x509.Certificate{URIs: []*url.URL{id}, KeyUsage: x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment},
+			wantID: id,
+		},
+		{
+			name:         "No URI SAN",
+			cert:         &x509.Certificate{KeyUsage: x509.KeyUsageDigitalSignature},
+			wantProblems: []string{"has no URI SAN with a SPIFFE ID"},
+		},
+		{
+			name:         "Multiple URI SANs",
+			cert:         &x509.Certificate{URIs: []*url.URL{id, mustParseURL("spiffe://example.org/other")}, KeyUsage: x509.KeyUsageDigitalSignature},
+			wantProblems: []string{"has 2 URI SANs, but must have exactly one"},
+		},
+		{
+			name:   "CA with wrong key usages",
+			cert:   &x509.Certificate{URIs: []*url.URL{id}, IsCA: true, KeyUsage: x509.KeyUsageCertSign | x509.KeyUsageCRLSign},
+			wantID: id,
+			wantProblems: []string{
+				"is a CA certificate",
+				"key usage does not include digital signature",
+				"key usage includes cert sign",
+				"key usage includes crl sign",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotID, gotProblems := validateSVID(tt.cert)
+			if gotID != tt.wantID {
+				t.Errorf("validateSVID() id = %v, want %v", gotID, tt.wantID)
+			}
+			if !reflect.DeepEqual(gotProblems, tt.wantProblems) {
+				t.Errorf("validateSVID() problems = %v, want %v", gotProblems, tt.wantProblems)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+//go:build !windows && !darwin
+
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+// platformVerifier is the verifier that crypto/x509 uses for the system trust
+// store. On other platforms the chain is verified against the root
+// certificates in the system certificate files, e.g. /etc/ssl/certs.
+const platformVerifier = "system root certificates"
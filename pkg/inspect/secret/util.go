@@ -18,19 +18,12 @@ package secret
 
 import (
 	"bytes"
-	"crypto"
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/pem"
-	"errors"
 	"fmt"
-	"io"
-	"net/http"
-	"net/url"
 	"strings"
 
-	"golang.org/x/crypto/ocsp"
-
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 )
 
@@ -51,79 +44,6 @@ func fingerprintCert(cert *x509.Certificate) string {
 	return buf.String()
 }
 
-func checkOCSPValidCert(leafCert, issuerCert *x509.Certificate) (bool, error) {
-	if len(leafCert.OCSPServer) < 1 {
-		return false, errors.New("No OCSP Server set")
-	}
-	buffer, err := ocsp.CreateRequest(leafCert, issuerCert, &ocsp.RequestOptions{Hash: crypto.SHA1})
-	if err != nil {
-		return false, fmt.Errorf("error creating OCSP request: %w", err)
-	}
-
-	for _, ocspServer := range leafCert.OCSPServer {
-		httpRequest, err := http.NewRequest(http.MethodPost, ocspServer, bytes.NewBuffer(buffer))
-		if err != nil {
-			return false, fmt.Errorf("error creating HTTP request: %w", err)
-		}
-		ocspUrl, err := url.Parse(ocspServer)
-		if err != nil {
-			return false, fmt.Errorf("error parsing OCSP URL: %w", err)
-		}
-		httpRequest.Header.Add("Content-Type", "application/ocsp-request")
-		httpRequest.Header.Add("Accept", "application/ocsp-response")
-		httpRequest.Header.Add("Host", ocspUrl.Host)
-		httpClient := &http.Client{}
-		httpResponse, err := httpClient.Do(httpRequest)
-		if err != nil {
-			return false, fmt.Errorf("error making HTTP request: %w", err)
-		}
-		defer httpResponse.Body.Close()
-		output, err := io.ReadAll(httpResponse.Body)
-		if err != nil {
-			return false, fmt.Errorf("error reading HTTP body: %w", err)
-		}
-		ocspResponse, err := ocsp.ParseResponse(output, issuerCert)
-		if err != nil {
-			return false, fmt.Errorf("error reading OCSP response: %w", err)
-		}
-
-		if ocspResponse.Status == ocsp.Revoked {
-			// one OCSP revoked it do not trust
-			return false, nil
-		}
-	}
-
-	return true, nil
-}
-
-func checkCRLValidCert(cert *x509.Certificate, url string) (bool, error) {
-	resp, err := http.Get(url)
-	if err != nil {
-		return false, fmt.Errorf("error getting HTTP response: %w", err)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return false, fmt.Errorf("error reading HTTP body: %w", err)
-	}
-	resp.Body.Close()
-
-	crl, err := x509.ParseRevocationList(body)
-	if err != nil {
-		return false, fmt.Errorf("error parsing HTTP body: %w", err)
-	}
-
-	// TODO: check CRL signature
-
-	for _, revoked := range crl.RevokedCertificateEntries {
-		if cert.SerialNumber.Cmp(revoked.SerialNumber) == 0 {
-			return false, nil
-		}
-	}
-
-	return true, nil
-}
-
 func printSlice(in []string) string {
 	if len(in) < 1 {
 		return "<none>"
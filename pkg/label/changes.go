@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package label
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+)
+
+// findResourceType returns the cert-manager resource kind named by name.
+func findResourceType(name string) (cmcmdutil.Kind, error) {
+	k, err := cmcmdutil.ParseKind(name)
+	if err != nil || !k.CertManager() {
+		return cmcmdutil.Kind{}, fmt.Errorf("%q is not a cert-manager resource type", name)
+	}
+	return k, nil
+}
+
+// changes are the metadata keys to set and remove.
+type changes struct {
+	set    map[string]string
+	remove []string
+}
+
+// parseArgs splits args into resource names and metadata changes in the
+// format 'key=value' and 'key-'. Label values are validated if labels is true.
+func parseArgs(args []string, labels bool) ([]string, changes, error) {
+	var names []string
+	c := changes{set: map[string]string{}}
+	for _, arg := range args {
+		switch {
+		case strings.Contains(arg, "="):
+			key, value, _ := strings.Cut(arg, "=")
+			if err := validateKey(key); err != nil {
+				return nil, c, err
+			}
+			if labels {
+				if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+					return nil, c, fmt.Errorf("invalid label value %q: %s", value, strings.Join(errs, "; "))
+				}
+			}
+			c.set[key] = value
+		case strings.HasSuffix(arg, "-"):
+			key := strings.TrimSuffix(arg, "-")
+			if err := validateKey(key); err != nil {
+				return nil, c, err
+			}
+			c.remove = append(c.remove, key)
+		default:
+			names = append(names, arg)
+		}
+	}
+
+	for _, key := range c.remove {
+		if _, ok := c.set[key]; ok {
+			return nil, c, fmt.Errorf("cannot both modify and remove %q", key)
+		}
+	}
+	if len(c.set) == 0 && len(c.remove) == 0 {
+		return nil, c, fmt.Errorf("at least one key=value or key- change is required")
+	}
+	return names, c, nil
+}
+
+func validateKey(key string) error {
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return fmt.Errorf("invalid key %q: %s", key, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// patch returns the merge patch values that apply c to current, where a nil
+// value removes a key. It returns an error if an existing key would get a
+// different value and overwrite is false.
+func (c changes) patch(current map[string]string, overwrite bool) (map[string]*string, error) {
+	patch := map[string]*string{}
+	for key, value := range c.set {
+		old, ok := current[key]
+		if ok && old == value {
+			continue
+		}
+		if ok && !overwrite {
+			return nil, fmt.Errorf("%q already has a value (%s), and --overwrite is false", key, old)
+		}
+		value := value
+		patch[key] = &value
+	}
+	for _, key := range c.remove {
+		if _, ok := current[key]; ok {
+			patch[key] = nil
+		}
+	}
+	return patch, nil
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package label
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseArgs(t *testing.T) {
+	tests := map[string]struct {
+		args     []string
+		labels   bool
+		expNames []string
+		exp      changes
+		expErr   bool
+	}{
+		"names and changes": {
+			args:     []string{"crt-1", "team=new", "crt-2", "env-"},
+			labels:   true,
+			expNames: []string{"crt-1", "crt-2"},
+			exp:      changes{set: map[string]string{"team": "new"}, remove: []string{"env"}},
+		},
+		"annotation values are not validated as label values": {
+			args: []string{"example.com/owner=team@example.com"},
+			exp:  changes{set: map[string]string{"example.com/owner": "team@example.com"}},
+		},
+		"invalid label value": {
+			args:   []string{"example.com/owner=team@example.com"},
+			labels: true,
+			expErr: true,
+		},
+		"invalid key": {
+			args:   []string{"-=value"},
+			expErr: true,
+		},
+		"modify and remove the same key": {
+			args:   []string{"team=new", "team-"},
+			expErr: true,
+		},
+		"no changes": {
+			args:   []string{"crt-1"},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			names, c, err := parseArgs(test.args, test.labels)
+			if (err != nil) != test.expErr {
+				t.Fatalf("expected error=%t, got %v", test.expErr, err)
+			}
+			if test.expErr {
+				return
+			}
+			if !reflect.DeepEqual(names, test.expNames) {
+				t.Errorf("unexpected names, exp=%v got=%v", test.expNames, names)
+			}
+			if !reflect.DeepEqual(c, test.exp) {
+				t.Errorf("unexpected changes, exp=%+v got=%+v", test.exp, c)
+			}
+		})
+	}
+}
+
+func TestPatch(t *testing.T) {
+	c := changes{set: map[string]string{"team": "new", "env": "prod"}, remove: []string{"old", "missing"}}
+	current := map[string]string{"team": "old", "env": "prod", "old": "x"}
+
+	if _, err := c.patch(current, false); err == nil {
+		t.Error("expected an error when overwriting without --overwrite")
+	}
+
+	patch, err := c.patch(current, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patch) != 2 || *patch["team"] != "new" || patch["old"] != nil {
+		t.Errorf("unexpected patch: %v", patch)
+	}
+	if _, ok := patch["old"]; !ok {
+		t.Error("expected the removed key to be in the patch")
+	}
+
+	patch, err = c.patch(map[string]string{"team": "new", "env": "prod"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patch) != 0 {
+		t.Errorf("expected an empty patch when nothing changes, got %v", patch)
+	}
+}
+
+func TestFindResourceType(t *testing.T) {
+	for _, name := range []string{"certs", "Certificates.cert-manager.io", "clusterissuer", "ciss", "iss", "challenges.acme.cert-manager.io"} {
+		if _, err := findResourceType(name); err != nil {
+			t.Errorf("expected %q to be a cert-manager resource type: %v", name, err)
+		}
+	}
+	for _, name := range []string{"secrets", "csr"} {
+		if _, err := findResourceType(name); err == nil {
+			t.Errorf("expected %q not to be a cert-manager resource type", name)
+		}
+	}
+}
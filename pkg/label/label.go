@@ -0,0 +1,273 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package label
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/metadata"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+)
+
+var (
+	labelLong = templates.LongDesc(i18n.T(`
+Update the labels of cert-manager resources in bulk.
+
+Resources are selected by name, by label selector or with --all, in the current namespace
+or across all namespaces. Existing labels are only changed with --overwrite. A summary
+of the changed and unchanged resources is printed at the end.
+
+TYPE is a cert-manager resource kind, its plural or its short name, e.g. cert, cr, iss or ciss.`))
+
+	labelExample = templates.Examples(i18n.T(build.WithTemplate(`
+# Relabel all Certificates of team 'old' in all namespaces
+{{.BuildName}} label certificates -l team=old team=new --overwrite --all-namespaces
+
+# Show which Issuers would get the label 'env=prod' without changing them
+{{.BuildName}} label issuers --all env=prod --dry-run
+
+# Remove the label 'team' from the Certificate 'my-crt'
+{{.BuildName}} label certificate my-crt team-`)))
+
+	annotateLong = templates.LongDesc(i18n.T(`
+Update the annotations of cert-manager resources in bulk.
+
+Resources are selected by name, by label selector or with --all, in the current namespace
+or across all namespaces. Existing annotations are only changed with --overwrite. A summary
+of the changed and unchanged resources is printed at the end.
+
+TYPE is a cert-manager resource kind, its plural or its short name, e.g. cert, cr, iss or ciss.`))
+
+	annotateExample = templates.Examples(i18n.T(build.WithTemplate(`
+# Annotate all Certificates with the label 'team=payments' with their owner
+{{.BuildName}} annotate certificates -l team=payments example.com/owner=payments@example.com
+
+# Remove the annotation 'example.com/owner' from all ClusterIssuers
+{{.BuildName}} annotate clusterissuers --all example.com/owner-`)))
+)
+
+// Options is a struct to support label and annotate commands
+type Options struct {
+	// Labels is true when editing labels and false when editing annotations.
+	Labels bool
+
+	LabelSelector string
+	All           bool
+	AllNamespaces bool
+	Overwrite     bool
+	DryRun        cmcmdutil.DryRun
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams, labels bool) *Options {
+	return &Options{
+		Labels:    labels,
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdLabel returns a cobra command for labeling cert-manager resources
+func NewCmdLabel(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	return newCmd(ctx, NewOptions(ioStreams, true), "label", "Update the labels of cert-manager resources", labelLong, labelExample)
+}
+
+// NewCmdAnnotate returns a cobra command for annotating cert-manager resources
+func NewCmdAnnotate(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	return newCmd(ctx, NewOptions(ioStreams, false), "annotate", "Update the annotations of cert-manager resources", annotateLong, annotateExample)
+}
+
+func newCmd(ctx context.Context, o *Options, use, short, long, example string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     use + " TYPE [NAME...] KEY_1=VAL_1 ... KEY_N=VAL_N",
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate(args)))
+			cmcmdutil.CheckErr(o.Run(ctx, args))
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.LabelSelector, "selector", "l", o.LabelSelector, "Selector (label query) to filter on, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
+	cmd.Flags().BoolVar(&o.All, "all", o.All, "Select all resources of the type in the given Namespace, or all namespaces with --all-namespaces enabled.")
+	factory.AddAllNamespacesFlag(cmd, &o.AllNamespaces, "select resources")
+	cmd.Flags().BoolVar(&o.Overwrite, "overwrite", o.Overwrite, "If true, allow existing keys to be overwritten.")
+	cmcmdutil.AddDryRunFlag(cmd.Flags(), &o.DryRun)
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) < 2 {
+		return errors.New("a resource type and at least one key=value or key- change have to be provided as arguments")
+	}
+	if _, err := findResourceType(args[0]); err != nil {
+		return err
+	}
+	names, _, err := parseArgs(args[1:], o.Labels)
+	if err != nil {
+		return err
+	}
+
+	if len(o.LabelSelector) > 0 && len(names) > 0 {
+		return errors.New("cannot specify resource names in conjunction with label selectors")
+	}
+	if len(o.LabelSelector) > 0 && o.All {
+		return errors.New("cannot specify label selectors in conjunction with --all flag")
+	}
+	if o.All && len(names) > 0 {
+		return errors.New("cannot specify resource names in conjunction with --all flag")
+	}
+	if !o.All && len(o.LabelSelector) == 0 && len(names) == 0 {
+		return errors.New("please supply one or more resource names, a label selector or use the --all flag")
+	}
+	return nil
+}
+
+// Run executes label or annotate command
+func (o *Options) Run(ctx context.Context, args []string) error {
+	rt, err := findResourceType(args[0])
+	if err != nil {
+		return err
+	}
+	names, c, err := parseArgs(args[1:], o.Labels)
+	if err != nil {
+		return err
+	}
+
+	// Only the metadata of the resources is listed and patched, since their
+	// spec and status are not needed to change labels or annotations.
+	client, err := metadata.NewForConfig(o.RESTConfig)
+	if err != nil {
+		return err
+	}
+	var resource metadata.ResourceInterface = client.Resource(rt.Resource)
+	if rt.Namespaced && !o.AllNamespaces {
+		resource = client.Resource(rt.Resource).Namespace(o.Namespace)
+	}
+
+	var objs []metav1.PartialObjectMetadata
+	if o.All || len(o.LabelSelector) > 0 {
+		err := cmcmdutil.ListPages(ctx, metav1.ListOptions{LabelSelector: o.LabelSelector}, func(opts metav1.ListOptions) (string, error) {
+			list, err := resource.List(ctx, opts)
+			if err != nil {
+				return "", err
+			}
+			objs = append(objs, list.Items...)
+			return list.Continue, nil
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		for _, name := range names {
+			obj, err := resource.Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			objs = append(objs, *obj)
+		}
+	}
+
+	if len(objs) == 0 {
+		fmt.Fprintf(o.ErrOut, "No %s resources found\n", rt.Name)
+		return nil
+	}
+
+	verb := "annotated"
+	if o.Labels {
+		verb = "labeled"
+	}
+	dryRun := o.DryRun.Suffix()
+
+	changed, unchanged := 0, 0
+	for _, obj := range objs {
+		current := obj.GetAnnotations()
+		if o.Labels {
+			current = obj.GetLabels()
+		}
+
+		id := fmt.Sprintf("%s/%s", rt.Name, obj.GetName())
+		if rt.Namespaced {
+			id = fmt.Sprintf("%s %s/%s", rt.Name, obj.GetNamespace(), obj.GetName())
+		}
+
+		values, err := c.patch(current, o.Overwrite)
+		if err != nil {
+			return fmt.Errorf("%s: %w", id, err)
+		}
+		if len(values) == 0 {
+			unchanged++
+			fmt.Fprintf(o.Out, "%s not %s\n", id, verb)
+			continue
+		}
+
+		if !o.DryRun.SkipRequest() {
+			if err := o.apply(ctx, client, rt, obj, values); err != nil {
+				return fmt.Errorf("failed to update %s: %w", id, err)
+			}
+		}
+		changed++
+		fmt.Fprintf(o.Out, "%s %s%s\n", id, verb, dryRun)
+	}
+
+	fmt.Fprintf(o.Out, "\nSummary: %d %s%s, %d unchanged\n", changed, verb, dryRun, unchanged)
+	return nil
+}
+
+func (o *Options) apply(ctx context.Context, client metadata.Interface, rt cmcmdutil.Kind, obj metav1.PartialObjectMetadata, values map[string]*string) error {
+	field := "annotations"
+	if o.Labels {
+		field = "labels"
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			field: values,
+			// The resourceVersion guards against conflicting concurrent
+			// changes between listing and patching.
+			"resourceVersion": obj.GetResourceVersion(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	var resource metadata.ResourceInterface = client.Resource(rt.Resource)
+	if rt.Namespaced {
+		resource = client.Resource(rt.Resource).Namespace(obj.GetNamespace())
+	}
+	_, err = resource.Patch(ctx, obj.GetName(), types.MergePatchType, patch, metav1.PatchOptions{DryRun: o.DryRun.Options()})
+	return err
+}
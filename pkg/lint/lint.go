@@ -0,0 +1,203 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/resource"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Check cert-manager manifests for common mistakes, without connecting to a cluster.
+
+Every rule has a default severity which can be changed with --severity, or disabled by
+setting it to 'off'. The command fails if a finding has at least the severity given by
+--fail-on, which makes it suitable for CI pipelines. Use --list-rules to show all rules.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Lint all manifests in the 'manifests' directory
+{{.BuildName}} lint -f ./manifests --recursive
+
+# Fail on warnings as well, and ignore Certificates without rotationPolicy
+{{.BuildName}} lint -f ./manifests --fail-on warning --severity missing-rotation-policy=off`)))
+)
+
+// Options is a struct to support lint command
+type Options struct {
+	// Severities overrides the severity of rules, in the form rule=severity.
+	Severities []string
+	// FailOn is the minimum severity of a finding that fails the command.
+	FailOn    string
+	ListRules bool
+
+	overrides map[string]Severity
+	failOn    Severity
+
+	resource.FilenameOptions
+	genericclioptions.IOStreams
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		FailOn:    string(SeverityError),
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdLint returns a cobra command for linting cert-manager manifests
+func NewCmdLint(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "lint",
+		Short:   "Check cert-manager manifests for common mistakes",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Complete()))
+			cmcmdutil.CheckErr(o.Run())
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&o.Severities, "severity", o.Severities, "Override the severity of a rule, in the form rule=severity. Severity is one of error, warning, info or off. May be repeated.")
+	cmd.Flags().StringVar(&o.FailOn, "fail-on", o.FailOn, "Fail if a finding has at least this severity, one of error, warning or info")
+	cmd.Flags().BoolVar(&o.ListRules, "list-rules", o.ListRules, "If true, list the available rules and exit.")
+	cmdutil.AddFilenameOptionFlags(cmd, &o.FilenameOptions, "Path to a file containing cert-manager resources to be linted.")
+
+	return cmd
+}
+
+// Complete parses and validates the provided options
+func (o *Options) Complete() error {
+	if o.ListRules {
+		return nil
+	}
+
+	if err := o.FilenameOptions.RequireFilenameOrKustomize(); err != nil {
+		return err
+	}
+
+	var err error
+	if o.failOn, err = parseSeverity(o.FailOn); err != nil {
+		return fmt.Errorf("invalid --fail-on: %w", err)
+	}
+	if o.failOn == SeverityOff {
+		return fmt.Errorf("--fail-on must be one of error, warning or info")
+	}
+
+	o.overrides = map[string]Severity{}
+	for _, s := range o.Severities {
+		id, value, ok := strings.Cut(s, "=")
+		if !ok {
+			return fmt.Errorf("invalid --severity %q, must be of the form rule=severity", s)
+		}
+		if !knownRule(id) {
+			return fmt.Errorf("unknown rule %q, use --list-rules to show all rules", id)
+		}
+		sev, err := parseSeverity(value)
+		if err != nil {
+			return err
+		}
+		o.overrides[id] = sev
+	}
+
+	return nil
+}
+
+// Run executes lint command
+func (o *Options) Run() error {
+	if o.ListRules {
+		w := util.NewTabWriter(o.Out)
+		fmt.Fprint(w, "RULE\tSEVERITY\tDESCRIPTION\n")
+		for _, r := range rules {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", r.ID, r.Default, r.Description)
+		}
+		return w.Flush()
+	}
+
+	r := resource.NewLocalBuilder().
+		Unstructured().
+		FilenameParam(false, &o.FilenameOptions).
+		Flatten().
+		Do()
+	if err := r.Err(); err != nil {
+		return err
+	}
+
+	infos, err := r.Infos()
+	if err != nil {
+		return err
+	}
+
+	var objs []object
+	for _, info := range infos {
+		u, ok := info.Object.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		objs = append(objs, object{Source: info.Source, Unstructured: u})
+	}
+
+	findings, err := newLinter(o.overrides).lint(objs)
+	if err != nil {
+		return err
+	}
+
+	out, closePager := cmcmdutil.StartPager(o.Out)
+	defer closePager()
+	o.Out = out
+
+	failed := 0
+	for _, f := range findings {
+		fmt.Fprintln(o.Out, f)
+		if f.Severity.rank() >= o.failOn.rank() {
+			failed++
+		}
+	}
+
+	if len(findings) == 0 {
+		fmt.Fprintf(o.ErrOut, "No issues found in %d resource(s)\n", len(objs))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d finding(s) with severity %s or higher", failed, o.failOn)
+	}
+	return nil
+}
+
+func knownRule(id string) bool {
+	for _, r := range rules {
+		if r.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,295 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// Severity is the severity of a finding.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+	SeverityOff     Severity = "off"
+)
+
+// rank orders severities, higher is more severe.
+func (s Severity) rank() int {
+	switch s {
+	case SeverityError:
+		return 3
+	case SeverityWarning:
+		return 2
+	case SeverityInfo:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func parseSeverity(s string) (Severity, error) {
+	switch sev := Severity(strings.ToLower(s)); sev {
+	case SeverityError, SeverityWarning, SeverityInfo, SeverityOff:
+		return sev, nil
+	}
+	return "", fmt.Errorf("unknown severity %q, must be one of: error, warning, info, off", s)
+}
+
+// Rule IDs.
+const (
+	RuleRenewBefore         = "renew-before"
+	RuleRotationPolicy      = "missing-rotation-policy"
+	RuleWildcardHTTP01      = "wildcard-http01"
+	RuleSecretNameCollision = "secret-name-collision"
+	RuleDeprecatedAPI       = "deprecated-api"
+)
+
+// Rule is a check applied to the linted manifests.
+type Rule struct {
+	ID          string
+	Description string
+	Default     Severity
+}
+
+var rules = []Rule{
+	{ID: RuleRenewBefore, Default: SeverityWarning, Description: "renewBefore is not shorter than, or too close to, the duration of a Certificate"},
+	{ID: RuleRotationPolicy, Default: SeverityWarning, Description: "Certificate does not set spec.privateKey.rotationPolicy, so the private key is reused on renewal"},
+	{ID: RuleWildcardHTTP01, Default: SeverityError, Description: "Certificate requests a wildcard name from an ACME issuer that only has HTTP01 solvers"},
+	{ID: RuleSecretNameCollision, Default: SeverityError, Description: "multiple Certificates in the same namespace write to the same Secret"},
+	{ID: RuleDeprecatedAPI, Default: SeverityWarning, Description: "resource uses a deprecated or removed cert-manager API version"},
+}
+
+// Finding is a single rule violation.
+type Finding struct {
+	Source    string
+	Kind      string
+	Namespace string
+	Name      string
+	Rule      string
+	Severity  Severity
+	Message   string
+}
+
+func (f Finding) String() string {
+	object := f.Kind + "/" + f.Name
+	if f.Namespace != "" {
+		object = f.Kind + "/" + f.Namespace + "/" + f.Name
+	}
+	return fmt.Sprintf("%s: %s: [%s] %s: %s", f.Source, object, f.Severity, f.Rule, f.Message)
+}
+
+// object is a manifest read from Source.
+type object struct {
+	Source string
+	*unstructured.Unstructured
+}
+
+// linter applies the rules to a set of manifests.
+type linter struct {
+	severities map[string]Severity
+
+	findings []Finding
+}
+
+func newLinter(overrides map[string]Severity) *linter {
+	l := &linter{severities: map[string]Severity{}}
+	for _, r := range rules {
+		l.severities[r.ID] = r.Default
+	}
+	for id, sev := range overrides {
+		l.severities[id] = sev
+	}
+	return l
+}
+
+func (l *linter) report(obj object, rule, format string, args ...interface{}) {
+	sev := l.severities[rule]
+	if sev == SeverityOff {
+		return
+	}
+	l.findings = append(l.findings, Finding{
+		Source:    obj.Source,
+		Kind:      obj.GetKind(),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Rule:      rule,
+		Severity:  sev,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+type issuerKey struct {
+	kind, namespace, name string
+}
+
+type certificate struct {
+	object
+	crt *cmapi.Certificate
+}
+
+// lint returns the findings for objs, sorted by source and object.
+func (l *linter) lint(objs []object) ([]Finding, error) {
+	var crts []certificate
+	issuers := map[issuerKey]*cmapi.IssuerSpec{}
+
+	for _, obj := range objs {
+		gv, err := schema.ParseGroupVersion(obj.GetAPIVersion())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", obj.Source, err)
+		}
+
+		switch gv.Group {
+		case "certmanager.k8s.io":
+			l.report(obj, RuleDeprecatedAPI, "the API group %s was removed in cert-manager v0.11, use cert-manager.io/v1", gv.Group)
+			continue
+		case cmapi.SchemeGroupVersion.Group, "acme.cert-manager.io":
+		default:
+			continue
+		}
+		if gv.Version != "v1" {
+			l.report(obj, RuleDeprecatedAPI, "%s is no longer served by cert-manager, use 'cmctl convert' to upgrade to %s/v1", obj.GetAPIVersion(), gv.Group)
+			continue
+		}
+
+		switch obj.GetKind() {
+		case cmapi.CertificateKind:
+			crt := &cmapi.Certificate{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, crt); err != nil {
+				return nil, fmt.Errorf("%s: failed to decode Certificate: %w", obj.Source, err)
+			}
+			crts = append(crts, certificate{object: obj, crt: crt})
+		case cmapi.IssuerKind, cmapi.ClusterIssuerKind:
+			iss := &cmapi.Issuer{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, iss); err != nil {
+				return nil, fmt.Errorf("%s: failed to decode %s: %w", obj.Source, obj.GetKind(), err)
+			}
+			ns := iss.Namespace
+			if obj.GetKind() == cmapi.ClusterIssuerKind {
+				ns = ""
+			}
+			issuers[issuerKey{kind: obj.GetKind(), namespace: ns, name: iss.Name}] = &iss.Spec
+		}
+	}
+
+	secrets := map[string][]string{}
+	for _, c := range crts {
+		l.checkRenewBefore(c)
+		l.checkRotationPolicy(c)
+		l.checkWildcardHTTP01(c, issuers)
+
+		key := c.crt.Namespace + "/" + c.crt.Spec.SecretName
+		secrets[key] = append(secrets[key], c.crt.Name)
+	}
+	for _, c := range crts {
+		owners := secrets[c.crt.Namespace+"/"+c.crt.Spec.SecretName]
+		if len(owners) > 1 {
+			l.report(c.object, RuleSecretNameCollision, "Secret %q is also used by Certificate(s) %s", c.crt.Spec.SecretName, strings.Join(others(owners, c.crt.Name), ", "))
+		}
+	}
+
+	sort.SliceStable(l.findings, func(i, j int) bool {
+		a, b := l.findings[i], l.findings[j]
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return l.findings, nil
+}
+
+func (l *linter) checkRenewBefore(c certificate) {
+	if c.crt.Spec.RenewBefore == nil {
+		return
+	}
+
+	duration := cmapi.DefaultCertificateDuration
+	if c.crt.Spec.Duration != nil {
+		duration = c.crt.Spec.Duration.Duration
+	}
+	renewBefore := c.crt.Spec.RenewBefore.Duration
+
+	switch {
+	case renewBefore >= duration:
+		l.report(c.object, RuleRenewBefore, "renewBefore (%s) is not shorter than duration (%s), cert-manager ignores it and renews after 2/3 of the duration", renewBefore, duration)
+	case duration-renewBefore < duration/10:
+		l.report(c.object, RuleRenewBefore, "renewBefore (%s) is close to duration (%s), the certificate is renewed only %s after issuance", renewBefore, duration, (duration - renewBefore).Round(time.Second))
+	}
+}
+
+func (l *linter) checkRotationPolicy(c certificate) {
+	if c.crt.Spec.PrivateKey == nil || c.crt.Spec.PrivateKey.RotationPolicy == "" {
+		l.report(c.object, RuleRotationPolicy, "spec.privateKey.rotationPolicy is not set, set it to %s to generate a new private key on every renewal", cmapi.RotationPolicyAlways)
+	}
+}
+
+func (l *linter) checkWildcardHTTP01(c certificate, issuers map[issuerKey]*cmapi.IssuerSpec) {
+	var wildcards []string
+	for _, name := range c.crt.Spec.DNSNames {
+		if strings.HasPrefix(name, "*.") {
+			wildcards = append(wildcards, name)
+		}
+	}
+	if len(wildcards) == 0 {
+		return
+	}
+
+	ref := c.crt.Spec.IssuerRef
+	if ref.Group != "" && ref.Group != cmapi.SchemeGroupVersion.Group {
+		return
+	}
+	key := issuerKey{kind: cmapi.IssuerKind, namespace: c.crt.Namespace, name: ref.Name}
+	if ref.Kind == cmapi.ClusterIssuerKind {
+		key = issuerKey{kind: cmapi.ClusterIssuerKind, name: ref.Name}
+	}
+
+	// Issuers that are not part of the linted manifests cannot be checked.
+	spec, ok := issuers[key]
+	if !ok || spec.ACME == nil {
+		return
+	}
+	for _, solver := range spec.ACME.Solvers {
+		if solver.DNS01 != nil {
+			return
+		}
+	}
+	l.report(c.object, RuleWildcardHTTP01, "wildcard name(s) %s can only be solved with DNS01, but %s %q has no DNS01 solver", strings.Join(wildcards, ", "), key.kind, ref.Name)
+}
+
+func others(names []string, name string) []string {
+	var out []string
+	for _, n := range names {
+		if n != name {
+			out = append(out, n)
+		}
+	}
+	return out
+}
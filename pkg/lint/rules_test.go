@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const manifests = `
+apiVersion: cert-manager.io/v1
+kind: ClusterIssuer
+metadata:
+  name: letsencrypt
+spec:
+  acme:
+    server: https://acme-v02.api.letsencrypt.org/directory
+    privateKeySecretRef:
+      name: letsencrypt
+    solvers:
+    - http01:
+        ingress: {}
+---
+apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  name: wildcard
+  namespace: ns
+spec:
+  secretName: shared
+  dnsNames: ["*.example.com"]
+  issuerRef:
+    name: letsencrypt
+    kind: ClusterIssuer
+  privateKey:
+    rotationPolicy: Always
+---
+apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  name: short
+  namespace: ns
+spec:
+  secretName: shared
+  dnsNames: ["example.com"]
+  duration: 24h
+  renewBefore: 23h
+  issuerRef:
+    name: ca
+---
+apiVersion: cert-manager.io/v1alpha2
+kind: Certificate
+metadata:
+  name: old
+  namespace: ns
+spec:
+  secretName: old
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: unrelated
+`
+
+func parseManifests(t *testing.T) []object {
+	var objs []object
+	for _, doc := range strings.Split(manifests, "\n---\n") {
+		u := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal([]byte(doc), &u.Object); err != nil {
+			t.Fatal(err)
+		}
+		objs = append(objs, object{Source: "manifests.yaml", Unstructured: u})
+	}
+	return objs
+}
+
+func TestLint(t *testing.T) {
+	tests := map[string]struct {
+		overrides map[string]Severity
+		exp       []string
+	}{
+		"default severities": {
+			exp: []string{
+				"manifests.yaml: Certificate/ns/old: [warning] deprecated-api: cert-manager.io/v1alpha2 is no longer served by cert-manager, use 'cmctl convert' to upgrade to cert-manager.io/v1",
+				"manifests.yaml: Certificate/ns/short: [warning] renew-before: renewBefore (23h0m0s) is close to duration (24h0m0s), the certificate is renewed only 1h0m0s after issuance",
+				"manifests.yaml: Certificate/ns/short: [warning] missing-rotation-policy: spec.privateKey.rotationPolicy is not set, set it to Always to generate a new private key on every renewal",
+				"manifests.yaml: Certificate/ns/short: [error] secret-name-collision: Secret \"shared\" is also used by Certificate(s) wildcard",
+				"manifests.yaml: Certificate/ns/wildcard: [error] wildcard-http01: wildcard name(s) *.example.com can only be solved with DNS01, but ClusterIssuer \"letsencrypt\" has no DNS01 solver",
+				"manifests.yaml: Certificate/ns/wildcard: [error] secret-name-collision: Secret \"shared\" is also used by Certificate(s) short",
+			},
+		},
+		"rules can be disabled or changed": {
+			overrides: map[string]Severity{
+				RuleDeprecatedAPI:       SeverityOff,
+				RuleRenewBefore:         SeverityOff,
+				RuleRotationPolicy:      SeverityOff,
+				RuleSecretNameCollision: SeverityOff,
+				RuleWildcardHTTP01:      SeverityInfo,
+			},
+			exp: []string{
+				"manifests.yaml: Certificate/ns/wildcard: [info] wildcard-http01: wildcard name(s) *.example.com can only be solved with DNS01, but ClusterIssuer \"letsencrypt\" has no DNS01 solver",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			findings, err := newLinter(test.overrides).lint(parseManifests(t))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, f := range findings {
+				got = append(got, f.String())
+			}
+			if !reflect.DeepEqual(got, test.exp) {
+				t.Errorf("unexpected findings,\nexp=%q\ngot=%q", test.exp, got)
+			}
+		})
+	}
+}
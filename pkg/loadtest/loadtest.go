@@ -0,0 +1,312 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Benchmark cert-manager by creating synthetic Certificates and measuring how long it takes
+for them to become Ready.
+
+Certificates are created at the given rate against an existing issuer, which should be a
+test issuer such as a SelfSigned or CA issuer. All Certificates and their Secrets are labelled
+with '` + runLabel + `' and are deleted after the test, unless --keep is set.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Create 5000 Certificates at 50 per second using the Issuer 'selfsigned' in namespace 'loadtest'
+{{.BuildName}} x loadtest --certificates 5000 --issuer selfsigned --rate 50/s --namespace loadtest
+
+# Use a ClusterIssuer and keep the Certificates after the test
+{{.BuildName}} x loadtest --certificates 100 --issuer ca --issuer-kind ClusterIssuer --keep`)))
+)
+
+// runLabel is set on all resources created by a load test, with the ID of the
+// run as value.
+const runLabel = "cmctl.cert-manager.io/loadtest"
+
+// Options is a struct to support loadtest command
+type Options struct {
+	Certificates int
+	IssuerName   string
+	IssuerKind   string
+	IssuerGroup  string
+	// Rate is the rate at which Certificates are created, e.g. 50/s.
+	Rate string
+	// Timeout is the maximum time to wait for all Certificates to become
+	// Ready after the last one was created.
+	Timeout time.Duration
+	// Keep skips the deletion of the created resources.
+	Keep bool
+
+	interval time.Duration
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		Certificates: 100,
+		IssuerKind:   cmapi.IssuerKind,
+		Rate:         "10/s",
+		Timeout:      10 * time.Minute,
+		IOStreams:    ioStreams,
+	}
+}
+
+// NewCmdLoadTest returns a cobra command for loadtest
+func NewCmdLoadTest(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "loadtest",
+		Short:   "Benchmark cert-manager by issuing synthetic Certificates",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate(args)))
+			cmcmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+
+	cmd.Flags().IntVar(&o.Certificates, "certificates", o.Certificates, "Number of Certificates to create")
+	cmd.Flags().StringVar(&o.IssuerName, "issuer", o.IssuerName, "Name of the issuer used for the Certificates")
+	cmd.Flags().StringVar(&o.IssuerKind, "issuer-kind", o.IssuerKind, "Kind of the issuer used for the Certificates, e.g. Issuer (iss) or ClusterIssuer (ciss)")
+	cmd.Flags().StringVar(&o.IssuerGroup, "issuer-group", o.IssuerGroup, "API group of the issuer used for the Certificates")
+	cmd.Flags().StringVar(&o.Rate, "rate", o.Rate, "Rate at which Certificates are created, e.g. 50/s or 600/m")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", o.Timeout, "Maximum time to wait for the Certificates to become Ready after the last one was created")
+	cmd.Flags().BoolVar(&o.Keep, "keep", o.Keep, "If true, do not delete the created Certificates and Secrets after the test")
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("loadtest does not accept arguments")
+	}
+	if o.Certificates < 1 {
+		return errors.New("--certificates must be at least 1")
+	}
+	o.IssuerKind = cmcmdutil.NormalizeIssuerKind(o.IssuerKind, o.IssuerGroup)
+	if len(o.IssuerName) == 0 {
+		return errors.New("the issuer has to be provided with --issuer")
+	}
+	if o.Timeout <= 0 {
+		return errors.New("--timeout must be greater than zero")
+	}
+
+	var err error
+	o.interval, err = parseRate(o.Rate)
+	return err
+}
+
+// Run executes loadtest command
+func (o *Options) Run(ctx context.Context) error {
+	runID := rand.String(5)
+	selector := runLabel + "=" + runID
+	certificates := o.CMClient.CertmanagerV1().Certificates(o.Namespace)
+
+	// Start watching before the first Certificate is created, so that no
+	// Ready condition is missed.
+	list, err := certificates.List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return fmt.Errorf("error when listing Certificate resources: %w", err)
+	}
+	w, err := watchtools.NewRetryWatcher(list.ResourceVersion, &cache.ListWatch{
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.LabelSelector = selector
+			return certificates.Watch(ctx, options)
+		},
+	})
+	if err != nil {
+		return err
+	}
+	defer w.Stop()
+
+	if !o.Keep {
+		defer o.cleanup(selector)
+	}
+
+	var (
+		mu      sync.Mutex
+		created = map[string]time.Time{}
+		ready   = map[string]time.Duration{}
+	)
+
+	fmt.Fprintf(o.Out, "Starting load test %s: creating %d Certificates in namespace %s using %s %q\n", runID, o.Certificates, o.Namespace, o.IssuerKind, o.IssuerName)
+
+	createErr := make(chan error, 1)
+	go func() {
+		defer close(createErr)
+		ticker := time.NewTicker(o.interval)
+		defer ticker.Stop()
+
+		for i := 0; i < o.Certificates; i++ {
+			crt := o.certificate(runID, i)
+			mu.Lock()
+			created[crt.Name] = time.Now()
+			mu.Unlock()
+
+			if _, err := certificates.Create(ctx, crt, metav1.CreateOptions{}); err != nil {
+				createErr <- fmt.Errorf("failed to create Certificate %s: %w", crt.Name, err)
+				return
+			}
+
+			if (i+1)%100 == 0 {
+				fmt.Fprintf(o.ErrOut, "Created %d/%d Certificates\n", i+1, o.Certificates)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	var (
+		deadline <-chan time.Time
+		start    = time.Now()
+	)
+	for done := false; !done; {
+		select {
+		case <-ctx.Done():
+			done = true
+		case err, ok := <-createErr:
+			if err != nil {
+				return err
+			}
+			if !ok {
+				// All Certificates have been created.
+				createErr = nil
+				deadline = time.After(o.Timeout)
+			}
+		case <-deadline:
+			fmt.Fprintf(o.ErrOut, "Timed out waiting for Certificates to become Ready\n")
+			done = true
+		case e, ok := <-w.ResultChan():
+			if !ok {
+				return errors.New("watch of Certificate resources was closed unexpectedly")
+			}
+			crt, isCrt := e.Object.(*cmapi.Certificate)
+			if !isCrt || !apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+				Type:   cmapi.CertificateConditionReady,
+				Status: cmmeta.ConditionTrue,
+			}) {
+				continue
+			}
+
+			mu.Lock()
+			if _, seen := ready[crt.Name]; !seen {
+				if t, ok := created[crt.Name]; ok {
+					ready[crt.Name] = time.Since(t)
+				}
+			}
+			done = len(ready) == o.Certificates
+			mu.Unlock()
+		}
+	}
+	elapsed := time.Since(start)
+
+	mu.Lock()
+	durations := make([]time.Duration, 0, len(ready))
+	for _, d := range ready {
+		durations = append(durations, d)
+	}
+	numCreated := len(created)
+	mu.Unlock()
+
+	return o.printReport(newStats(durations), numCreated, elapsed)
+}
+
+func (o *Options) certificate(runID string, i int) *cmapi.Certificate {
+	name := fmt.Sprintf("loadtest-%s-%d", runID, i)
+	labels := map[string]string{runLabel: runID}
+
+	return &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: o.Namespace,
+			Labels:    labels,
+		},
+		Spec: cmapi.CertificateSpec{
+			SecretName:     name,
+			DNSNames:       []string{name + ".loadtest.cmctl.local"},
+			IssuerRef:      cmmeta.ObjectReference{Name: o.IssuerName, Kind: o.IssuerKind, Group: o.IssuerGroup},
+			SecretTemplate: &cmapi.CertificateSecretTemplate{Labels: labels},
+		},
+	}
+}
+
+func (o *Options) printReport(s stats, created int, elapsed time.Duration) error {
+	fmt.Fprintf(o.Out, "\n%d/%d Certificates became Ready in %s\n\n", s.Count, created, elapsed.Round(time.Millisecond))
+	if s.Count == 0 {
+		return nil
+	}
+
+	w := util.NewTabWriter(o.Out)
+	fmt.Fprint(w, "TIME TO READY\tMIN\tMEAN\tP50\tP90\tP99\tMAX\n")
+	fmt.Fprintf(w, "\t%s\t%s\t%s\t%s\t%s\t%s\n",
+		s.Min.Round(time.Millisecond), s.Mean.Round(time.Millisecond), s.P50.Round(time.Millisecond),
+		s.P90.Round(time.Millisecond), s.P99.Round(time.Millisecond), s.Max.Round(time.Millisecond))
+	return w.Flush()
+}
+
+// cleanup deletes all Certificates and Secrets of a run. It uses a new
+// context, so that resources are also deleted when the test was interrupted.
+func (o *Options) cleanup(selector string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	fmt.Fprintf(o.ErrOut, "Deleting Certificates and Secrets with label %s\n", selector)
+	listOpts := metav1.ListOptions{LabelSelector: selector}
+	if err := o.CMClient.CertmanagerV1().Certificates(o.Namespace).DeleteCollection(ctx, metav1.DeleteOptions{}, listOpts); err != nil {
+		fmt.Fprintf(o.ErrOut, "Failed to delete Certificates: %v\n", err)
+	}
+	if err := o.KubeClient.CoreV1().Secrets(o.Namespace).DeleteCollection(ctx, metav1.DeleteOptions{}, listOpts); err != nil {
+		fmt.Fprintf(o.ErrOut, "Failed to delete Secrets: %v\n", err)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadtest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRate(t *testing.T) {
+	tests := map[string]struct {
+		in     string
+		exp    time.Duration
+		expErr bool
+	}{
+		"per second":   {in: "50/s", exp: 20 * time.Millisecond},
+		"per minute":   {in: "120/m", exp: 500 * time.Millisecond},
+		"without unit": {in: "4", exp: 250 * time.Millisecond},
+		"fractional":   {in: "0.5/s", exp: 2 * time.Second},
+		"unknown unit": {in: "50/d", expErr: true},
+		"zero":         {in: "0/s", expErr: true},
+		"not a number": {in: "fast", expErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseRate(test.in)
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error, expErr=%v got=%v", test.expErr, err)
+			}
+			if got != test.exp {
+				t.Errorf("unexpected interval, exp=%v got=%v", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestNewStats(t *testing.T) {
+	var durations []time.Duration
+	for i := 100; i >= 1; i-- {
+		durations = append(durations, time.Duration(i)*time.Second)
+	}
+
+	got := newStats(durations)
+	exp := stats{
+		Count: 100,
+		Min:   time.Second,
+		Mean:  50500 * time.Millisecond,
+		P50:   50 * time.Second,
+		P90:   90 * time.Second,
+		P99:   99 * time.Second,
+		Max:   100 * time.Second,
+	}
+	if got != exp {
+		t.Errorf("unexpected stats,\nexp=%+v\ngot=%+v", exp, got)
+	}
+
+	if empty := newStats(nil); empty != (stats{}) {
+		t.Errorf("expected empty stats, got %+v", empty)
+	}
+}
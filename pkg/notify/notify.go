@@ -0,0 +1,243 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Send a report of the Certificates that expire soon or are not ready to a webhook.
+
+The report is sent as a JSON document by default, or as a Slack message with
+--format slack, which also works for other chat tools that accept Slack compatible
+incoming webhooks. The command is meant to run as a nightly CronJob, to get notified
+about expiring Certificates without setting up a full monitoring stack.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Send a report of the Certificates in all namespaces that expire within 14 days to Slack
+{{.BuildName}} notify --all-namespaces --within 14d --format slack --webhook-url https://hooks.slack.com/services/...
+
+# Only send a report if a Certificate needs attention
+{{.BuildName}} notify --all-namespaces --skip-empty --webhook-url https://example.com/hooks/cert-manager
+
+# Print the report instead of sending it
+{{.BuildName}} notify --all-namespaces --print`)))
+)
+
+// Options is a struct to support notify command
+type Options struct {
+	Within        string
+	WebhookURL    string
+	Format        string
+	LabelSelector string
+	FieldSelector string
+	AllNamespaces bool
+	// SkipEmpty skips sending the report if no Certificate needs attention.
+	SkipEmpty bool
+	// Print prints the payload instead of sending it.
+	Print   bool
+	Timeout time.Duration
+
+	within        time.Duration
+	fieldSelector *cmcmdutil.CertificateFieldSelector
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		Within:    "14d",
+		Format:    "json",
+		Timeout:   30 * time.Second,
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdNotify returns a cobra command for sending expiry reports to webhooks
+func NewCmdNotify(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "notify",
+		Short:   "Send a Certificate expiry and health report to a webhook",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate(args)))
+			cmcmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.Within, "within", o.Within, "Report Certificates that expire within this duration, e.g. 14d or 72h.")
+	cmd.Flags().StringVar(&o.WebhookURL, "webhook-url", o.WebhookURL, "URL the report is sent to with an HTTP POST request.")
+	cmd.Flags().StringVar(&o.Format, "format", o.Format, "Format of the report, one of 'json' or 'slack'.")
+	cmd.Flags().StringVarP(&o.LabelSelector, "selector", "l", o.LabelSelector, "Selector (label query) to filter on, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
+	cmd.Flags().StringVar(&o.FieldSelector, "field-selector", o.FieldSelector, cmcmdutil.FieldSelectorUsage)
+	factory.AddAllNamespacesFlag(cmd, &o.AllNamespaces, "report Certificates")
+	cmd.Flags().BoolVar(&o.SkipEmpty, "skip-empty", o.SkipEmpty, "If true, do not send the report if no Certificate expires soon or is not ready.")
+	cmd.Flags().BoolVar(&o.Print, "print", o.Print, "If true, print the report instead of sending it.")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", o.Timeout, "Time to wait for the webhook to respond.")
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("notify does not accept arguments")
+	}
+
+	var err error
+	o.within, err = cmcmdutil.ParseDays(o.Within)
+	if err != nil {
+		return fmt.Errorf("invalid --within: %w", err)
+	}
+	if o.within <= 0 {
+		return errors.New("--within must be positive")
+	}
+
+	switch o.Format {
+	case "json", "slack":
+	default:
+		return errors.New(`--format must be 'json' or 'slack'`)
+	}
+
+	o.fieldSelector, err = cmcmdutil.ParseCertificateFieldSelector(o.FieldSelector)
+	if err != nil {
+		return err
+	}
+
+	if o.Print {
+		return nil
+	}
+	if cmcmdutil.Offline() {
+		return errors.New("the report cannot be sent with --offline, use --print to print it instead")
+	}
+	if o.WebhookURL == "" {
+		return errors.New("--webhook-url is required unless --print is set")
+	}
+	u, err := url.Parse(o.WebhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("--webhook-url must be an http or https URL, got %q", o.WebhookURL)
+	}
+	return nil
+}
+
+// Run executes notify command
+func (o *Options) Run(ctx context.Context) error {
+	ns := o.NamespaceOrAll(o.AllNamespaces)
+
+	opts := metav1.ListOptions{
+		LabelSelector: o.LabelSelector,
+		FieldSelector: o.fieldSelector.ServerSide(),
+	}
+	cmcmdutil.LogListFilters(ctx, "Certificates", ns, opts, o.fieldSelector.ClientSide())
+
+	var crts []cmapi.Certificate
+	err := cmcmdutil.ListPages(ctx, opts, func(opts metav1.ListOptions) (string, error) {
+		list, err := o.CMClient.CertmanagerV1().Certificates(ns).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		crts = append(crts, o.fieldSelector.Filter(list.Items)...)
+		return list.Continue, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error when listing Certificate resources: %w", err)
+	}
+
+	report := buildReport(crts, time.Now(), o.within, o.Within)
+	if o.SkipEmpty && report.empty() {
+		fmt.Fprintln(o.ErrOut, "No Certificates need attention, not sending a report")
+		return nil
+	}
+
+	var payload []byte
+	if o.Format == "slack" {
+		payload, err = report.slackMessage()
+	} else {
+		payload, err = json.Marshal(report)
+	}
+	if err != nil {
+		return err
+	}
+
+	if o.Print {
+		fmt.Fprintln(o.Out, string(payload))
+		return nil
+	}
+
+	if err := o.send(ctx, payload); err != nil {
+		return err
+	}
+	fmt.Fprintf(o.ErrOut, "Sent report: %d expiring and %d not ready of %d Certificates\n", len(report.Expiring), len(report.NotReady), report.Total)
+	return nil
+}
+
+func (o *Options) send(ctx context.Context, payload []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		// The URL may contain a secret token, e.g. for Slack webhooks, so only
+		// the underlying error is reported.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return cmcmdutil.NetworkError(fmt.Errorf("failed to send the report to the webhook: %w", err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook responded with %s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// maxSlackEntries limits the number of Certificates listed per section of a
+// Slack message.
+const maxSlackEntries = 20
+
+// Report is the expiry and health report of the Certificates in a cluster.
+type Report struct {
+	GeneratedAt time.Time `json:"generatedAt"`
+	Within      string    `json:"within"`
+	Total       int       `json:"total"`
+	// Expiring are the Certificates that expire within the report window,
+	// including those that have already expired.
+	Expiring []Entry `json:"expiring"`
+	// NotReady are the Certificates whose Ready condition is not True.
+	NotReady []Entry `json:"notReady"`
+}
+
+// Entry is a Certificate in a Report.
+type Entry struct {
+	Namespace string     `json:"namespace"`
+	Name      string     `json:"name"`
+	NotAfter  *time.Time `json:"notAfter,omitempty"`
+	Reason    string     `json:"reason,omitempty"`
+	Message   string     `json:"message,omitempty"`
+}
+
+// buildReport returns the report of crts at now for the given window.
+func buildReport(crts []cmapi.Certificate, now time.Time, within time.Duration, withinFlag string) Report {
+	r := Report{
+		GeneratedAt: now.UTC(),
+		Within:      withinFlag,
+		Total:       len(crts),
+		Expiring:    []Entry{},
+		NotReady:    []Entry{},
+	}
+
+	for _, crt := range crts {
+		entry := Entry{Namespace: crt.Namespace, Name: crt.Name}
+		if crt.Status.NotAfter != nil {
+			notAfter := crt.Status.NotAfter.Time.UTC()
+			entry.NotAfter = &notAfter
+		}
+
+		if entry.NotAfter != nil && entry.NotAfter.Before(now.Add(within)) {
+			r.Expiring = append(r.Expiring, entry)
+		}
+
+		// #nosec G601 -- False positive. See https://github.com/golang/go/discussions/56010
+		cond := apiutil.GetCertificateCondition(&crt, cmapi.CertificateConditionReady)
+		if cond == nil || cond.Status != cmmeta.ConditionTrue {
+			if cond != nil {
+				entry.Reason, entry.Message = cond.Reason, cond.Message
+			}
+			r.NotReady = append(r.NotReady, entry)
+		}
+	}
+
+	sort.SliceStable(r.Expiring, func(i, j int) bool {
+		return r.Expiring[i].NotAfter.Before(*r.Expiring[j].NotAfter)
+	})
+	return r
+}
+
+// empty returns true if no Certificate needs attention.
+func (r Report) empty() bool {
+	return len(r.Expiring) == 0 && len(r.NotReady) == 0
+}
+
+// slackMessage formats the report as the payload of a Slack incoming webhook.
+func (r Report) slackMessage() ([]byte, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*cert-manager report*: %d of %d Certificates expire within %s, %d not ready\n",
+		len(r.Expiring), r.Total, r.Within, len(r.NotReady))
+
+	if len(r.Expiring) > 0 {
+		b.WriteString("\n*Expiring*\n")
+		for i, e := range r.Expiring {
+			if i == maxSlackEntries {
+				fmt.Fprintf(&b, "• … and %d more\n", len(r.Expiring)-i)
+				break
+			}
+			if e.NotAfter.Before(r.GeneratedAt) {
+				fmt.Fprintf(&b, "• `%s/%s` expired %s ago (%s)\n", e.Namespace, e.Name,
+					duration.HumanDuration(r.GeneratedAt.Sub(*e.NotAfter)), e.NotAfter.Format(time.RFC3339))
+			} else {
+				fmt.Fprintf(&b, "• `%s/%s` expires in %s (%s)\n", e.Namespace, e.Name,
+					duration.HumanDuration(e.NotAfter.Sub(r.GeneratedAt)), e.NotAfter.Format(time.RFC3339))
+			}
+		}
+	}
+
+	if len(r.NotReady) > 0 {
+		b.WriteString("\n*Not ready*\n")
+		for i, e := range r.NotReady {
+			if i == maxSlackEntries {
+				fmt.Fprintf(&b, "• … and %d more\n", len(r.NotReady)-i)
+				break
+			}
+			switch {
+			case e.Message != "":
+				fmt.Fprintf(&b, "• `%s/%s`: %s: %s\n", e.Namespace, e.Name, e.Reason, e.Message)
+			case e.Reason != "":
+				fmt.Fprintf(&b, "• `%s/%s`: %s\n", e.Namespace, e.Name, e.Reason)
+			default:
+				fmt.Fprintf(&b, "• `%s/%s`\n", e.Namespace, e.Name)
+			}
+		}
+	}
+
+	return json.Marshal(map[string]string{"text": b.String()})
+}
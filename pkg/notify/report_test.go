@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+var now = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func certificate(name string, notAfter time.Time, ready cmmeta.ConditionStatus, reason string) cmapi.Certificate {
+	crt := cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name},
+		Status: cmapi.CertificateStatus{
+			Conditions: []cmapi.CertificateCondition{{Type: cmapi.CertificateConditionReady, Status: ready, Reason: reason}},
+		},
+	}
+	if !notAfter.IsZero() {
+		t := metav1.NewTime(notAfter)
+		crt.Status.NotAfter = &t
+	}
+	return crt
+}
+
+func names(entries []Entry) []string {
+	var out []string
+	for _, e := range entries {
+		out = append(out, e.Name)
+	}
+	return out
+}
+
+func TestBuildReport(t *testing.T) {
+	day := 24 * time.Hour
+	crts := []cmapi.Certificate{
+		certificate("healthy", now.Add(60*day), cmmeta.ConditionTrue, "Ready"),
+		certificate("soon", now.Add(10*day), cmmeta.ConditionTrue, "Ready"),
+		certificate("sooner", now.Add(2*day), cmmeta.ConditionTrue, "Ready"),
+		certificate("expired", now.Add(-day), cmmeta.ConditionFalse, "Expired"),
+		certificate("pending", time.Time{}, cmmeta.ConditionFalse, "DoesNotExist"),
+	}
+
+	r := buildReport(crts, now, 14*day, "14d")
+
+	if r.Total != 5 {
+		t.Errorf("expected a total of 5, got %d", r.Total)
+	}
+	if exp := []string{"expired", "sooner", "soon"}; !reflect.DeepEqual(names(r.Expiring), exp) {
+		t.Errorf("unexpected expiring Certificates, exp=%v got=%v", exp, names(r.Expiring))
+	}
+	if exp := []string{"expired", "pending"}; !reflect.DeepEqual(names(r.NotReady), exp) {
+		t.Errorf("unexpected not ready Certificates, exp=%v got=%v", exp, names(r.NotReady))
+	}
+	if r.empty() {
+		t.Error("expected the report not to be empty")
+	}
+	if !buildReport(crts[:1], now, 14*day, "14d").empty() {
+		t.Error("expected the report of a healthy Certificate to be empty")
+	}
+}
+
+func TestSlackMessage(t *testing.T) {
+	day := 24 * time.Hour
+	r := buildReport([]cmapi.Certificate{
+		certificate("soon", now.Add(3*day), cmmeta.ConditionTrue, "Ready"),
+		certificate("expired", now.Add(-2*day), cmmeta.ConditionFalse, "Expired"),
+	}, now, 14*day, "14d")
+
+	payload, err := r.slackMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msg map[string]string
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		t.Fatal(err)
+	}
+
+	exp := "*cert-manager report*: 2 of 2 Certificates expire within 14d, 1 not ready\n" +
+		"\n*Expiring*\n" +
+		"• `ns/expired` expired 2d ago (2024-02-28T00:00:00Z)\n" +
+		"• `ns/soon` expires in 3d (2024-03-04T00:00:00Z)\n" +
+		"\n*Not ready*\n" +
+		"• `ns/expired`: Expired\n"
+	if msg["text"] != exp {
+		t.Errorf("unexpected message:\n%q\nexpected:\n%q", msg["text"], exp)
+	}
+}
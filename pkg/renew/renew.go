@@ -20,12 +20,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"slices"
 
 	"github.com/spf13/cobra"
-	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
-	"k8s.io/client-go/kubernetes"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
@@ -34,13 +35,23 @@ import (
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
 	"github.com/cert-manager/cmctl/v2/pkg/build"
 	"github.com/cert-manager/cmctl/v2/pkg/factory"
 )
 
 var (
 	long = templates.LongDesc(i18n.T(`
-Mark cert-manager Certificate resources for manual renewal.`))
+Mark cert-manager Certificate resources for manual renewal.
+
+When Certificates are selected with --all, a label selector or a field selector, the
+number of Certificates is shown and confirmation is required unless --yes or --dry-run
+is given. When more than one name is read from stdin, stdin cannot answer the prompt,
+so --yes is required instead.
+
+Label selectors and field selectors on metadata.name and metadata.namespace are applied
+by the API server, other field selectors after listing. Run with -v=2 to see which filters
+were applied where.`))
 
 	example = templates.Examples(i18n.T(build.WithTemplate(`
 # Renew the Certificates named 'my-app' and 'vault' in the current context namespace.
@@ -50,14 +61,40 @@ Mark cert-manager Certificate resources for manual renewal.`))
 {{.BuildName}} renew --namespace kube-system --all
 
 # Renew all Certificates in all namespaces, provided those Certificates have the label 'app=my-service'
-{{.BuildName}} renew --all-namespaces -l app=my-service`)))
+{{.BuildName}} renew --all-namespaces -l app=my-service
+
+# Renew all Certificates issued by the Issuer 'letsencrypt' that are not Ready
+{{.BuildName}} renew --field-selector spec.issuerRef.name=letsencrypt,status.conditions[Ready]=False
+
+# Renew the Certificates whose names are read from stdin, as 'namespace/name' or 'name' per line
+kubectl get certificates -l app=my-service -o name | {{.BuildName}} renew - --yes
+
+# Show which Certificates in the 'kube-system' namespace would be renewed
+{{.BuildName}} renew --namespace kube-system --all --dry-run
+
+# Renew all Certificates in all namespaces without asking for confirmation
+{{.BuildName}} renew --all-namespaces --all --yes
+
+# Renew all Certificates in all namespaces, 50 at a time
+{{.BuildName}} renew --all-namespaces --all --yes --concurrency 50`)))
 )
 
 // Options is a struct to support renew command
 type Options struct {
 	LabelSelector string
+	FieldSelector string
 	All           bool
 	AllNamespaces bool
+	// Yes skips the confirmation prompt when Certificates are selected with
+	// --all, a label selector or a field selector, and confirms renewing
+	// several Certificates read from stdin.
+	Yes bool
+	// DryRun prints the Certificates that would be renewed, without renewing
+	// them or only submitting dry-run requests.
+	DryRun cmcmdutil.DryRun
+	// Concurrency is the maximum number of Certificates that are renewed at
+	// the same time.
+	Concurrency int
 
 	genericclioptions.IOStreams
 	*factory.Factory
@@ -80,14 +117,18 @@ func NewCmdRenew(ctx context.Context, ioStreams genericclioptions.IOStreams) *co
 		Example:           example,
 		ValidArgsFunction: factory.ValidArgsListCertificates(ctx, &o.Factory),
 		Run: func(cmd *cobra.Command, args []string) {
-			cmdutil.CheckErr(o.Validate(cmd, args))
-			cmdutil.CheckErr(o.Run(ctx, args))
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate(cmd, args)))
+			cmcmdutil.CheckErr(o.Run(ctx, args))
 		},
 	}
 
 	cmd.Flags().StringVarP(&o.LabelSelector, "selector", "l", o.LabelSelector, "Selector (label query) to filter on, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
-	cmd.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", o.AllNamespaces, "If present, mark Certificates across namespaces for manual renewal. Namespace in current context is ignored even if specified with --namespace.")
+	cmd.Flags().StringVar(&o.FieldSelector, "field-selector", o.FieldSelector, cmcmdutil.FieldSelectorUsage)
+	factory.AddAllNamespacesFlag(cmd, &o.AllNamespaces, "mark Certificates for manual renewal")
 	cmd.Flags().BoolVar(&o.All, "all", o.All, "Renew all Certificates in the given Namespace, or all namespaces with --all-namespaces enabled.")
+	cmcmdutil.AddConfirmFlags(cmd.Flags(), &o.Yes)
+	cmcmdutil.AddDryRunFlag(cmd.Flags(), &o.DryRun)
+	cmcmdutil.AddConcurrencyFlag(cmd.Flags(), &o.Concurrency)
 
 	o.Factory = factory.New(ctx, cmd)
 
@@ -104,6 +145,18 @@ func (o *Options) Validate(cmd *cobra.Command, args []string) error {
 		return errors.New("cannot specify label selectors in conjunction with --all flag")
 	}
 
+	if len(o.FieldSelector) > 0 && len(args) > 0 {
+		return errors.New("cannot specify Certificate names in conjunction with field selectors")
+	}
+
+	if len(o.FieldSelector) > 0 && o.All {
+		return errors.New("cannot specify field selectors in conjunction with --all flag")
+	}
+
+	if _, err := cmcmdutil.ParseCertificateFieldSelector(o.FieldSelector); err != nil {
+		return err
+	}
+
 	if o.All && len(args) > 0 {
 		return errors.New("cannot specify Certificate names in conjunction with --all flag")
 	}
@@ -112,11 +165,19 @@ func (o *Options) Validate(cmd *cobra.Command, args []string) error {
 		return errors.New("cannot specify --namespace flag in conjunction with --all flag")
 	}
 
-	if !o.All && len(args) == 0 {
-		return errors.New("please supply one or more Certificate resource names or use the --all flag to renew all Certificate resources")
+	if !o.All && len(o.LabelSelector) == 0 && len(o.FieldSelector) == 0 && len(args) == 0 {
+		return errors.New("please supply one or more Certificate resource names, a selector or use the --all flag to renew all Certificate resources")
 	}
 
-	return nil
+	if len(args) > 1 && slices.Contains(args, cmcmdutil.StdinArg) {
+		return errors.New("cannot specify Certificate names in conjunction with '-' to read them from stdin")
+	}
+
+	if o.AllNamespaces && cmcmdutil.IsStdinArg(args) {
+		return errors.New("cannot specify --all-namespaces flag when reading Certificate names from stdin")
+	}
+
+	return cmcmdutil.ValidateConcurrency(o.Concurrency)
 }
 
 // Complete takes the command arguments and factory and infers any remaining options.
@@ -142,74 +203,147 @@ func (o *Options) Complete(f cmdutil.Factory) error {
 
 // Run executes renew command
 func (o *Options) Run(ctx context.Context, args []string) error {
+	var (
+		crts []cmapi.Certificate
+		err  error
+	)
+	if cmcmdutil.IsStdinArg(args) {
+		crts, err = o.certificatesFromStdin(ctx)
+	} else {
+		crts, err = o.certificates(ctx, args)
+	}
+	if err != nil {
+		return err
+	}
 
-	nss := []corev1.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: o.Namespace}}}
-
-	if o.AllNamespaces {
-		kubeClient, err := kubernetes.NewForConfig(o.RESTConfig)
-		if err != nil {
-			return err
+	if len(crts) == 0 {
+		if o.AllNamespaces {
+			fmt.Fprintln(o.ErrOut, "No Certificates found")
+		} else {
+			fmt.Fprintf(o.ErrOut, "No Certificates found in %s namespace.\n", o.Namespace)
 		}
 
-		nsList, err := kubeClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
-		if err != nil {
+		return nil
+	}
+
+	if !o.DryRun.Enabled() && (o.All || len(o.LabelSelector) > 0 || len(o.FieldSelector) > 0) {
+		prompt := fmt.Sprintf("%d Certificate(s) will be marked for renewal.", len(crts))
+		if err := cmcmdutil.Confirm(o.In, o.ErrOut, o.Yes, prompt); err != nil {
 			return err
 		}
+	}
 
-		nss = nsList.Items
+	var progress *cmcmdutil.Progress
+	if len(crts) > 1 {
+		progress = cmcmdutil.NewProgress(o.ErrOut, "Renewing Certificates", len(crts))
+		defer progress.Finish()
 	}
 
+	bulk := cmcmdutil.Bulk{Concurrency: o.Concurrency, Out: o.Out, Progress: progress}
+	return cmcmdutil.ForEach(ctx, bulk, crts, certificateName, func(ctx context.Context, out io.Writer, crt cmapi.Certificate) error {
+		return o.renewCertificate(ctx, out, &crt)
+	})
+}
+
+func certificateName(crt cmapi.Certificate) string {
+	return crt.Namespace + "/" + crt.Name
+}
+
+// certificates returns the Certificates selected by args, --all or the label
+// and field selectors in the selected namespaces.
+func (o *Options) certificates(ctx context.Context, args []string) ([]cmapi.Certificate, error) {
+	fieldSelector, err := cmcmdutil.ParseCertificateFieldSelector(o.FieldSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	ns := o.NamespaceOrAll(o.AllNamespaces)
+
 	var crts []cmapi.Certificate
-	for _, ns := range nss {
-		switch {
-		case o.All, len(o.LabelSelector) > 0:
-			crtsList, err := o.CMClient.CertmanagerV1().Certificates(ns.Name).List(ctx, metav1.ListOptions{
-				LabelSelector: o.LabelSelector,
-			})
+	listCertificates := func(opts metav1.ListOptions, clientSide string) error {
+		cmcmdutil.LogListFilters(ctx, "Certificates", ns, opts, clientSide)
+		return cmcmdutil.ListPages(ctx, opts, func(opts metav1.ListOptions) (string, error) {
+			list, err := o.CMClient.CertmanagerV1().Certificates(ns).List(ctx, opts)
 			if err != nil {
-				return err
+				return "", err
 			}
+			crts = append(crts, fieldSelector.Filter(list.Items)...)
+			return list.Continue, nil
+		})
+	}
 
-			crts = append(crts, crtsList.Items...)
+	switch {
+	case o.All, len(o.LabelSelector) > 0, len(o.FieldSelector) > 0:
+		err := listCertificates(metav1.ListOptions{
+			LabelSelector: o.LabelSelector,
+			FieldSelector: fieldSelector.ServerSide(),
+		}, fieldSelector.ClientSide())
+		if err != nil {
+			return nil, err
+		}
 
-		default:
-			for _, crtName := range args {
-				crt, err := o.CMClient.CertmanagerV1().Certificates(ns.Name).Get(ctx, crtName, metav1.GetOptions{})
-				if err != nil {
-					return err
-				}
+	case o.AllNamespaces:
+		// The API server finds the Certificates with the given name in all
+		// namespaces, instead of looking for them in every namespace.
+		for _, crtName := range args {
+			found := len(crts)
+			err := listCertificates(metav1.ListOptions{
+				FieldSelector: fields.OneTermEqualSelector("metadata.name", crtName).String(),
+			}, "")
+			if err != nil {
+				return nil, err
+			}
+			if len(crts) == found {
+				return nil, cmcmdutil.NotFoundError(fmt.Errorf("Certificate %q not found in any namespace", crtName))
+			}
+		}
 
-				crts = append(crts, *crt)
+	default:
+		for _, crtName := range args {
+			crt, err := o.CMClient.CertmanagerV1().Certificates(o.Namespace).Get(ctx, crtName, metav1.GetOptions{})
+			if err != nil {
+				return nil, err
 			}
+
+			crts = append(crts, *crt)
 		}
 	}
 
-	if len(crts) == 0 {
-		if o.AllNamespaces {
-			fmt.Fprintln(o.ErrOut, "No Certificates found")
-		} else {
-			fmt.Fprintf(o.ErrOut, "No Certificates found in %s namespace.\n", o.Namespace)
-		}
+	return crts, nil
+}
 
-		return nil
+// certificatesFromStdin returns the Certificates named on stdin.
+func (o *Options) certificatesFromStdin(ctx context.Context) ([]cmapi.Certificate, error) {
+	names, err := cmcmdutil.ReadNames(o.In, o.Namespace)
+	if err != nil {
+		return nil, err
 	}
-
-	for _, crt := range crts {
-		// #nosec G601 -- False positive. See https://github.com/golang/go/discussions/56010
-		if err := o.renewCertificate(ctx, &crt); err != nil {
-			return err
+	if !o.DryRun.Enabled() {
+		what := fmt.Sprintf("renew %d Certificate(s)", len(names))
+		if err := cmcmdutil.ConfirmStdinNames(o.Yes, len(names), what); err != nil {
+			return nil, err
 		}
 	}
 
-	return nil
+	crts := make([]cmapi.Certificate, 0, len(names))
+	for _, name := range names {
+		crt, err := o.CMClient.CertmanagerV1().Certificates(name.Namespace).Get(ctx, name.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		crts = append(crts, *crt)
+	}
+	return crts, nil
 }
 
-func (o *Options) renewCertificate(ctx context.Context, crt *cmapi.Certificate) error {
+func (o *Options) renewCertificate(ctx context.Context, out io.Writer, crt *cmapi.Certificate) error {
 	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionTrue, "ManuallyTriggered", "Certificate re-issuance manually triggered")
-	_, err := o.CMClient.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to trigger issuance of Certificate %s/%s: %v", crt.Namespace, crt.Name, err)
+	if !o.DryRun.SkipRequest() {
+		_, err := o.CMClient.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{DryRun: o.DryRun.Options()})
+		if err != nil {
+			return fmt.Errorf("failed to trigger issuance of Certificate %s/%s: %v", crt.Namespace, crt.Name, err)
+		}
 	}
-	fmt.Fprintf(o.Out, "Manually triggered issuance of Certificate %s/%s\n", crt.Namespace, crt.Name)
+	fmt.Fprintf(out, "Manually triggered issuance of Certificate %s/%s%s\n", crt.Namespace, crt.Name, o.DryRun.Suffix())
 	return nil
 }
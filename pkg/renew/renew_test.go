@@ -87,6 +87,63 @@ func TestValidate(t *testing.T) {
 			},
 			expErr: false,
 		},
+		"If there are arguments, as well as field selector, error": {
+			options: &Options{
+				FieldSelector: "spec.issuerRef.name=foo",
+			},
+			args:   []string{"abc"},
+			expErr: true,
+		},
+		"If there are all certificates selected, as well as field selector, error": {
+			options: &Options{
+				FieldSelector: "spec.issuerRef.name=foo",
+				All:           true,
+			},
+			expErr: true,
+		},
+		"If the field selector contains an unsupported field, error": {
+			options: &Options{
+				FieldSelector: "spec.dnsNames=example.com",
+			},
+			expErr: true,
+		},
+		"If only a field selector is specified, don't error": {
+			options: &Options{
+				FieldSelector: "status.conditions[Ready]=False",
+			},
+			expErr: false,
+		},
+		"If label and field selectors are specified, don't error": {
+			options: &Options{
+				LabelSelector: "foo=bar",
+				FieldSelector: "status.conditions[Ready]=False",
+			},
+			expErr: false,
+		},
+		"If '-' is specified, don't error": {
+			options: &Options{},
+			args:    []string{"-"},
+			expErr:  false,
+		},
+		"If '-' is specified with other arguments, error": {
+			options: &Options{},
+			args:    []string{"-", "abc"},
+			expErr:  true,
+		},
+		"If '-' is specified with --all-namespaces, error": {
+			options: &Options{
+				AllNamespaces: true,
+			},
+			args:   []string{"-"},
+			expErr: true,
+		},
+		"If --concurrency is negative, error": {
+			options: &Options{
+				All:         true,
+				Concurrency: -1,
+			},
+			expErr: true,
+		},
 		"If --namespace specified with multiple arguments, don't error": {
 			options: &Options{},
 			args:    []string{"bar", "abc"},
@@ -0,0 +1,241 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Force a new private key to be generated for a cert-manager Certificate and trigger its issuance.
+
+Unlike 'renew', which re-uses the existing private key when the Certificate's
+spec.privateKey.rotationPolicy is 'Never' (the default), this command first sets
+the rotationPolicy to 'Always'. This change to the Certificate spec is persistent.
+
+With --wait, the command blocks until the Secret contains a new private key and a
+certificate for it.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Rotate the private key of the Certificate 'my-crt' in namespace 'my-namespace'
+{{.BuildName}} rotate-key certificate my-crt --namespace my-namespace
+
+# Rotate the private key and wait up to 10 minutes for the new key to be issued
+{{.BuildName}} rotate-key certificate my-crt --wait --timeout 10m`)))
+)
+
+// Options is a struct to support rotate-key certificate command
+type Options struct {
+	// Wait blocks until the Secret contains the rotated private key.
+	Wait bool
+	// Timeout is the maximum time to wait for the rotated private key.
+	Timeout time.Duration
+	// DryRun prints the changes that would be made, without making them or
+	// only submitting dry-run requests.
+	DryRun cmcmdutil.DryRun
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		Timeout:   5 * time.Minute,
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdRotateKeyCert returns a cobra command for rotate-key certificate
+func NewCmdRotateKeyCert(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:               "certificate",
+		Aliases:           cmcmdutil.KindAliases(cmapi.CertificateKind, "certificate"),
+		Short:             "Force a new private key on the next issuance of a Certificate",
+		Long:              long,
+		Example:           example,
+		ValidArgsFunction: factory.ValidArgsListCertificates(ctx, &o.Factory),
+		Run: func(cmd *cobra.Command, args []string) {
+			args, err := o.PickCertificate(ctx, o.IOStreams, factory.PickToChange, args)
+			cmcmdutil.CheckErr(err)
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate(args)))
+			cmcmdutil.CheckErr(o.Run(ctx, args))
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.Wait, "wait", o.Wait, "If true, wait until the Secret contains the rotated private key.")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", o.Timeout, "Maximum time to wait for the rotated private key, must include unit, e.g. 5m")
+	cmcmdutil.AddDryRunFlag(cmd.Flags(), &o.DryRun)
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) < 1 {
+		return errors.New("the name of the Certificate has to be provided as argument")
+	}
+	if len(args) > 1 {
+		return errors.New("only one argument can be passed in: the name of the Certificate")
+	}
+	if o.Wait && o.Timeout <= 0 {
+		return errors.New("--timeout must be greater than zero")
+	}
+	if o.Wait && o.DryRun.Enabled() {
+		return errors.New("cannot specify --wait in conjunction with --dry-run")
+	}
+	return nil
+}
+
+// Run executes rotate-key certificate command
+func (o *Options) Run(ctx context.Context, args []string) error {
+	crt, err := o.CMClient.CertmanagerV1().Certificates(o.Namespace).Get(ctx, args[0], metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error when getting Certificate resource: %w", err)
+	}
+
+	// Remember the current key, so that its replacement can be detected.
+	var oldKey crypto.PublicKey
+	secret, err := o.KubeClient.CoreV1().Secrets(crt.Namespace).Get(ctx, crt.Spec.SecretName, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		// The Certificate has not been issued yet.
+	case err != nil:
+		return fmt.Errorf("error when getting Secret %q: %v", crt.Spec.SecretName, err)
+	default:
+		oldKey = publicKey(secret)
+	}
+
+	if crt.Spec.PrivateKey == nil || crt.Spec.PrivateKey.RotationPolicy != cmapi.RotationPolicyAlways {
+		crt, err = o.setRotationPolicyAlways(ctx, crt)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(o.Out, "Set private key rotationPolicy of Certificate %s/%s to %s%s\n", crt.Namespace, crt.Name, cmapi.RotationPolicyAlways, o.DryRun.Suffix())
+	}
+
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionTrue, "ManuallyTriggered", "Private key rotation manually triggered")
+	if !o.DryRun.SkipRequest() {
+		if _, err := o.CMClient.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{DryRun: o.DryRun.Options()}); err != nil {
+			return fmt.Errorf("failed to trigger issuance of Certificate %s/%s: %v", crt.Namespace, crt.Name, err)
+		}
+	}
+	fmt.Fprintf(o.Out, "Manually triggered private key rotation of Certificate %s/%s%s\n", crt.Namespace, crt.Name, o.DryRun.Suffix())
+
+	if !o.Wait {
+		return nil
+	}
+
+	fmt.Fprintf(o.Out, "Waiting for Secret %s/%s to contain the rotated private key...\n", crt.Namespace, crt.Spec.SecretName)
+	progress := cmcmdutil.NewProgress(o.ErrOut, "Waiting for the rotated private key", 0)
+	_, err = o.WaitForSecret(ctx, crt.Namespace, crt.Spec.SecretName, o.Timeout, func(secret *corev1.Secret) bool {
+		return keyRotated(oldKey, secret)
+	})
+	progress.Finish()
+	if err != nil {
+		return fmt.Errorf("error while waiting for the rotated private key: %w", err)
+	}
+
+	fmt.Fprintf(o.Out, "Secret %s/%s contains the rotated private key\n", crt.Namespace, crt.Spec.SecretName)
+	return nil
+}
+
+func (o *Options) setRotationPolicyAlways(ctx context.Context, crt *cmapi.Certificate) (*cmapi.Certificate, error) {
+	if o.DryRun.SkipRequest() {
+		crt = crt.DeepCopy()
+		if crt.Spec.PrivateKey == nil {
+			crt.Spec.PrivateKey = &cmapi.CertificatePrivateKey{}
+		}
+		crt.Spec.PrivateKey.RotationPolicy = cmapi.RotationPolicyAlways
+		return crt, nil
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"privateKey": map[string]interface{}{
+				"rotationPolicy": cmapi.RotationPolicyAlways,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	crt, err = o.CMClient.CertmanagerV1().Certificates(crt.Namespace).Patch(ctx, crt.Name, types.MergePatchType, patch, metav1.PatchOptions{DryRun: o.DryRun.Options()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set private key rotationPolicy: %v", err)
+	}
+	return crt, nil
+}
+
+// publicKey returns the public key of the private key stored in secret, or
+// nil if it cannot be decoded.
+func publicKey(secret *corev1.Secret) crypto.PublicKey {
+	key, err := pki.DecodePrivateKeyBytes(secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil
+	}
+	return key.Public()
+}
+
+// keyRotated returns true if secret contains a private key different from
+// oldKey, together with a certificate issued for it.
+func keyRotated(oldKey crypto.PublicKey, secret *corev1.Secret) bool {
+	newKey := publicKey(secret)
+	if newKey == nil {
+		return false
+	}
+	if oldKey != nil {
+		if equal, err := pki.PublicKeysEqual(oldKey, newKey); err != nil || equal {
+			return false
+		}
+	}
+
+	cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return false
+	}
+	matches, err := pki.PublicKeyMatchesCertificate(newKey, cert)
+	return err == nil && matches
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"crypto"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func issue(t *testing.T) (crypto.PublicKey, []byte, []byte) {
+	key, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template, err := pki.GenerateTemplate(&cmapi.Certificate{
+		Spec: cmapi.CertificateSpec{CommonName: "example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM, _, err := pki.SignCertificate(template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM, err := pki.EncodeECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key.Public(), certPEM, keyPEM
+}
+
+func TestKeyRotated(t *testing.T) {
+	oldPub, oldCert, oldKey := issue(t)
+	_, newCert, newKey := issue(t)
+
+	secret := func(cert, key []byte) *corev1.Secret {
+		return &corev1.Secret{Data: map[string][]byte{
+			corev1.TLSCertKey:       cert,
+			corev1.TLSPrivateKeyKey: key,
+		}}
+	}
+
+	tests := map[string]struct {
+		oldKey crypto.PublicKey
+		secret *corev1.Secret
+		exp    bool
+	}{
+		"same private key": {
+			oldKey: oldPub,
+			secret: secret(oldCert, oldKey),
+		},
+		"new private key but certificate not issued yet": {
+			oldKey: oldPub,
+			secret: secret(oldCert, newKey),
+		},
+		"new private key and certificate": {
+			oldKey: oldPub,
+			secret: secret(newCert, newKey),
+			exp:    true,
+		},
+		"first issuance": {
+			secret: secret(newCert, newKey),
+			exp:    true,
+		},
+		"empty Secret": {
+			oldKey: oldPub,
+			secret: &corev1.Secret{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := keyRotated(test.oldKey, test.secret); got != test.exp {
+				t.Errorf("unexpected result, exp=%t got=%t", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		args    []string
+		wait    bool
+		timeout time.Duration
+		expErr  bool
+	}{
+		"a single Certificate":   {args: []string{"my-crt"}, timeout: time.Minute},
+		"no Certificate":         {expErr: true},
+		"multiple Certificates":  {args: []string{"a", "b"}, expErr: true},
+		"wait without a timeout": {args: []string{"my-crt"}, wait: true, expErr: true},
+		"wait with a timeout":    {args: []string{"my-crt"}, wait: true, timeout: time.Minute},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := &Options{Wait: test.wait, Timeout: test.timeout}
+			err := o.Validate(test.args)
+			if (err != nil) != test.expErr {
+				t.Errorf("unexpected error, expErr=%v got=%v", test.expErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rotatekey
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/cert-manager/cmctl/v2/pkg/rotatekey/certificate"
+)
+
+func NewCmdRotateKey(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	cmds := &cobra.Command{
+		Use:   "rotate-key",
+		Short: "Force the rotation of private keys",
+		Long:  `Force cert-manager to generate a new private key on the next issuance of a resource, e.g. a Certificate`,
+	}
+
+	cmds.AddCommand(certificate.NewCmdRotateKeyCert(ctx, ioStreams))
+
+	return cmds
+}
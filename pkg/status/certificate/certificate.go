@@ -20,7 +20,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"time"
 
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
@@ -28,7 +27,6 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/reference"
-	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
 
@@ -37,8 +35,10 @@ import (
 	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	"github.com/cert-manager/cert-manager/pkg/ctl"
 	"github.com/cert-manager/cert-manager/pkg/util/predicate"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
 	"github.com/cert-manager/cmctl/v2/pkg/build"
 	"github.com/cert-manager/cmctl/v2/pkg/factory"
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
 )
 
 var (
@@ -48,11 +48,17 @@ Get details about the current status of a cert-manager Certificate resource, inc
 	example = templates.Examples(i18n.T(build.WithTemplate(`
 # Query status of Certificate with name 'my-crt' in namespace 'my-namespace'
 {{.BuildName}} status certificate my-crt --namespace my-namespace
+
+# Print timestamps relative to now, e.g. '3h ago' or 'in 29d'
+{{.BuildName}} status certificate my-crt --time-format relative
 `)))
 )
 
 // Options is a struct to support status certificate command
 type Options struct {
+	// TimeFormat is the format in which timestamps are printed
+	TimeFormat util.TimeFormat
+
 	genericclioptions.IOStreams
 	*factory.Factory
 }
@@ -80,7 +86,8 @@ type Data struct {
 // NewOptions returns initialized Options
 func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
 	return &Options{
-		IOStreams: ioStreams,
+		TimeFormat: util.TimeFormatRFC3339,
+		IOStreams:  ioStreams,
 	}
 }
 
@@ -90,16 +97,20 @@ func NewCmdStatusCert(ctx context.Context, ioStreams genericclioptions.IOStreams
 
 	cmd := &cobra.Command{
 		Use:               "certificate",
+		Aliases:           cmcmdutil.KindAliases(cmapi.CertificateKind, "certificate"),
 		Short:             "Get details about the current status of a cert-manager Certificate resource",
 		Long:              long,
 		Example:           example,
 		ValidArgsFunction: factory.ValidArgsListCertificates(ctx, &o.Factory),
 		Run: func(cmd *cobra.Command, args []string) {
-			cmdutil.CheckErr(o.Validate(args))
-			cmdutil.CheckErr(o.Run(ctx, args))
+			args, err := o.PickCertificate(ctx, o.IOStreams, factory.PickToRead, args)
+			cmcmdutil.CheckErr(err)
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate(args)))
+			cmcmdutil.CheckErr(o.Run(ctx, args))
 		},
 	}
 
+	o.TimeFormat.AddFlag(cmd.Flags())
 	o.Factory = factory.New(ctx, cmd)
 
 	return cmd
@@ -124,9 +135,12 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 	}
 
 	// Build status of Certificate with data gathered
-	status := StatusFromResources(data)
+	status := StatusFromResources(data).withTimeFormat(o.TimeFormat)
 
-	fmt.Fprintf(o.Out, status.String())
+	out, closePager := cmcmdutil.StartPager(o.Out)
+	defer closePager()
+
+	fmt.Fprintf(out, status.String())
 
 	return nil
 }
@@ -143,7 +157,7 @@ func (o *Options) GetResources(ctx context.Context, crtName string) (*Data, erro
 
 	crt, err := o.CMClient.CertmanagerV1().Certificates(o.Namespace).Get(ctx, crtName, metav1.GetOptions{})
 	if err != nil {
-		return nil, fmt.Errorf("error when getting Certificate resource: %v", err)
+		return nil, fmt.Errorf("error when getting Certificate resource: %w", err)
 	}
 
 	crtRef, err := reference.GetReference(ctl.Scheme, crt)
@@ -278,13 +292,13 @@ func formatStringSlice(strings []string) string {
 	return result
 }
 
-// formatTimeString returns the time as a string
+// formatTimeString returns the time as a string in the given format
 // If nil, return "<none>"
-func formatTimeString(t *metav1.Time) string {
+func formatTimeString(t *metav1.Time, format util.TimeFormat) string {
 	if t == nil {
 		return "<none>"
 	}
-	return t.Time.Format(time.RFC3339)
+	return format.Format(t.Time)
 }
 
 // findMatchingCR tries to find a CertificateRequest that is owned by crt and has the correct revision annotated from reqs.
@@ -292,11 +306,6 @@ func formatTimeString(t *metav1.Time) string {
 // If one found returns the CR
 // If multiple found or error occurs when listing CRs, returns error
 func findMatchingCR(cmClient cmclient.Interface, ctx context.Context, crt *cmapi.Certificate) (*cmapi.CertificateRequest, error) {
-	reqs, err := cmClient.CertmanagerV1().CertificateRequests(crt.Namespace).List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("error when listing CertificateRequest resources: %w", err)
-	}
-
 	possibleMatches := []*cmapi.CertificateRequest{}
 
 	// CertificateRequest revisions begin from 1.
@@ -306,12 +315,27 @@ func findMatchingCR(cmClient cmclient.Interface, ctx context.Context, crt *cmapi
 	if crt.Status.Revision != nil {
 		nextRevision = *crt.Status.Revision + 1
 	}
-	for _, req := range reqs.Items {
-		// #nosec G601 -- False positive. See https://github.com/golang/go/discussions/56010
-		if predicate.CertificateRequestRevision(nextRevision)(&req) &&
-			predicate.ResourceOwnedBy(crt)(&req) {
-			possibleMatches = append(possibleMatches, req.DeepCopy())
+
+	// The API server cannot select resources by owner or annotation, so the
+	// CertificateRequests are matched client-side.
+	cmcmdutil.LogListFilters(ctx, "CertificateRequests", crt.Namespace, metav1.ListOptions{},
+		fmt.Sprintf("owned by Certificate %s with revision %d", crt.Name, nextRevision))
+	err := cmcmdutil.ListPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+		reqs, err := cmClient.CertmanagerV1().CertificateRequests(crt.Namespace).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		for _, req := range reqs.Items {
+			// #nosec G601 -- False positive. See https://github.com/golang/go/discussions/56010
+			if predicate.CertificateRequestRevision(nextRevision)(&req) &&
+				predicate.ResourceOwnedBy(crt)(&req) {
+				possibleMatches = append(possibleMatches, req.DeepCopy())
+			}
 		}
+		return reqs.Continue, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error when listing CertificateRequest resources: %w", err)
 	}
 
 	if len(possibleMatches) < 1 {
@@ -328,17 +352,23 @@ func findMatchingCR(cmClient cmclient.Interface, ctx context.Context, crt *cmapi
 // If one found returns the Order
 // If multiple found or error occurs when listing Orders, returns error
 func findMatchingOrder(cmClient cmclient.Interface, ctx context.Context, req *cmapi.CertificateRequest) (*cmacme.Order, error) {
-	orders, err := cmClient.AcmeV1().Orders(req.Namespace).List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, err
-	}
-
 	possibleMatches := []*cmacme.Order{}
-	for _, order := range orders.Items {
-		// #nosec G601 -- False positive. See https://github.com/golang/go/discussions/56010
-		if predicate.ResourceOwnedBy(req)(&order) {
-			possibleMatches = append(possibleMatches, order.DeepCopy())
+	cmcmdutil.LogListFilters(ctx, "Orders", req.Namespace, metav1.ListOptions{}, "owned by CertificateRequest "+req.Name)
+	err := cmcmdutil.ListPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+		orders, err := cmClient.AcmeV1().Orders(req.Namespace).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		for _, order := range orders.Items {
+			// #nosec G601 -- False positive. See https://github.com/golang/go/discussions/56010
+			if predicate.ResourceOwnedBy(req)(&order) {
+				possibleMatches = append(possibleMatches, order.DeepCopy())
+			}
 		}
+		return orders.Continue, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if len(possibleMatches) < 1 {
@@ -379,17 +409,23 @@ func getGenericIssuer(cmClient cmclient.Interface, ctx context.Context, crt *cma
 // findMatchingChallenges tries to find Challenges that are owned by order.
 // If none found returns empty slice.
 func findMatchingChallenges(cmClient cmclient.Interface, ctx context.Context, order *cmacme.Order) ([]*cmacme.Challenge, error) {
-	challenges, err := cmClient.AcmeV1().Challenges(order.Namespace).List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, err
-	}
-
 	possibleMatches := []*cmacme.Challenge{}
-	for _, challenge := range challenges.Items {
-		// #nosec G601 -- False positive. See https://github.com/golang/go/discussions/56010
-		if predicate.ResourceOwnedBy(order)(&challenge) {
-			possibleMatches = append(possibleMatches, challenge.DeepCopy())
+	cmcmdutil.LogListFilters(ctx, "Challenges", order.Namespace, metav1.ListOptions{}, "owned by Order "+order.Name)
+	err := cmcmdutil.ListPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+		challenges, err := cmClient.AcmeV1().Challenges(order.Namespace).List(ctx, opts)
+		if err != nil {
+			return "", err
 		}
+		for _, challenge := range challenges.Items {
+			// #nosec G601 -- False positive. See https://github.com/golang/go/discussions/56010
+			if predicate.ResourceOwnedBy(order)(&challenge) {
+				possibleMatches = append(possibleMatches, challenge.DeepCopy())
+			}
+		}
+		return challenges.Continue, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return possibleMatches, nil
@@ -63,6 +63,9 @@ type CertificateStatus struct {
 	OrderStatus *OrderStatus
 
 	ChallengeStatusList *ChallengeStatusList
+
+	// timeFormat is the format in which timestamps are printed
+	timeFormat util.TimeFormat
 }
 
 type IssuerStatus struct {
@@ -137,6 +140,9 @@ type OrderStatus struct {
 	Authorizations []cmacme.ACMEAuthorization
 	// Time the Order failed
 	FailureTime *metav1.Time
+
+	// timeFormat is the format in which timestamps are printed
+	timeFormat util.TimeFormat
 }
 
 type ChallengeStatusList struct {
@@ -167,6 +173,14 @@ func newCertificateStatusFromCert(crt *cmapi.Certificate) *CertificateStatus {
 		NotBefore: crt.Status.NotBefore, NotAfter: crt.Status.NotAfter, RenewalTime: crt.Status.RenewalTime}
 }
 
+func (status *CertificateStatus) withTimeFormat(format util.TimeFormat) *CertificateStatus {
+	status.timeFormat = format
+	if status.OrderStatus != nil {
+		status.OrderStatus.timeFormat = format
+	}
+	return status
+}
+
 func (status *CertificateStatus) withEvents(events *v1.EventList) *CertificateStatus {
 	status.Events = events
 	return status
@@ -278,7 +292,7 @@ func (status *CertificateStatus) String() string {
 	output := ""
 	output += fmt.Sprintf("Name: %s\n", status.Name)
 	output += fmt.Sprintf("Namespace: %s\n", status.Namespace)
-	output += fmt.Sprintf("Created at: %s\n", formatTimeString(&status.CreationTime))
+	output += fmt.Sprintf("Created at: %s\n", formatTimeString(&status.CreationTime, status.timeFormat))
 
 	// Output one line about each type of Condition that is set.
 	// Certificate can have multiple Conditions of different types set, e.g. "Ready" or "Issuing"
@@ -298,9 +312,9 @@ func (status *CertificateStatus) String() string {
 	output += status.IssuerStatus.String()
 	output += status.SecretStatus.String()
 
-	output += fmt.Sprintf("Not Before: %s\n", formatTimeString(status.NotBefore))
-	output += fmt.Sprintf("Not After: %s\n", formatTimeString(status.NotAfter))
-	output += fmt.Sprintf("Renewal Time: %s\n", formatTimeString(status.RenewalTime))
+	output += fmt.Sprintf("Not Before: %s\n", formatTimeString(status.NotBefore, status.timeFormat))
+	output += fmt.Sprintf("Not After: %s\n", formatTimeString(status.NotAfter, status.timeFormat))
+	output += fmt.Sprintf("Renewal Time: %s\n", formatTimeString(status.RenewalTime, status.timeFormat))
 
 	output += status.CRStatus.String()
 
@@ -472,7 +486,7 @@ func (orderStatus *OrderStatus) String() string {
 		output += authString
 	}
 	if orderStatus.FailureTime != nil {
-		output += fmt.Sprintf("  FailureTime: %s\n", formatTimeString(orderStatus.FailureTime))
+		output += fmt.Sprintf("  FailureTime: %s\n", formatTimeString(orderStatus.FailureTime, orderStatus.timeFormat))
 	}
 
 	return output
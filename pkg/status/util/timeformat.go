@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// TimeFormat is the format in which commands print timestamps. It implements
+// pflag.Value so that invalid formats are rejected when flags are parsed.
+type TimeFormat string
+
+const (
+	// TimeFormatRelative prints the time relative to now, e.g. '3h ago' or
+	// 'in 29d'.
+	TimeFormatRelative TimeFormat = "relative"
+	// TimeFormatRFC3339 prints e.g. '2024-01-02T15:04:05Z'.
+	TimeFormatRFC3339 TimeFormat = "rfc3339"
+	// TimeFormatRFC1123 prints e.g. 'Tue, 02 Jan 2024 15:04:05 UTC'.
+	TimeFormatRFC1123 TimeFormat = "rfc1123"
+)
+
+var timeFormats = []TimeFormat{TimeFormatRelative, TimeFormatRFC3339, TimeFormatRFC1123}
+
+// AddFlag registers the --time-format flag, defaulting to the current value.
+func (f *TimeFormat) AddFlag(flags *pflag.FlagSet) {
+	flags.Var(f, "time-format", "Format of printed timestamps. One of 'relative', 'rfc3339' or 'rfc1123'.")
+}
+
+// String implements pflag.Value.
+func (f *TimeFormat) String() string {
+	return string(*f)
+}
+
+// Set implements pflag.Value.
+func (f *TimeFormat) Set(s string) error {
+	for _, format := range timeFormats {
+		if strings.EqualFold(s, string(format)) {
+			*f = format
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of 'relative', 'rfc3339' or 'rfc1123'")
+}
+
+// Type implements pflag.Value.
+func (f *TimeFormat) Type() string {
+	return "string"
+}
+
+// Format returns t in the format f. The zero value formats as RFC3339.
+func (f TimeFormat) Format(t time.Time) string {
+	return f.format(t, time.Now())
+}
+
+func (f TimeFormat) format(t, now time.Time) string {
+	switch f {
+	case TimeFormatRelative:
+		if d := now.Sub(t); d >= 0 {
+			return duration.HumanDuration(d) + " ago"
+		}
+		return "in " + duration.HumanDuration(t.Sub(now))
+	case TimeFormatRFC1123:
+		return t.Format(time.RFC1123)
+	default:
+		return t.Format(time.RFC3339)
+	}
+}
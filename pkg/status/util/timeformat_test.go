@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeFormat(t *testing.T) {
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	tests := map[string]struct {
+		format TimeFormat
+		t      time.Time
+		exp    string
+	}{
+		"relative past":   {format: TimeFormatRelative, t: now.Add(-3 * time.Hour), exp: "3h ago"},
+		"relative future": {format: TimeFormatRelative, t: now.Add(29 * 24 * time.Hour), exp: "in 29d"},
+		"rfc3339":         {format: TimeFormatRFC3339, t: now, exp: "2024-01-02T15:04:05Z"},
+		"rfc1123":         {format: TimeFormatRFC1123, t: now, exp: "Tue, 02 Jan 2024 15:04:05 UTC"},
+		"default":         {t: now, exp: "2024-01-02T15:04:05Z"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := test.format.format(test.t, now); got != test.exp {
+				t.Errorf("unexpected time, exp=%q got=%q", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestTimeFormatSet(t *testing.T) {
+	var f TimeFormat
+	if err := f.Set("RFC1123"); err != nil || f != TimeFormatRFC1123 {
+		t.Errorf("unexpected result, format=%q err=%v", f, err)
+	}
+	if err := f.Set("unix"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
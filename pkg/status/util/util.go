@@ -29,6 +29,8 @@ import (
 	"k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/kubectl/pkg/describe"
 	"k8s.io/kubectl/pkg/util/event"
+
+	"github.com/cert-manager/cmctl/v2/internal/printers"
 )
 
 // This file contains functions that are copied from "k8s.io/kubectl/pkg/describe".
@@ -70,7 +72,7 @@ func DescribeEvents(el *corev1.EventList, w describe.PrefixWriter, baseLevel int
 
 // NewTabWriter returns a *tabwriter.Writer with fixed parameters to be used in the status command
 func NewTabWriter(writer io.Writer) *tabwriter.Writer {
-	return tabwriter.NewWriter(writer, 0, 8, 2, ' ', 0)
+	return printers.NewTabWriter(writer)
 }
 
 // formatEventSource formats EventSource as a comma separated string excluding Host when empty
@@ -25,9 +25,12 @@ import (
 	"github.com/spf13/cobra"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
 	"helm.sh/helm/v3/pkg/storage/driver"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/yaml"
 
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
 	"github.com/cert-manager/cmctl/v2/pkg/build"
 	"github.com/cert-manager/cmctl/v2/pkg/install/helm"
 )
@@ -40,6 +43,7 @@ type options struct {
 	disableHooks bool
 	dryRun       bool
 	wait         bool
+	yes          bool
 
 	genericclioptions.IOStreams
 }
@@ -63,6 +67,8 @@ or
 	$ {{.BuildName}} x uninstall --dry-run
 or
 	$ {{.BuildName}} x uninstall --no-hooks
+or
+	$ {{.BuildName}} x uninstall --yes
 `)
 }
 
@@ -104,6 +110,7 @@ func NewCmd(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.C
 	cmd.Flags().BoolVar(&options.wait, "wait", true, "if set, will wait until all the resources are deleted before returning. It will wait for as long as --timeout")
 	cmd.Flags().BoolVar(&options.dryRun, "dry-run", false, "simulate uninstall and output manifests to be deleted")
 	cmd.Flags().BoolVar(&options.disableHooks, "no-hooks", false, "prevent hooks from running during uninstallation (pre- and post-uninstall hooks)")
+	cmcmdutil.AddConfirmFlags(cmd.Flags(), &options.yes)
 
 	return cmd
 }
@@ -112,14 +119,55 @@ func NewCmd(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.C
 // this is not configurable to avoid uninstalling non-cert-manager releases.
 func run(ctx context.Context, o options) (*release.UninstallReleaseResponse, error) {
 	o.client.DisableHooks = o.disableHooks
-	o.client.DryRun = o.dryRun
 	o.client.Wait = o.wait
 
+	if !o.dryRun && !o.yes {
+		// Simulate the uninstall first to show what is going to be deleted.
+		o.client.DryRun = true
+		res, err := o.client.Run(o.releaseName)
+		if err != nil {
+			return nil, o.releaseError(err)
+		}
+
+		if err := cmcmdutil.Confirm(o.In, o.ErrOut, o.yes, confirmPrompt(o.releaseName, res.Release.Manifest)); err != nil {
+			return nil, err
+		}
+	}
+
+	o.client.DryRun = o.dryRun
 	res, err := o.client.Run(o.releaseName)
+	if err != nil {
+		return nil, o.releaseError(err)
+	}
+
+	return res, nil
+}
 
+func (o options) releaseError(err error) error {
 	if errors.Is(err, driver.ErrReleaseNotFound) {
-		return nil, fmt.Errorf("release %v not found in namespace %v, did you use the correct namespace?", releaseName, o.settings.Namespace())
+		return cmcmdutil.NotFoundError(fmt.Errorf("release %v not found in namespace %v, did you use the correct namespace?", o.releaseName, o.settings.Namespace()))
 	}
+	return err
+}
 
-	return res, nil
+// confirmPrompt describes the resources of the release that are about to be
+// deleted, calling out CRDs because deleting them also deletes all
+// cert-manager custom resources in the cluster.
+func confirmPrompt(releaseName, manifest string) string {
+	var total, crds int
+	for _, doc := range releaseutil.SplitManifests(manifest) {
+		var head releaseutil.SimpleHead
+		if err := yaml.Unmarshal([]byte(doc), &head); err != nil || head.Kind == "" {
+			continue
+		}
+		total++
+		if head.Kind == "CustomResourceDefinition" {
+			crds++
+		}
+	}
+
+	if crds > 0 {
+		return fmt.Sprintf("Release %q with %d resource(s) will be uninstalled, including %d CustomResourceDefinition(s) and ALL of their custom resources.", releaseName, total, crds)
+	}
+	return fmt.Sprintf("Release %q with %d resource(s) will be uninstalled.", releaseName, total)
 }
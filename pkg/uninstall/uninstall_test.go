@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package uninstall
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/action"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+	"github.com/cert-manager/cmctl/v2/pkg/install/helm"
+)
+
+func TestConfirmPrompt(t *testing.T) {
+	tests := map[string]struct {
+		manifest string
+		exp      string
+	}{
+		"resources are counted": {
+			manifest: `---
+# Source: cert-manager/templates/serviceaccount.yaml
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: cert-manager
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: cert-manager
+`,
+			exp: `Release "cert-manager" with 2 resource(s) will be uninstalled.`,
+		},
+		"CRDs are called out": {
+			manifest: `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: certificates.cert-manager.io
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: cert-manager
+---
+# Source: cert-manager/templates/empty.yaml
+`,
+			exp: `Release "cert-manager" with 2 resource(s) will be uninstalled, including 1 CustomResourceDefinition(s) and ALL of their custom resources.`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := confirmPrompt("cert-manager", test.manifest); got != test.exp {
+				t.Errorf("unexpected prompt, exp=%q got=%q", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	tests := map[string]struct {
+		releaseName string
+		expCode     int
+	}{
+		"a missing release is reported as not found": {
+			releaseName: "cert-manager",
+			expCode:     cmcmdutil.ExitCodeNotFound,
+		},
+		"other lookup errors are returned": {
+			releaseName: "Not A Valid Release Name",
+			expCode:     cmcmdutil.ExitCodeError,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &action.Configuration{
+				Releases:   storage.Init(driver.NewMemory()),
+				KubeClient: &kubefake.PrintingKubeClient{Out: io.Discard},
+				Log:        t.Logf,
+			}
+
+			res, err := run(context.TODO(), options{
+				settings:    &helm.NormalisedEnvSettings{Factory: &factory.Factory{Namespace: "cert-manager"}},
+				client:      action.NewUninstall(cfg),
+				releaseName: test.releaseName,
+				yes:         true,
+			})
+			if err == nil {
+				t.Fatalf("expected an error, got response %v", res)
+			}
+			if code := cmcmdutil.ExitCodeFor(err); code != test.expCode {
+				t.Errorf("unexpected exit code, exp=%d got=%d (%v)", test.expCode, code, err)
+			}
+		})
+	}
+}
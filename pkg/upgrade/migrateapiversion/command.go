@@ -23,11 +23,11 @@ import (
 	apiextinstall "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/install"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
-	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
 	"github.com/cert-manager/cmctl/v2/pkg/build"
 	"github.com/cert-manager/cmctl/v2/pkg/factory"
 )
@@ -50,6 +50,9 @@ This command must be run with a cluster running cert-manager v1.0 or greater.`))
 # This should only be used if you have manually edited/patched the CRDs already.
 # It will force a read and a write of ALL cert-manager resources unconditionally.
 {{.BuildName}} upgrade migrate-api-version --skip-stored-version-check
+
+# Migrate up to 50 resources at the same time, raising the client rate limits accordingly.
+{{.BuildName}} upgrade migrate-api-version --concurrency 50 --qps 50 --burst 100
 `)))
 )
 
@@ -62,6 +65,7 @@ type Options struct {
 	skipStoredVersionCheck bool
 	qps                    float32
 	burst                  int
+	concurrency            int
 }
 
 // NewOptions returns initialized Options
@@ -81,9 +85,9 @@ func NewCmdMigrate(ctx context.Context, ioStreams genericclioptions.IOStreams) *
 		Long:    long,
 		Example: example,
 		Run: func(cmd *cobra.Command, args []string) {
-			cmdutil.CheckErr(o.Validate(args))
-			cmdutil.CheckErr(o.Complete())
-			cmdutil.CheckErr(o.Run(ctx, args))
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate(args)))
+			cmcmdutil.CheckErr(o.Complete())
+			cmcmdutil.CheckErr(o.Run(ctx, args))
 		},
 	}
 
@@ -92,6 +96,7 @@ func NewCmdMigrate(ctx context.Context, ioStreams genericclioptions.IOStreams) *
 		"Use this mode if you have previously manually modified the 'status.storedVersions' field on CRD resources.")
 	cmd.Flags().Float32Var(&o.qps, "qps", 5, "Indicates the maximum QPS to the apiserver from the client.")
 	cmd.Flags().IntVar(&o.burst, "burst", 10, "Maximum burst value for queries set to the apiserver from the client.")
+	cmcmdutil.AddConcurrencyFlag(cmd.Flags(), &o.concurrency)
 	o.Factory = factory.New(ctx, cmd)
 
 	return cmd
@@ -99,7 +104,7 @@ func NewCmdMigrate(ctx context.Context, ioStreams genericclioptions.IOStreams) *
 
 // Validate validates the provided options
 func (o *Options) Validate(_ []string) error {
-	return nil
+	return cmcmdutil.ValidateConcurrency(o.concurrency)
 }
 
 // Complete takes the command arguments and factory and infers any remaining options.
@@ -126,7 +131,9 @@ func (o *Options) Complete() error {
 
 // Run executes renew command
 func (o *Options) Run(ctx context.Context, args []string) error {
-	_, err := NewMigrator(o.client, o.skipStoredVersionCheck, o.Out, o.ErrOut).Run(ctx, "v1", []string{
+	migrator := NewMigrator(o.client, o.skipStoredVersionCheck, o.Out, o.ErrOut)
+	migrator.Concurrency = o.concurrency
+	_, err := migrator.Run(ctx, "v1", []string{
 		"certificates.cert-manager.io",
 		"certificaterequests.cert-manager.io",
 		"issuers.cert-manager.io",
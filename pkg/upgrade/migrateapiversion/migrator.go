@@ -31,6 +31,8 @@ import (
 
 	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
 )
 
 type Migrator struct {
@@ -44,6 +46,10 @@ type Migrator struct {
 
 	// Writers to write informational & error messages to
 	Out, ErrOut io.Writer
+
+	// Concurrency is the maximum number of resources that are migrated at the
+	// same time. Zero selects cmcmdutil.DefaultConcurrency.
+	Concurrency int
 }
 
 // NewMigrator creates a new migrator with the given API client.
@@ -175,19 +181,25 @@ func (m *Migrator) migrateResourcesForCRD(ctx context.Context, crd *apiext.Custo
 		return err
 	}
 	fmt.Fprintf(m.Out, " %d resources to migrate...\n", len(list.Items))
-	for _, obj := range list.Items {
+	progress := cmcmdutil.NewProgress(m.ErrOut, fmt.Sprintf("Migrating %s objects", crd.Spec.Names.Kind), len(list.Items))
+	defer progress.Finish()
+	bulk := cmcmdutil.Bulk{Concurrency: m.Concurrency, Out: m.Out, Progress: progress}
+	err := cmcmdutil.ForEach(ctx, bulk, list.Items, objectName, func(ctx context.Context, _ io.Writer, obj unstructured.Unstructured) error {
 		// retry on any kind of error to handle cases where e.g. the network connection to the apiserver fails
-		if err := retry.OnError(wait.Backoff{
+		err := retry.OnError(wait.Backoff{
 			Duration: time.Second, // wait 1s between attempts
 			Steps:    3,           // allow up to 3 attempts per object
 		}, func(err error) bool {
 			// Retry on any errors that are not otherwise skipped/ignored
 			return handleUpdateErr(err) != nil
 		}, func() error {
-			return m.Client.Update(ctx, &obj) // #nosec G601 -- False positive. See https://github.com/golang/go/discussions/56010
-		}); handleUpdateErr(err) != nil {
-			return err
-		}
+			return m.Client.Update(ctx, &obj)
+		})
+		return handleUpdateErr(err)
+	})
+	progress.Finish()
+	if err != nil {
+		return err
 	}
 	// add 500ms to the duration to ensure we always round up
 	duration := time.Now().Sub(startTime) + (time.Millisecond * 500)
@@ -195,6 +207,13 @@ func (m *Migrator) migrateResourcesForCRD(ctx context.Context, crd *apiext.Custo
 	return nil
 }
 
+func objectName(obj unstructured.Unstructured) string {
+	if obj.GetNamespace() == "" {
+		return obj.GetName()
+	}
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
+
 // patchCRDStoredVersions will patch the `status.storedVersions` field of all passed in CRDs to be
 // set to an array containing JUST the current storage version.
 // This is only safe to run after a successful migration (i.e. a read/write of all resources of the given CRD type).
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/cert-manager/cmctl/v2/internal/installation"
+)
+
+// Component is a deployed cert-manager component.
+type Component struct {
+	Component string `json:"component"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Version   string `json:"version,omitempty"`
+	Image     string `json:"image"`
+	// ImageDigests are the digests of the images that the Pods of the
+	// component run. Pods that are being rolled out may run different images.
+	ImageDigests []string `json:"imageDigests,omitempty"`
+}
+
+// listComponents returns the cert-manager components that are deployed in any
+// namespace, with the image digests of their Pods. If the Pods of a component
+// cannot be listed, the component is returned without digests, together with
+// the error.
+func listComponents(ctx context.Context, kubeClient kubernetes.Interface) ([]Component, error) {
+	deploys, err := installation.ListDeployments(ctx, kubeClient, metav1.NamespaceAll)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		result  []Component
+		podErrs []error
+	)
+	for _, deploy := range deploys {
+		pods, err := listPods(ctx, kubeClient, deploy)
+		if err != nil {
+			podErrs = append(podErrs, err)
+		}
+		result = append(result, component(deploy, pods))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, errors.Join(podErrs...)
+}
+
+// listPods returns the Pods selected by deploy.
+func listPods(ctx context.Context, kubeClient kubernetes.Interface, deploy installation.Deployment) ([]corev1.Pod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deploy.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector of Deployment %s/%s: %w", deploy.Namespace, deploy.Name, err)
+	}
+	pods, err := kubeClient.CoreV1().Pods(deploy.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("error when listing Pods of Deployment %s/%s: %w", deploy.Namespace, deploy.Name, err)
+	}
+	return pods.Items, nil
+}
+
+// component describes deploy, using the statuses of its pods for the image
+// digests.
+func component(deploy installation.Deployment, pods []corev1.Pod) Component {
+	c := Component{
+		Component: deploy.Component,
+		Namespace: deploy.Namespace,
+		Name:      deploy.Name,
+		Version:   deploy.Version,
+		Image:     deploy.Container.Image,
+	}
+
+	digests := map[string]bool{}
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != deploy.Container.Name {
+				continue
+			}
+			if _, digest, ok := strings.Cut(status.ImageID, "@"); ok && !digests[digest] {
+				digests[digest] = true
+				c.ImageDigests = append(c.ImageDigests, digest)
+			}
+		}
+	}
+	sort.Strings(c.ImageDigests)
+
+	return c
+}
+
+// mixedVersions returns a description of the versions that the components
+// run, e.g. 'v1.13.3 (controller), v1.14.0 (cainjector, webhook)', or "" if
+// they all run the same version.
+func mixedVersions(comps []Component) string {
+	byVersion := map[string][]string{}
+	for _, c := range comps {
+		version := c.Version
+		if version == "" {
+			version = "unknown"
+		}
+		byVersion[version] = append(byVersion[version], c.Component)
+	}
+	if len(byVersion) < 2 {
+		return ""
+	}
+
+	versions := make([]string, 0, len(byVersion))
+	for version := range byVersion {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+
+	parts := make([]string, 0, len(versions))
+	for _, version := range versions {
+		names := byVersion[version]
+		sort.Strings(names)
+		parts = append(parts, fmt.Sprintf("%s (%s)", version, strings.Join(names, ", ")))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// printComponents prints comps as a table.
+func (o *Options) printComponents(comps []Component) error {
+	table := o.NewTable("COMPONENT", "NAMESPACE", "NAME", "VERSION", "IMAGE", "DIGESTS").SetNameColumns(1, 2)
+	for _, c := range comps {
+		digests := strings.Join(c.ImageDigests, ",")
+		if digests == "" {
+			digests = "<none>"
+		}
+		table.AddObjectRow(c, c.Component, c.Namespace, c.Name, c.Version, c.Image, digests)
+	}
+	return table.Print(o.Out)
+}
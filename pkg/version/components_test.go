@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/cert-manager/cmctl/v2/internal/installation"
+)
+
+func deployment(name, component string, images ...string) appsv1.Deployment {
+	deploy := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "cert-manager",
+			Name:      name,
+			Labels:    map[string]string{installation.ComponentLabel: component, installation.VersionLabel: "v1.13.3"},
+		},
+	}
+	for i, image := range images {
+		deploy.Spec.Template.Spec.Containers = append(deploy.Spec.Template.Spec.Containers, corev1.Container{
+			Name:  name + "-" + string(rune('a'+i)),
+			Image: image,
+		})
+	}
+	return deploy
+}
+
+func pod(container, imageID string) corev1.Pod {
+	return corev1.Pod{
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{Name: container, ImageID: imageID}},
+		},
+	}
+}
+
+func TestComponent(t *testing.T) {
+	tests := map[string]struct {
+		deploy appsv1.Deployment
+		pods   []corev1.Pod
+		exp    Component
+	}{
+		"digests of all pods are reported once": {
+			deploy: deployment("cert-manager", "controller", "quay.io/jetstack/cert-manager-controller:v1.14.0"),
+			pods: []corev1.Pod{
+				pod("cert-manager-a", "quay.io/jetstack/cert-manager-controller@sha256:bbb"),
+				pod("cert-manager-a", "docker-pullable://quay.io/jetstack/cert-manager-controller@sha256:aaa"),
+				pod("cert-manager-a", "quay.io/jetstack/cert-manager-controller@sha256:bbb"),
+				pod("sidecar", "example.com/sidecar@sha256:ccc"),
+			},
+			exp: Component{
+				Component:    "controller",
+				Namespace:    "cert-manager",
+				Name:         "cert-manager",
+				Version:      "v1.14.0",
+				Image:        "quay.io/jetstack/cert-manager-controller:v1.14.0",
+				ImageDigests: []string{"sha256:aaa", "sha256:bbb"},
+			},
+		},
+		"the version label is used for images without a tag": {
+			deploy: deployment("webhook", "webhook", "example.com/sidecar:v1", "registry.local/cert-manager-webhook@sha256:aaa"),
+			exp: Component{
+				Component: "webhook",
+				Namespace: "cert-manager",
+				Name:      "webhook",
+				Version:   "v1.13.3",
+				Image:     "registry.local/cert-manager-webhook@sha256:aaa",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			deploy, ok := installation.ComponentDeployment(test.deploy)
+			if !ok {
+				t.Fatal("expected a cert-manager component")
+			}
+			got := component(deploy, test.pods)
+			if !reflect.DeepEqual(got, test.exp) {
+				t.Errorf("unexpected component, exp=%+v got=%+v", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestListComponentsWithoutPods(t *testing.T) {
+	deploy := deployment("cert-manager", "controller", "quay.io/jetstack/cert-manager-controller:v1.14.0")
+	deploy.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "cert-manager"}}
+	kubeClient := fake.NewSimpleClientset(&deploy)
+	kubeClient.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", nil)
+	})
+
+	comps, err := listComponents(context.Background(), kubeClient)
+	if !apierrors.IsForbidden(err) {
+		t.Errorf("expected the Forbidden error to be returned, got %v", err)
+	}
+	exp := []Component{{
+		Component: "controller",
+		Namespace: "cert-manager",
+		Name:      "cert-manager",
+		Version:   "v1.14.0",
+		Image:     "quay.io/jetstack/cert-manager-controller:v1.14.0",
+	}}
+	if !reflect.DeepEqual(comps, exp) {
+		t.Errorf("expected the component without digests, exp=%+v got=%+v", exp, comps)
+	}
+}
+
+func TestMixedVersions(t *testing.T) {
+	tests := map[string]struct {
+		comps []Component
+		exp   string
+	}{
+		"same version": {
+			comps: []Component{
+				{Component: "controller", Version: "v1.14.0"},
+				{Component: "webhook", Version: "v1.14.0"},
+			},
+		},
+		"different versions": {
+			comps: []Component{
+				{Component: "webhook", Version: "v1.14.0"},
+				{Component: "controller", Version: "v1.13.3"},
+				{Component: "cainjector", Version: "v1.14.0"},
+			},
+			exp: "v1.13.3 (controller), v1.14.0 (cainjector, webhook)",
+		},
+		"unknown version": {
+			comps: []Component{
+				{Component: "controller", Version: "v1.14.0"},
+				{Component: "webhook"},
+			},
+			exp: "unknown (webhook), v1.14.0 (controller)",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := mixedVersions(test.comps); got != test.exp {
+				t.Errorf("unexpected result, exp=%q got=%q", test.exp, got)
+			}
+		})
+	}
+}
@@ -18,18 +18,17 @@ package version
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
-	cmdutil "k8s.io/kubectl/pkg/cmd/util"
-	"sigs.k8s.io/yaml"
 
 	"github.com/cert-manager/cert-manager/pkg/util"
 	"github.com/cert-manager/cert-manager/pkg/util/versionchecker"
+	"github.com/cert-manager/cmctl/v2/internal/printers"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
 	"github.com/cert-manager/cmctl/v2/pkg/build"
 	"github.com/cert-manager/cmctl/v2/pkg/factory"
 )
@@ -38,6 +37,9 @@ import (
 type Version struct {
 	ClientVersion *util.Version           `json:"clientVersion,omitempty"`
 	ServerVersion *versionchecker.Version `json:"serverVersion,omitempty"`
+	// Components are the deployed cert-manager components with their
+	// versions and images.
+	Components []Component `json:"components,omitempty"`
 }
 
 // Options is a struct to support version command
@@ -49,9 +51,14 @@ type Options struct {
 	Short bool
 
 	// Output is the target output format for the version string. This may be of
-	// value "", "json" or "yaml".
+	// value "" or one of the formats supported by printers.NewPrinter.
 	Output string
 
+	printer *printers.Printer
+
+	// TableOptions configure the table of the deployed components.
+	printers.TableOptions
+
 	VersionChecker versionchecker.Interface
 
 	genericclioptions.IOStreams
@@ -77,6 +84,10 @@ version information, the tool checks if all versions are the same and returns
 that version. If no version information is found or the found versions differ,
 an error will be displayed.
 
+The version and image of every deployed cert-manager component (the controller, webhook
+and cainjector Deployments) are printed as well, including the digests of the images
+that their Pods run. A warning is printed if the components run different versions.
+
 The '--client' flag can be used to disable the logic that tries to determine the installed
 cert-manager version.
 
@@ -88,6 +99,10 @@ or
 	$ {{.BuildName}} version --short
 or
 	$ {{.BuildName}} version -o yaml
+or
+	$ {{.BuildName}} version -o jsonpath='{.serverVersion.detected}'
+or
+	$ {{.BuildName}} version -o jsonpath='{range .components[*]}{.component}{"\t"}{.imageDigests}{"\n"}{end}'
 `)
 }
 
@@ -100,29 +115,39 @@ func NewCmdVersion(ctx context.Context, ioStreams genericclioptions.IOStreams) *
 		Short: "Print the cert-manager CLI version and the deployed cert-manager version",
 		Long:  versionLong(),
 		Run: func(cmd *cobra.Command, args []string) {
-			cmdutil.CheckErr(o.Validate())
-			cmdutil.CheckErr(o.Complete())
-			cmdutil.CheckErr(o.Run(ctx))
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate()))
+			cmcmdutil.CheckErr(o.Complete())
+			cmcmdutil.CheckErr(o.Run(ctx))
 		},
 	}
 
 	cmd.Flags().BoolVar(&o.ClientOnly, "client", o.ClientOnly, "If true, shows client version only (no server required).")
 	cmd.Flags().BoolVar(&o.Short, "short", o.Short, "If true, print just the version number.")
-	cmd.Flags().StringVarP(&o.Output, "output", "o", o.Output, "One of 'yaml' or 'json'.")
+	cmd.Flags().StringVarP(&o.Output, "output", "o", o.Output, "One of "+printers.PrinterFormats+".")
+	cmcmdutil.AllowOutputFromEnv(cmd.Flags(), "json", "yaml")
+	o.TableOptions.AddFlags(cmd.Flags())
 
-	o.Factory = factory.New(ctx, cmd)
+	// The client version is printed without loading a kubeconfig.
+	o.Factory = factory.New(ctx, cmd, factory.SkipWhen(func() bool { return o.ClientOnly }))
 
 	return cmd
 }
 
 // Validate validates the provided options
 func (o *Options) Validate() error {
-	switch o.Output {
-	case "", "yaml", "json":
-		return nil
-	default:
-		return errors.New(`--output must be '', 'yaml' or 'json'`)
+	if o.Output != "" {
+		var err error
+		o.printer, err = printers.NewPrinter(o.Output)
+		if err != nil {
+			return err
+		}
+	}
+
+	if (o.Output != "" || o.Short || o.ClientOnly) && (o.NoHeaders || o.Quiet || o.SortBy != "" || o.Filter != "") {
+		return errors.New("--no-headers, --quiet, --sort-by and --filter can only be used with the table of components")
 	}
+
+	return o.TableOptions.Validate()
 }
 
 // Complete takes the command arguments and factory and infers any remaining options.
@@ -156,38 +181,45 @@ func (o *Options) Run(ctx context.Context) error {
 	if !o.ClientOnly {
 		serverVersion, serverErr = o.VersionChecker.Version(ctx)
 		versionInfo.ServerVersion = serverVersion
-	}
 
-	switch o.Output {
-	case "":
-		if o.Short {
-			fmt.Fprintf(o.Out, "Client Version: %s\n", clientVersion.GitVersion)
-			if serverVersion != nil {
-				fmt.Fprintf(o.Out, "Server Version: %s\n", serverVersion.Detected)
-			}
-		} else {
-			fmt.Fprintf(o.Out, "Client Version: %s\n", fmt.Sprintf("%#v", clientVersion))
-			if serverVersion != nil {
-				fmt.Fprintf(o.Out, "Server Version: %s\n", fmt.Sprintf("%#v", serverVersion))
-			}
+		// The components are reported in addition to the server version, so
+		// failing to list them, e.g. because of RBAC, is not an error.
+		comps, err := listComponents(ctx, o.KubeClient)
+		switch {
+		case err != nil && len(comps) == 0:
+			fmt.Fprintf(o.ErrOut, "Unable to list cert-manager components: %v\n", err)
+		case err != nil:
+			fmt.Fprintf(o.ErrOut, "Unable to find the image digests of cert-manager components: %v\n", err)
 		}
-	case "yaml":
-		marshalled, err := yaml.Marshal(&versionInfo)
-		if err != nil {
-			return err
+		versionInfo.Components = comps
+		if mixed := mixedVersions(comps); mixed != "" {
+			fmt.Fprintf(o.ErrOut, "Warning: cert-manager components run different versions: %s\n", mixed)
 		}
-		fmt.Fprint(o.Out, string(marshalled))
-	case "json":
-		marshalled, err := json.MarshalIndent(&versionInfo, "", "  ")
-		if err != nil {
+	}
+
+	if o.printer != nil {
+		if err := o.printer.Print(o.Out, &versionInfo); err != nil {
 			return err
 		}
-		fmt.Fprintln(o.Out, string(marshalled))
-	default:
-		// There is a bug in the program if we hit this case.
-		// However, we follow a policy of never panicking.
-		return fmt.Errorf("VersionOptions were not validated: --output=%q should have been rejected", o.Output)
+		return serverErr
 	}
 
+	if o.Short {
+		fmt.Fprintf(o.Out, "Client Version: %s\n", clientVersion.GitVersion)
+		if serverVersion != nil {
+			fmt.Fprintf(o.Out, "Server Version: %s\n", serverVersion.Detected)
+		}
+	} else {
+		fmt.Fprintf(o.Out, "Client Version: %s\n", fmt.Sprintf("%#v", clientVersion))
+		if serverVersion != nil {
+			fmt.Fprintf(o.Out, "Server Version: %s\n", fmt.Sprintf("%#v", serverVersion))
+		}
+		if len(versionInfo.Components) > 0 {
+			fmt.Fprintln(o.Out)
+			if err := o.printComponents(versionInfo.Components); err != nil {
+				return err
+			}
+		}
+	}
 	return serverErr
 }
@@ -0,0 +1,239 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package whichcert
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const none = "<none>"
+
+// match is a certificate that covers the requested hostname. The JSON names
+// are used by JSONPath expressions in --sort-by and --filter.
+type match struct {
+	Namespace   string `json:"namespace"`
+	Secret      string `json:"secret"`
+	Certificate string `json:"certificate"`
+	Issuer      string `json:"issuer"`
+	Expires     string `json:"expires"`
+
+	// The following fields are only printed with '-o wide'.
+	IssuerGroup  string `json:"issuerGroup"`
+	KeyAlgorithm string `json:"keyAlgorithm"`
+	Revision     string `json:"revision"`
+	LastFailure  string `json:"lastFailure"`
+}
+
+// coveringSecret is a Secret whose certificate is valid for the requested
+// hostname, together with that decoded certificate.
+type coveringSecret struct {
+	metav1.ObjectMeta
+	cert *x509.Certificate
+}
+
+// findMatches returns the covering Secrets, and the Certificates whose spec
+// covers host but whose Secret does not.
+func findMatches(host string, secrets []coveringSecret, crts []cmapi.Certificate) []match {
+	var matches []match
+	seen := map[string]bool{}
+
+	byName := map[string]*cmapi.Certificate{}
+	for i := range crts {
+		byName[crts[i].Namespace+"/"+crts[i].Name] = &crts[i]
+	}
+
+	for _, secret := range secrets {
+		m := match{
+			Namespace:    secret.Namespace,
+			Secret:       secret.Name,
+			Certificate:  none,
+			Issuer:       "x509:" + secret.cert.Issuer.CommonName,
+			Expires:      secret.cert.NotAfter.Format(time.RFC3339),
+			IssuerGroup:  none,
+			KeyAlgorithm: x509KeyAlgorithm(secret.cert),
+			Revision:     none,
+			LastFailure:  none,
+		}
+		if name, ok := secret.Annotations[cmapi.CertificateNameKey]; ok {
+			m.Certificate = name
+			m.Issuer = issuerString(secret.Annotations[cmapi.IssuerKindAnnotationKey], secret.Annotations[cmapi.IssuerNameAnnotationKey])
+			m.IssuerGroup = issuerGroup(secret.Annotations[cmapi.IssuerGroupAnnotationKey])
+			if crt, ok := byName[secret.Namespace+"/"+name]; ok {
+				m.Revision, m.LastFailure = revision(crt), lastFailure(crt)
+			}
+		}
+		matches = append(matches, m)
+		seen[secret.Namespace+"/"+secret.Name] = true
+	}
+
+	for _, crt := range crts {
+		if seen[crt.Namespace+"/"+crt.Spec.SecretName] || !certificateCovers(&crt, host) {
+			continue
+		}
+
+		expires := "<not issued>"
+		if crt.Status.NotAfter != nil {
+			expires = crt.Status.NotAfter.Time.Format(time.RFC3339)
+		}
+		matches = append(matches, match{
+			Namespace:    crt.Namespace,
+			Secret:       crt.Spec.SecretName,
+			Certificate:  crt.Name,
+			Issuer:       issuerString(crt.Spec.IssuerRef.Kind, crt.Spec.IssuerRef.Name),
+			Expires:      expires,
+			IssuerGroup:  issuerGroup(crt.Spec.IssuerRef.Group),
+			KeyAlgorithm: specKeyAlgorithm(crt.Spec.PrivateKey),
+			Revision:     revision(&crt),
+			LastFailure:  lastFailure(&crt),
+		})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Namespace != matches[j].Namespace {
+			return matches[i].Namespace < matches[j].Namespace
+		}
+		return matches[i].Secret < matches[j].Secret
+	})
+	return matches
+}
+
+// secretCovers decodes the certificate stored in secret and returns it if it
+// is valid for host.
+func secretCovers(secret *corev1.Secret, host string) (coveringSecret, bool) {
+	cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil || cert.VerifyHostname(host) != nil {
+		return coveringSecret{}, false
+	}
+	return coveringSecret{ObjectMeta: secret.ObjectMeta, cert: cert}, true
+}
+
+func certificateCovers(crt *cmapi.Certificate, host string) bool {
+	names := append([]string{crt.Spec.CommonName}, crt.Spec.DNSNames...)
+	names = append(names, crt.Spec.IPAddresses...)
+	for _, name := range names {
+		if matchHostname(name, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchHostname returns true if pattern, which may contain a wildcard in its
+// left-most label, matches host.
+func matchHostname(pattern, host string) bool {
+	pattern = strings.ToLower(strings.TrimSuffix(pattern, "."))
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if pattern == "" || host == "" {
+		return false
+	}
+	if pattern == host {
+		return true
+	}
+
+	suffix, ok := strings.CutPrefix(pattern, "*.")
+	if !ok {
+		return false
+	}
+	label, rest, ok := strings.Cut(host, ".")
+	return ok && label != "" && rest == suffix
+}
+
+func issuerGroup(group string) string {
+	if group == "" {
+		return "cert-manager.io"
+	}
+	return group
+}
+
+func revision(crt *cmapi.Certificate) string {
+	if crt.Status.Revision == nil {
+		return none
+	}
+	return strconv.Itoa(*crt.Status.Revision)
+}
+
+func lastFailure(crt *cmapi.Certificate) string {
+	if crt.Status.LastFailureTime == nil {
+		return none
+	}
+	return crt.Status.LastFailureTime.Time.Format(time.RFC3339)
+}
+
+// x509KeyAlgorithm describes the public key of cert, e.g. "ECDSA P-256".
+func x509KeyAlgorithm(cert *x509.Certificate) string {
+	switch pub := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return fmt.Sprintf("RSA %d", pub.N.BitLen())
+	case *ecdsa.PublicKey:
+		return "ECDSA " + pub.Curve.Params().Name
+	case ed25519.PublicKey:
+		return "Ed25519"
+	}
+	return cert.PublicKeyAlgorithm.String()
+}
+
+// specKeyAlgorithm describes the private key requested by a Certificate spec
+// in the same format as x509KeyAlgorithm, applying cert-manager's defaults.
+func specKeyAlgorithm(pk *cmapi.CertificatePrivateKey) string {
+	alg, size := cmapi.RSAKeyAlgorithm, 0
+	if pk != nil {
+		if pk.Algorithm != "" {
+			alg = pk.Algorithm
+		}
+		size = pk.Size
+	}
+
+	switch alg {
+	case cmapi.RSAKeyAlgorithm:
+		if size == 0 {
+			size = 2048
+		}
+		return fmt.Sprintf("RSA %d", size)
+	case cmapi.ECDSAKeyAlgorithm:
+		if size == 0 {
+			size = 256
+		}
+		return fmt.Sprintf("ECDSA P-%d", size)
+	case cmapi.Ed25519KeyAlgorithm:
+		return "Ed25519"
+	}
+	return string(alg)
+}
+
+func issuerString(kind, name string) string {
+	if name == "" {
+		return none
+	}
+	if kind == "" {
+		kind = cmapi.IssuerKind
+	}
+	return kind + "/" + name
+}
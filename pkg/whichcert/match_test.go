@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package whichcert
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func TestMatchHostname(t *testing.T) {
+	tests := map[string]struct {
+		pattern, host string
+		exp           bool
+	}{
+		"exact match":                        {pattern: "app.example.com", host: "app.example.com", exp: true},
+		"case and trailing dot are ignored":  {pattern: "App.Example.com.", host: "app.example.com", exp: true},
+		"different host":                     {pattern: "api.example.com", host: "app.example.com"},
+		"wildcard matches one label":         {pattern: "*.example.com", host: "app.example.com", exp: true},
+		"wildcard does not match apex":       {pattern: "*.example.com", host: "example.com"},
+		"wildcard does not match two labels": {pattern: "*.example.com", host: "a.app.example.com"},
+		"empty pattern":                      {pattern: "", host: "example.com"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := matchHostname(test.pattern, test.host); got != test.exp {
+				t.Errorf("unexpected result, exp=%t got=%t", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestFindMatches(t *testing.T) {
+	key, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template, err := pki.GenerateTemplate(&cmapi.Certificate{
+		Spec: cmapi.CertificateSpec{DNSNames: []string{"*.example.com"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM, _, err := pki.SignCertificate(template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revision := 3
+	failed := metav1.NewTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	list := []corev1.Secret{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "b", Name: "managed", Annotations: map[string]string{
+				cmapi.CertificateNameKey:      "wildcard",
+				cmapi.IssuerKindAnnotationKey: cmapi.ClusterIssuerKind,
+				cmapi.IssuerNameAnnotationKey: "letsencrypt",
+			}},
+			Data: map[string][]byte{corev1.TLSCertKey: certPEM},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "a", Name: "unmanaged"},
+			Data:       map[string][]byte{corev1.TLSCertKey: certPEM},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "a", Name: "empty"},
+		},
+	}
+	var secrets []coveringSecret
+	for i := range list {
+		if secret, ok := secretCovers(&list[i], "app.example.com"); ok {
+			secrets = append(secrets, secret)
+		}
+	}
+
+	crts := []cmapi.Certificate{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "b", Name: "wildcard"},
+			Spec: cmapi.CertificateSpec{
+				SecretName: "managed",
+				DNSNames:   []string{"*.example.com"},
+				IssuerRef:  cmmeta.ObjectReference{Name: "letsencrypt", Kind: cmapi.ClusterIssuerKind},
+			},
+			Status: cmapi.CertificateStatus{Revision: &revision},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "c", Name: "pending"},
+			Spec: cmapi.CertificateSpec{
+				SecretName: "pending-tls",
+				DNSNames:   []string{"app.example.com"},
+				IssuerRef:  cmmeta.ObjectReference{Name: "ca"},
+				PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.RSAKeyAlgorithm, Size: 4096},
+			},
+			Status: cmapi.CertificateStatus{LastFailureTime: &failed},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "c", Name: "other"},
+			Spec:       cmapi.CertificateSpec{SecretName: "other-tls", DNSNames: []string{"other.example.org"}},
+		},
+	}
+
+	expires := template.NotAfter.UTC().Format(time.RFC3339)
+	exp := []match{
+		{
+			Namespace: "a", Secret: "unmanaged", Certificate: none, Issuer: "x509:", Expires: expires,
+			IssuerGroup: none, KeyAlgorithm: "ECDSA P-256", Revision: none, LastFailure: none,
+		},
+		{
+			Namespace: "b", Secret: "managed", Certificate: "wildcard", Issuer: "ClusterIssuer/letsencrypt", Expires: expires,
+			IssuerGroup: "cert-manager.io", KeyAlgorithm: "ECDSA P-256", Revision: "3", LastFailure: none,
+		},
+		{
+			Namespace: "c", Secret: "pending-tls", Certificate: "pending", Issuer: "Issuer/ca", Expires: "<not issued>",
+			IssuerGroup: "cert-manager.io", KeyAlgorithm: "RSA 4096", Revision: none, LastFailure: "2024-01-02T03:04:05Z",
+		},
+	}
+
+	got := findMatches("app.example.com", secrets, crts)
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("unexpected matches,\nexp=%v\ngot=%v", exp, got)
+	}
+}
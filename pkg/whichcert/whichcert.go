@@ -0,0 +1,185 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package whichcert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cmctl/v2/internal/printers"
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Find the Certificates and TLS Secrets that cover a hostname.
+
+All TLS Secrets whose certificate is valid for the hostname are listed, including
+Secrets that are not managed by cert-manager, together with the Certificates whose
+spec covers the hostname but that have not been issued yet. Wildcard names are
+taken into account.
+
+All namespaces are searched, unless a namespace is given with --namespace and
+--all-namespaces is not set.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Find the certificates that serve 'app.example.com'
+{{.BuildName}} which-cert app.example.com
+
+# Only search the 'my-namespace' namespace
+{{.BuildName}} which-cert app.example.com --namespace my-namespace
+
+# Search all namespaces, ignoring --namespace
+{{.BuildName}} which-cert app.example.com -A
+
+# Also show the issuer group, key algorithm, revision and last failure time
+{{.BuildName}} which-cert app.example.com -o wide
+
+# Only show certificates managed by cert-manager, the soonest to expire first
+{{.BuildName}} which-cert app.example.com --filter 'certificate!=<none>' --sort-by expires`)))
+)
+
+// Options is a struct to support which-cert command
+type Options struct {
+	// Output is the output format, either "" or "wide".
+	Output        string
+	AllNamespaces bool
+
+	printers.TableOptions
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdWhichCert returns a cobra command for which-cert
+func NewCmdWhichCert(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "which-cert",
+		Short:   "Find the certificates that cover a hostname",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmcmdutil.CheckErr(cmcmdutil.ValidationError(o.Validate(args)))
+			cmcmdutil.CheckErr(o.Run(ctx, args))
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.Output, "output", "o", o.Output, "Output format. One of '' or 'wide'. 'wide' adds the issuer group, key algorithm, revision and last failure time.")
+	factory.AddAllNamespacesFlag(cmd, &o.AllNamespaces, "search Secrets and Certificates")
+	o.TableOptions.AddFlags(cmd.Flags())
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) < 1 {
+		return errors.New("the hostname has to be provided as argument")
+	}
+	if len(args) > 1 {
+		return errors.New("only one argument can be passed in: the hostname")
+	}
+	switch o.Output {
+	case "":
+	case "wide":
+		o.Wide = true
+	default:
+		return errors.New(`--output must be '' or 'wide'`)
+	}
+	return o.TableOptions.Validate()
+}
+
+// Run executes which-cert command
+func (o *Options) Run(ctx context.Context, args []string) error {
+	host := args[0]
+
+	ns := o.NamespaceOrAll(o.AllNamespaces || !o.EnforceNamespace)
+
+	cmcmdutil.LogListFilters(ctx, "Certificates", ns, metav1.ListOptions{}, "spec covers "+host)
+	var crts []cmapi.Certificate
+	err := cmcmdutil.ListPages(ctx, metav1.ListOptions{}, func(opts metav1.ListOptions) (string, error) {
+		list, err := o.CMClient.CertmanagerV1().Certificates(ns).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		crts = append(crts, list.Items...)
+		return list.Continue, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error when listing Certificate resources: %w", err)
+	}
+
+	// TLS Secrets are by far the largest objects that are listed, so only the
+	// ones that cover host are kept from every page.
+	opts := metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("type", string(corev1.SecretTypeTLS)).String(),
+	}
+	cmcmdutil.LogListFilters(ctx, "Secrets", ns, opts, "certificate covers "+host)
+
+	var secrets []coveringSecret
+	err = cmcmdutil.ListPages(ctx, opts, func(opts metav1.ListOptions) (string, error) {
+		list, err := o.KubeClient.CoreV1().Secrets(ns).List(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		for i := range list.Items {
+			if secret, ok := secretCovers(&list.Items[i], host); ok {
+				secrets = append(secrets, secret)
+			}
+		}
+		return list.Continue, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error when listing Secret resources: %w", err)
+	}
+
+	matches := findMatches(host, secrets, crts)
+	if len(matches) == 0 {
+		fmt.Fprintf(o.ErrOut, "No certificates found for %q\n", host)
+		return nil
+	}
+
+	table := o.NewTable("NAMESPACE", "SECRET", "CERTIFICATE", "ISSUER", "EXPIRES").SetNameColumns(0, 1).
+		WideColumns("ISSUER GROUP", "KEY ALGORITHM", "REVISION", "LAST FAILURE")
+	for _, m := range matches {
+		table.AddObjectRow(m, m.Namespace, m.Secret, m.Certificate, m.Issuer, m.Expires,
+			m.IssuerGroup, m.KeyAlgorithm, m.Revision, m.LastFailure)
+	}
+	return table.Print(o.Out)
+}
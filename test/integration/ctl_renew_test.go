@@ -166,6 +166,7 @@ func TestCtlRenew(t *testing.T) {
 				LabelSelector: test.inputLabels,
 				All:           test.inputAll,
 				AllNamespaces: test.inputAllNamespaces,
+				Yes:           true,
 				Factory: &factory.Factory{
 					CMClient:   cmCl,
 					RESTConfig: config,